@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/metadata"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Metadata keys read by employee-service to identify the caller.
+const (
+	CallerIdKey          = "x-caller-id"
+	CallerPermissionsKey = "x-caller-permissions"
+	CallerEmployeeIdKey  = "x-caller-employee-id"
+)
+
+const (
+	APIKeyHeader = "X-API-Key"
+	callerKey    = "caller"
+)
+
+type Caller struct {
+	Id          string   `json:"caller_id"`
+	Permissions []string `json:"permissions"`
+	// EmployeeId binds the key to an employee, who may then act on their own
+	// leave requests and those of their reports.
+	EmployeeId int32 `json:"employee_id,omitempty"`
+}
+
+type apiKey struct {
+	Key string `json:"key"`
+	Caller
+}
+
+// minAPIKeyLength rejects guessable keys, such as placeholders copied from
+// api_keys.example.json.
+const minAPIKeyLength = 32
+
+// LoadAPIKeys parses keys, or without them the file at path, usually a secret
+// mount: a JSON array of API keys with the caller and permissions each of them
+// grants.
+func LoadAPIKeys(keys, path string) (map[string]Caller, error) {
+	data := []byte(keys)
+	if keys == "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("auth: read api keys: %w", err)
+		}
+	}
+
+	var parsed []apiKey
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("auth: parse api keys: %w", err)
+	}
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("auth: no api keys")
+	}
+
+	callers := make(map[string]Caller, len(parsed))
+	for i, key := range parsed {
+		if len(key.Key) < minAPIKeyLength {
+			return nil, fmt.Errorf("auth: api key %d (%s) is shorter than %d characters", i, key.Id, minAPIKeyLength)
+		}
+		if _, ok := callers[key.Key]; ok {
+			return nil, fmt.Errorf("auth: api key %d (%s) is repeated", i, key.Id)
+		}
+		callers[key.Key] = key.Caller
+	}
+	return callers, nil
+}
+
+// Middleware resolves the caller from the X-API-Key header. Requests without a
+// key are served as an anonymous caller with no permissions, requests with an
+// unknown key are rejected.
+func Middleware(callers map[string]Caller) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(APIKeyHeader)
+		if key == "" {
+			c.Set(callerKey, Caller{})
+			c.Next()
+			return
+		}
+
+		caller, ok := callers[key]
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, map[string]interface{}{"gw_auth": "invalid api key"})
+			return
+		}
+		c.Set(callerKey, caller)
+		c.Next()
+	}
+}
+
+func CallerFrom(c *gin.Context) Caller {
+	caller, _ := c.Get(callerKey)
+	resolved, _ := caller.(Caller)
+	return resolved
+}
+
+// OutgoingContext attaches the caller to ctx as gRPC metadata.
+func OutgoingContext(ctx context.Context, caller Caller) context.Context {
+	if caller.Id == "" {
+		return ctx
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx,
+		CallerIdKey, caller.Id,
+		CallerPermissionsKey, strings.Join(caller.Permissions, ","))
+	if caller.EmployeeId != 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, CallerEmployeeIdKey, strconv.Itoa(int(caller.EmployeeId)))
+	}
+	return ctx
+}
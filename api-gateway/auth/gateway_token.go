@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// GatewayTokenKey is the metadata key of the secret shared with
+// employee-service, which only trusts the caller metadata of calls that carry
+// it.
+const GatewayTokenKey = "x-gateway-token"
+
+// LoadGatewayToken returns token, or without it the contents of the file at
+// path, usually a secret mount.
+func LoadGatewayToken(token, path string) (string, error) {
+	if token == "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("auth: read gateway token: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	if token == "" {
+		return "", fmt.Errorf("auth: gateway token is empty")
+	}
+	return token, nil
+}
+
+// GatewayCredentials attaches the gateway token to every call to
+// employee-service. The connection is inside the private network, so the
+// token is sent without transport security.
+type GatewayCredentials struct {
+	Token string
+}
+
+func (c GatewayCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{GatewayTokenKey: c.Token}, nil
+}
+
+func (c GatewayCredentials) RequireTransportSecurity() bool {
+	return false
+}
@@ -2,12 +2,36 @@ package config
 
 import (
 	"github.com/spf13/viper"
+	"strings"
+	"time"
 )
 
 type Config struct {
 	Address      string
 	GatewayPort  string
 	EmployeePort string
+	MetricsPort  string
+	APIKeys      string
+	APIKeysPath  string
+
+	GatewayToken     string
+	GatewayTokenPath string
+
+	ShutdownTimeout time.Duration
+
+	RateLimitDefault string
+	RateLimitRoutes  string
+	RateLimitPerIP   string
+	// TrustedProxies lists the proxies whose X-Forwarded-For header is used
+	// for the client IP. Empty means the gateway is not behind a proxy.
+	TrustedProxies []string
+
+	RequestTimeoutDefault string
+	RequestTimeoutRoutes  string
+
+	TracingExporter     string
+	TracingOTLPEndpoint string
+	TracingFilePath     string
 }
 
 func LoadConfig() (*Config, error) {
@@ -16,11 +40,62 @@ func LoadConfig() (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	// API keys and the gateway token come from the environment or a secret
+	// mount, never from config.env.
+	if err = viper.BindEnv("API_KEYS"); err != nil {
+		return nil, err
+	}
+	if err = viper.BindEnv("API_KEYS_PATH"); err != nil {
+		return nil, err
+	}
+	if err = viper.BindEnv("GATEWAY_TOKEN"); err != nil {
+		return nil, err
+	}
+	if err = viper.BindEnv("GATEWAY_TOKEN_PATH"); err != nil {
+		return nil, err
+	}
+	// The deployment picks the tracing exporter, see docker-compose.yml.
+	if err = viper.BindEnv("TRACING_EXPORTER"); err != nil {
+		return nil, err
+	}
+	if err = viper.BindEnv("TRACING_OTLP_ENDPOINT"); err != nil {
+		return nil, err
+	}
 
 	config := &Config{
 		Address:      viper.GetString("ADDRESS"),
 		GatewayPort:  viper.GetString("GATEWAY_PORT"),
 		EmployeePort: viper.GetString("EMPLOYEE_PORT"),
+		MetricsPort:  viper.GetString("METRICS_PORT"),
+		APIKeys:      viper.GetString("API_KEYS"),
+		APIKeysPath:  viper.GetString("API_KEYS_PATH"),
+
+		GatewayToken:     viper.GetString("GATEWAY_TOKEN"),
+		GatewayTokenPath: viper.GetString("GATEWAY_TOKEN_PATH"),
+
+		ShutdownTimeout: viper.GetDuration("SHUTDOWN_TIMEOUT"),
+
+		RateLimitDefault: viper.GetString("RATE_LIMIT_DEFAULT"),
+		RateLimitRoutes:  viper.GetString("RATE_LIMIT_ROUTES"),
+		RateLimitPerIP:   viper.GetString("RATE_LIMIT_PER_IP"),
+		TrustedProxies:   splitList(viper.GetString("TRUSTED_PROXIES")),
+
+		RequestTimeoutDefault: viper.GetString("REQUEST_TIMEOUT_DEFAULT"),
+		RequestTimeoutRoutes:  viper.GetString("REQUEST_TIMEOUT_ROUTES"),
+
+		TracingExporter:     viper.GetString("TRACING_EXPORTER"),
+		TracingOTLPEndpoint: viper.GetString("TRACING_OTLP_ENDPOINT"),
+		TracingFilePath:     viper.GetString("TRACING_FILE_PATH"),
 	}
 	return config, nil
 }
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
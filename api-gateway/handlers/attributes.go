@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"api-gateway/proto"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type AttributeHandlers struct {
+	attributeClient proto.AttributeServiceClient
+}
+
+func NewAttributeHandler(attributeClient proto.AttributeServiceClient) *AttributeHandlers {
+	return &AttributeHandlers{attributeClient: attributeClient}
+}
+
+func (h *AttributeHandlers) CreateAttribute(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+
+	var createRequest proto.CreateAttributeRequest
+	if err := c.BindJSON(&createRequest); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: create attribute: bind:": err.Error()})
+		return
+	}
+	createRequest.CompanyId = companyId
+
+	created, err := h.attributeClient.CreateAttribute(callContext(c), &createRequest)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: create attribute: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *AttributeHandlers) ListAttributes(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+
+	list, err := h.attributeClient.ListAttributes(callContext(c), &proto.ListAttributesRequest{CompanyId: companyId})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: list attributes: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+func (h *AttributeHandlers) UpdateAttribute(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+	attributeId, ok := int32Param(c, "attribute_id")
+	if !ok {
+		return
+	}
+
+	var updateRequest proto.UpdateAttributeRequest
+	if err := c.BindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: update attribute: bind:": err.Error()})
+		return
+	}
+	updateRequest.CompanyId = companyId
+	updateRequest.Id = attributeId
+
+	updated, err := h.attributeClient.UpdateAttribute(callContext(c), &updateRequest)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: update attribute: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+func (h *AttributeHandlers) DeleteAttribute(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+	attributeId, ok := int32Param(c, "attribute_id")
+	if !ok {
+		return
+	}
+
+	success, err := h.attributeClient.DeleteAttribute(callContext(c),
+		&proto.DeleteAttributeRequest{CompanyId: companyId, Id: attributeId})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: delete attribute: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, success)
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"api-gateway/proto"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// batchRequest is the body of POST /employees:batch. Exactly one of Add,
+// Update and Delete must be set.
+type batchRequest struct {
+	Mode   string                         `json:"mode"`
+	Add    []*proto.AddEmployeeRequest    `json:"add"`
+	Update []*proto.UpdateEmployeeRequest `json:"update"`
+	Delete []int32                        `json:"delete"`
+}
+
+// BatchEmployees serves POST /employees:batch. gin registers that route as the
+// parameter batch following /employees, which also matches paths such as
+// /employeesX, so anything but the literal ":batch" is not found.
+func (h *Handlers) BatchEmployees(c *gin.Context) {
+	if c.Param("batch") != ":batch" {
+		c.JSON(http.StatusNotFound, map[string]interface{}{"gw_handlers: batch employees:": "not found"})
+		return
+	}
+
+	var batch batchRequest
+	if err := c.BindJSON(&batch); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: batch employees: bind:": err.Error()})
+		return
+	}
+
+	operations := 0
+	for _, set := range []bool{len(batch.Add) > 0, len(batch.Update) > 0, len(batch.Delete) > 0} {
+		if set {
+			operations++
+		}
+	}
+	if operations != 1 {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"gw_handlers: batch employees:": "exactly one of add, update and delete must be set"})
+		return
+	}
+
+	var resp *proto.BatchEmployeesResponse
+	var err error
+	switch {
+	case len(batch.Add) > 0:
+		resp, err = h.employeeClient.BatchAddEmployees(callContext(c),
+			&proto.BatchAddEmployeesRequest{Mode: batch.Mode, Employees: batch.Add})
+	case len(batch.Update) > 0:
+		resp, err = h.employeeClient.BatchUpdateEmployees(callContext(c),
+			&proto.BatchUpdateEmployeesRequest{Mode: batch.Mode, Employees: batch.Update})
+	default:
+		resp, err = h.employeeClient.BatchDeleteEmployees(callContext(c),
+			&proto.BatchDeleteEmployeesRequest{Mode: batch.Mode, Ids: batch.Delete})
+	}
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: batch employees: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
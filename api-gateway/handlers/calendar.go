@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"api-gateway/ical"
+	"api-gateway/proto"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+type CalendarHandlers struct {
+	calendarClient proto.CalendarServiceClient
+}
+
+func NewCalendarHandler(calendarClient proto.CalendarServiceClient) *CalendarHandlers {
+	return &CalendarHandlers{calendarClient: calendarClient}
+}
+
+func (h *CalendarHandlers) CreateCalendarFeed(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+
+	var createRequest proto.CreateCalendarFeedRequest
+	if err := bindOptionalJSON(c, &createRequest); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: create calendar feed: bind:": err.Error()})
+		return
+	}
+	createRequest.CompanyId = companyId
+
+	created, err := h.calendarClient.CreateCalendarFeed(callContext(c), &createRequest)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: create calendar feed: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *CalendarHandlers) ListCalendarFeeds(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+
+	list, err := h.calendarClient.ListCalendarFeeds(callContext(c), &proto.ListCalendarFeedsRequest{CompanyId: companyId})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: list calendar feeds: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+func (h *CalendarHandlers) RevokeCalendarFeed(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+	feedId, ok := int32Param(c, "feed_id")
+	if !ok {
+		return
+	}
+
+	revoked, err := h.calendarClient.RevokeCalendarFeed(callContext(c),
+		&proto.RevokeCalendarFeedRequest{CompanyId: companyId, Id: feedId})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: revoke calendar feed: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, revoked)
+}
+
+// GetCalendarFeed serves a feed as an iCalendar file. The token is passed in
+// the query rather than the path, which is written to access logs and traces.
+func (h *CalendarHandlers) GetCalendarFeed(c *gin.Context) {
+	feed, err := h.calendarClient.GetCalendarFeedEvents(callContext(c),
+		&proto.GetCalendarFeedEventsRequest{Token: c.Query("token")})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: get calendar feed: client:": err.Error()})
+		return
+	}
+
+	calendar := ical.Calendar{Name: calendarName(feed.Feed)}
+	for _, event := range feed.Events {
+		start, err := time.Parse(dateLayout, event.StartDate)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, map[string]interface{}{"gw_handlers: get calendar feed: start date:": err.Error()})
+			return
+		}
+		end, err := time.Parse(dateLayout, event.EndDate)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, map[string]interface{}{"gw_handlers: get calendar feed: end date:": err.Error()})
+			return
+		}
+		calendar.Events = append(calendar.Events, ical.Event{
+			UID:         event.Uid,
+			Summary:     event.Summary,
+			Description: event.Description,
+			Category:    event.Kind,
+			Start:       start,
+			End:         end,
+		})
+	}
+
+	c.Header("Cache-Control", "private, no-cache")
+	c.Data(http.StatusOK, ical.ContentType, ical.Marshal(calendar, time.Now()))
+}
+
+func calendarName(feed *proto.CalendarFeed) string {
+	if feed.GetName() != "" {
+		return feed.Name
+	}
+	if feed.GetDepartment() != nil {
+		return fmt.Sprintf("Company %d: %s", feed.CompanyId, feed.Department.Name)
+	}
+	return fmt.Sprintf("Company %d", feed.GetCompanyId())
+}
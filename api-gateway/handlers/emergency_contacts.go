@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"api-gateway/proto"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (h *Handlers) AddEmergencyContact(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var addRequest proto.AddEmergencyContactRequest
+	if err := c.BindJSON(&addRequest); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: add emergency contact: bind:": err.Error()})
+		return
+	}
+	addRequest.EmployeeId = id
+
+	contact, err := h.employeeClient.AddEmergencyContact(callContext(c), &addRequest)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: add emergency contact: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, contact)
+}
+
+func (h *Handlers) ListEmergencyContacts(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	contacts, err := h.employeeClient.ListEmergencyContacts(callContext(c),
+		&proto.ListEmergencyContactsRequest{EmployeeId: id})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: list emergency contacts: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, contacts)
+}
+
+func (h *Handlers) UpdateEmergencyContact(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	contactId, ok := int32Param(c, "contact_id")
+	if !ok {
+		return
+	}
+
+	var updateRequest proto.UpdateEmergencyContactRequest
+	if err := c.BindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: update emergency contact: bind:": err.Error()})
+		return
+	}
+	updateRequest.EmployeeId = id
+	updateRequest.Id = contactId
+
+	contact, err := h.employeeClient.UpdateEmergencyContact(callContext(c), &updateRequest)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: update emergency contact: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, contact)
+}
+
+func (h *Handlers) DeleteEmergencyContact(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	contactId, ok := int32Param(c, "contact_id")
+	if !ok {
+		return
+	}
+
+	deleted, err := h.employeeClient.DeleteEmergencyContact(callContext(c),
+		&proto.DeleteEmergencyContactRequest{EmployeeId: id, Id: contactId})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: delete emergency contact: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, deleted)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"api-gateway/proto"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (h *Handlers) HireEmployee(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var hireRequest proto.HireEmployeeRequest
+	if err := bindOptionalJSON(c, &hireRequest); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: hire employee: bind:": err.Error()})
+		return
+	}
+	hireRequest.Id = id
+
+	employment, err := h.employeeClient.HireEmployee(callContext(c), &hireRequest)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: hire employee: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, employment)
+}
+
+func (h *Handlers) TerminateEmployee(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var terminateRequest proto.TerminateEmployeeRequest
+	if err := c.BindJSON(&terminateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: terminate employee: bind:": err.Error()})
+		return
+	}
+	terminateRequest.Id = id
+
+	employment, err := h.employeeClient.TerminateEmployee(callContext(c), &terminateRequest)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: terminate employee: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, employment)
+}
+
+func (h *Handlers) RehireEmployee(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var rehireRequest proto.RehireEmployeeRequest
+	if err := bindOptionalJSON(c, &rehireRequest); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: rehire employee: bind:": err.Error()})
+		return
+	}
+	rehireRequest.Id = id
+
+	employment, err := h.employeeClient.RehireEmployee(callContext(c), &rehireRequest)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: rehire employee: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, employment)
+}
+
+// StartLeave and EndLeave move an active employee on leave and back.
+func (h *Handlers) StartLeave(c *gin.Context) {
+	h.setOnLeave(c, true)
+}
+
+func (h *Handlers) EndLeave(c *gin.Context) {
+	h.setOnLeave(c, false)
+}
+
+func (h *Handlers) setOnLeave(c *gin.Context, onLeave bool) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	employment, err := h.employeeClient.SetOnLeave(callContext(c), &proto.SetOnLeaveRequest{Id: id, OnLeave: onLeave})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: set on leave: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, employment)
+}
+
+// bindOptionalJSON binds the request body into obj unless it is empty.
+func bindOptionalJSON(c *gin.Context, obj interface{}) error {
+	if c.Request.ContentLength == 0 {
+		return nil
+	}
+	return c.BindJSON(obj)
+}
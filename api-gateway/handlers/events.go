@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"api-gateway/proto"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/metadata"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const keepAliveInterval = 15 * time.Second
+
+// WatchEmployees streams employee changes of a company as Server-Sent Events.
+// The event id is the resume token, so browsers resume automatically through
+// Last-Event-ID after a reconnect; other clients can pass ?resume_token=.
+func (h *Handlers) WatchEmployees(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+
+	resumeToken := c.GetHeader("Last-Event-ID")
+	if token := c.Query("resume_token"); token != "" {
+		resumeToken = token
+	}
+
+	ctx := callContext(c)
+	stream, err := h.employeeClient.WatchEmployees(ctx, &proto.WatchEmployeesRequest{
+		CompanyId:   companyId,
+		ResumeToken: resumeToken,
+	})
+	if err == nil {
+		// employee-service sends headers once the request is accepted, so
+		// invalid requests still get a regular error response.
+		var md metadata.MD
+		md, err = stream.Header()
+		if err == nil && md == nil {
+			_, err = stream.Recv()
+		}
+	}
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: watch employees: client:": err.Error()})
+		return
+	}
+
+	changes := make(chan *proto.EmployeeChange)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			change, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case changes <- change:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case change := <-changes:
+			if err := writeEvent(c.Writer, change); err != nil {
+				return
+			}
+		case err := <-recvErr:
+			if err != io.EOF && ctx.Err() == nil {
+				slog.WarnContext(ctx, "gw_handlers: watch employees: stream ended", "error", err)
+				data, _ := json.Marshal(map[string]interface{}{"gw_handlers: watch employees: stream": err.Error()})
+				fmt.Fprintf(c.Writer, "event: error\ndata: %s\n\n", data)
+			}
+			c.Writer.Flush()
+			return
+		case <-keepAlive.C:
+			if _, err := io.WriteString(c.Writer, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case <-h.closing:
+			return
+		case <-ctx.Done():
+			return
+		}
+		c.Writer.Flush()
+	}
+}
+
+func writeEvent(w io.Writer, change *proto.EmployeeChange) error {
+	data, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", change.ResumeToken, change.Type, data)
+	return err
+}
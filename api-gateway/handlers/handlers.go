@@ -1,18 +1,101 @@
 package handlers
 
 import (
+	"api-gateway/auth"
+	"api-gateway/logging"
 	"api-gateway/proto"
 	"context"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// statusClientClosedRequest is the non-standard status used when the client
+// disconnects before the response is ready. It is only ever seen in logs.
+const statusClientClosedRequest = 499
+
+// IdempotencyKeyHeader makes POST /employees safe to retry: a retry with the
+// same key and payload returns the original response with
+// IdempotentReplayedHeader set instead of creating another employee.
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	idempotentReplayedKey = "x-idempotent-replayed"
 )
 
 type Handlers struct {
 	employeeClient proto.EmployeeServiceClient
+
+	closing   chan struct{}
+	closeOnce sync.Once
 }
 
 func NewHandler(employeeClient proto.EmployeeServiceClient) *Handlers {
-	return &Handlers{employeeClient: employeeClient}
+	return &Handlers{employeeClient: employeeClient, closing: make(chan struct{})}
+}
+
+// CloseStreams ends open event streams, which would otherwise keep a graceful
+// shutdown waiting until its timeout.
+func (h *Handlers) CloseStreams() {
+	h.closeOnce.Do(func() { close(h.closing) })
+}
+
+// callContext returns the context for a call to employee-service on behalf of
+// the authenticated caller. It is derived from the request context, so the
+// call is cancelled when the client goes away or the route timeout expires.
+func callContext(c *gin.Context) context.Context {
+	ctx := auth.OutgoingContext(c.Request.Context(), auth.CallerFrom(c))
+	return metadata.AppendToOutgoingContext(ctx, logging.RequestIDKey, logging.RequestID(ctx))
+}
+
+// idParam parses the :id path parameter, responding with 400 if it is not a
+// valid employee id.
+func idParam(c *gin.Context) (int32, bool) {
+	return int32Param(c, "id")
+}
+
+// int32Param parses the named path parameter, responding with 400 if it is not
+// a valid id.
+func int32Param(c *gin.Context, name string) (int32, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: " + name + " param": err.Error()})
+		return 0, false
+	}
+	return int32(id), true
+}
+
+// statusCode maps a gRPC error returned by employee-service to an HTTP status.
+func statusCode(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.FailedPrecondition:
+		return http.StatusUnprocessableEntity
+	case codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Canceled:
+		return statusClientClosedRequest
+	default:
+		return http.StatusInternalServerError
+	}
 }
 
 func (h *Handlers) AddEmployee(c *gin.Context) {
@@ -22,12 +105,20 @@ func (h *Handlers) AddEmployee(c *gin.Context) {
 		return
 	}
 
-	id, err := h.employeeClient.AddEmployee(context.Background(), &AddEmployeeRequest)
+	if key := c.GetHeader(IdempotencyKeyHeader); key != "" {
+		AddEmployeeRequest.IdempotencyKey = key
+	}
+
+	var header metadata.MD
+	id, err := h.employeeClient.AddEmployee(callContext(c), &AddEmployeeRequest, grpc.Header(&header))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, map[string]interface{}{"gw_handler: add employee: client": err.Error()})
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handler: add employee: client": err.Error()})
 		return
 	}
 
+	if values := header.Get(idempotentReplayedKey); len(values) > 0 && values[0] == "true" {
+		c.Header(IdempotentReplayedHeader, "true")
+	}
 	c.JSON(http.StatusOK, id)
 }
 
@@ -38,9 +129,9 @@ func (h *Handlers) RemoveEmployee(c *gin.Context) {
 		return
 	}
 
-	success, err := h.employeeClient.DeleteEmployee(context.Background(), removeRequest)
+	success, err := h.employeeClient.DeleteEmployee(callContext(c), removeRequest)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, map[string]interface{}{"gw_handlers: remove employee: client: ": err.Error()})
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: remove employee: client: ": err.Error()})
 		return
 	}
 
@@ -55,9 +146,25 @@ func (h *Handlers) GetEmployees(c *gin.Context) {
 		return
 	}
 
-	companyResponse, err := h.employeeClient.ShowCompanyEmployees(context.Background(), companyRequest)
+	companyRequest.Fields = append(companyRequest.Fields, fieldsQuery(c)...)
+	if statuses := c.Query("status"); statuses != "" {
+		for _, employmentStatus := range strings.Split(statuses, ",") {
+			companyRequest.Statuses = append(companyRequest.Statuses, strings.TrimSpace(employmentStatus))
+		}
+	}
+	// Custom attribute filters can also be given as ?attr[badge_number]=1042.
+	if attributes := c.QueryMap("attr"); len(attributes) > 0 {
+		if companyRequest.CustomAttributes == nil {
+			companyRequest.CustomAttributes = map[string]string{}
+		}
+		for key, value := range attributes {
+			companyRequest.CustomAttributes[key] = value
+		}
+	}
+
+	companyResponse, err := h.employeeClient.ShowCompanyEmployees(callContext(c), companyRequest)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, map[string]interface{}{"gw_handlers: get employee: show company": err.Error()})
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: get employee: show company": err.Error()})
 		return
 	}
 
@@ -71,9 +178,39 @@ func (h *Handlers) UpdateEmployee(c *gin.Context) {
 		return
 	}
 
-	success, err := h.employeeClient.UpdateEmployee(context.Background(), updateRequest)
+	success, err := h.employeeClient.UpdateEmployee(callContext(c), updateRequest)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: update employee: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, success)
+}
+
+func (h *Handlers) ExportEmployeeData(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	dossier, err := h.employeeClient.ExportEmployeeData(callContext(c), &proto.ExportEmployeeDataRequest{Id: id})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: export employee data: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dossier)
+}
+
+func (h *Handlers) EraseEmployee(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	success, err := h.employeeClient.EraseEmployee(callContext(c), &proto.EraseEmployeeRequest{Id: id})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, map[string]interface{}{"gw_handlers: update employee: client:": err.Error()})
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: erase employee: client:": err.Error()})
 		return
 	}
 
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"net/http"
+	"time"
+)
+
+const readinessTimeout = 2 * time.Second
+
+type HealthHandlers struct {
+	healthClient healthpb.HealthClient
+}
+
+func NewHealthHandler(healthClient healthpb.HealthClient) *HealthHandlers {
+	return &HealthHandlers{healthClient: healthClient}
+}
+
+// Liveness reports that the gateway process is up. It does not depend on
+// employee-service, so a failing backend never gets the gateway restarted.
+func (h *HealthHandlers) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, map[string]interface{}{"status": "ok"})
+}
+
+// Readiness reports whether employee-service, and through it the database, can
+// serve requests.
+func (h *HealthHandlers) Readiness(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	resp, err := h.healthClient.Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, map[string]interface{}{
+			"status":           "unavailable",
+			"employee_service": err.Error(),
+		})
+		return
+	}
+
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		c.JSON(http.StatusServiceUnavailable, map[string]interface{}{
+			"status":           "unavailable",
+			"employee_service": resp.Status.String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"status":           "ok",
+		"employee_service": resp.Status.String(),
+	})
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"api-gateway/proto"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// GetReports returns the direct reports of an employee, or with
+// ?recursive=true the whole subtree below it, limited by ?max_depth.
+func (h *Handlers) GetReports(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	recursive, err := strconv.ParseBool(c.DefaultQuery("recursive", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: get reports: recursive:": err.Error()})
+		return
+	}
+
+	if !recursive {
+		reports, err := h.employeeClient.GetDirectReports(callContext(c),
+			&proto.GetDirectReportsRequest{Id: id, Fields: fieldsQuery(c)})
+		if err != nil {
+			c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: get reports: client:": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, reports)
+		return
+	}
+
+	maxDepth, err := strconv.ParseInt(c.DefaultQuery("max_depth", "0"), 10, 32)
+	if err != nil || maxDepth < 0 {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: get reports: max_depth:": "must be a non-negative integer"})
+		return
+	}
+
+	subtree, err := h.employeeClient.GetOrgSubtree(callContext(c),
+		&proto.GetOrgSubtreeRequest{Id: id, MaxDepth: int32(maxDepth), Fields: fieldsQuery(c)})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: get reports: client:": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, subtree)
+}
+
+// GetReportingChain returns the managers of an employee up to the top.
+func (h *Handlers) GetReportingChain(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	chain, err := h.employeeClient.GetReportingChain(callContext(c),
+		&proto.GetReportingChainRequest{Id: id, Fields: fieldsQuery(c)})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: get reporting chain: client:": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, chain)
+}
+
+// fieldsQuery parses the comma separated ?fields query parameter.
+func fieldsQuery(c *gin.Context) []string {
+	var fields []string
+	if query := c.Query("fields"); query != "" {
+		for _, field := range strings.Split(query, ",") {
+			fields = append(fields, strings.TrimSpace(field))
+		}
+	}
+	return fields
+}
@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"api-gateway/proto"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type LeaveHandlers struct {
+	leaveClient proto.LeaveServiceClient
+}
+
+func NewLeaveHandler(leaveClient proto.LeaveServiceClient) *LeaveHandlers {
+	return &LeaveHandlers{leaveClient: leaveClient}
+}
+
+func (h *LeaveHandlers) CreateLeaveType(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+
+	var createRequest proto.CreateLeaveTypeRequest
+	if err := c.BindJSON(&createRequest); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: create leave type: bind:": err.Error()})
+		return
+	}
+	createRequest.CompanyId = companyId
+
+	created, err := h.leaveClient.CreateLeaveType(callContext(c), &createRequest)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: create leave type: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *LeaveHandlers) ListLeaveTypes(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+
+	list, err := h.leaveClient.ListLeaveTypes(callContext(c), &proto.ListLeaveTypesRequest{CompanyId: companyId})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: list leave types: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+func (h *LeaveHandlers) UpdateLeaveType(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+	typeId, ok := int32Param(c, "type_id")
+	if !ok {
+		return
+	}
+
+	var updateRequest proto.UpdateLeaveTypeRequest
+	if err := c.BindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: update leave type: bind:": err.Error()})
+		return
+	}
+	updateRequest.CompanyId = companyId
+	updateRequest.Id = typeId
+
+	updated, err := h.leaveClient.UpdateLeaveType(callContext(c), &updateRequest)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: update leave type: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+func (h *LeaveHandlers) GetLeaveBalances(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	balances, err := h.leaveClient.GetLeaveBalances(callContext(c), &proto.GetLeaveBalancesRequest{EmployeeId: id})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: get leave balances: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, balances)
+}
+
+func (h *LeaveHandlers) AdjustLeaveBalance(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	typeId, ok := int32Param(c, "type_id")
+	if !ok {
+		return
+	}
+
+	var adjustRequest proto.AdjustLeaveBalanceRequest
+	if err := c.BindJSON(&adjustRequest); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: adjust leave balance: bind:": err.Error()})
+		return
+	}
+	adjustRequest.EmployeeId = id
+	adjustRequest.LeaveTypeId = typeId
+
+	balance, err := h.leaveClient.AdjustLeaveBalance(callContext(c), &adjustRequest)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: adjust leave balance: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, balance)
+}
+
+func (h *LeaveHandlers) SubmitLeave(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var submitRequest proto.SubmitLeaveRequest
+	if err := c.BindJSON(&submitRequest); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: submit leave: bind:": err.Error()})
+		return
+	}
+	submitRequest.EmployeeId = id
+
+	submitted, err := h.leaveClient.SubmitLeave(callContext(c), &submitRequest)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: submit leave: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, submitted)
+}
+
+func (h *LeaveHandlers) ListLeaveRequests(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	req := &proto.ListLeaveRequestsRequest{EmployeeId: id}
+	if statuses := c.Query("status"); statuses != "" {
+		for _, leaveStatus := range strings.Split(statuses, ",") {
+			req.Statuses = append(req.Statuses, strings.TrimSpace(leaveStatus))
+		}
+	}
+
+	list, err := h.leaveClient.ListLeaveRequests(callContext(c), req)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: list leave requests: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+func (h *LeaveHandlers) ApproveLeave(c *gin.Context) {
+	h.decideLeave(c, true)
+}
+
+func (h *LeaveHandlers) RejectLeave(c *gin.Context) {
+	h.decideLeave(c, false)
+}
+
+func (h *LeaveHandlers) decideLeave(c *gin.Context, approve bool) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var decideRequest proto.DecideLeaveRequest
+	if err := bindOptionalJSON(c, &decideRequest); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: decide leave: bind:": err.Error()})
+		return
+	}
+	decideRequest.Id = id
+	decideRequest.Approve = approve
+
+	decided, err := h.leaveClient.DecideLeave(callContext(c), &decideRequest)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: decide leave: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, decided)
+}
+
+func (h *LeaveHandlers) CancelLeave(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	cancelled, err := h.leaveClient.CancelLeave(callContext(c), &proto.CancelLeaveRequest{Id: id})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: cancel leave: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cancelled)
+}
+
+func (h *LeaveHandlers) ListAbsences(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+
+	req := &proto.ListAbsencesRequest{CompanyId: companyId, From: c.Query("from"), To: c.Query("to")}
+	if name := c.Query("department"); name != "" {
+		req.Department = &proto.Employee_Department{Name: name, Phone: c.Query("department_phone")}
+	}
+	if value := c.Query("include_pending"); value != "" {
+		includePending, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: include_pending query": err.Error()})
+			return
+		}
+		req.IncludePending = includePending
+	}
+
+	absences, err := h.leaveClient.ListAbsences(callContext(c), req)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: list absences: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, absences)
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"api-gateway/proto"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// ExportOrgChart renders the company's org chart as ?format=dot, mermaid or
+// json. ?root_employee or ?root_department (with the optional
+// ?root_department_phone) select a subtree, ?max_depth limits its depth.
+func (h *Handlers) ExportOrgChart(c *gin.Context) {
+	companyId, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	req := &proto.ExportOrgChartRequest{CompanyId: companyId, Format: c.Query("format")}
+
+	if root := c.Query("root_employee"); root != "" {
+		id, err := strconv.ParseInt(root, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: export org chart: root_employee:": err.Error()})
+			return
+		}
+		req.RootEmployeeId = int32(id)
+	}
+	if name := c.Query("root_department"); name != "" {
+		req.RootDepartment = &proto.Employee_Department{Name: name, Phone: c.Query("root_department_phone")}
+	}
+	if depth := c.Query("max_depth"); depth != "" {
+		maxDepth, err := strconv.ParseInt(depth, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: export org chart: max_depth:": err.Error()})
+			return
+		}
+		req.MaxDepth = int32(maxDepth)
+	}
+
+	chart, err := h.employeeClient.ExportOrgChart(callContext(c), req)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: export org chart: client:": err.Error()})
+		return
+	}
+
+	c.Data(http.StatusOK, chart.ContentType, []byte(chart.Content))
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"api-gateway/proto"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type PositionHandlers struct {
+	positionClient proto.PositionServiceClient
+}
+
+func NewPositionHandler(positionClient proto.PositionServiceClient) *PositionHandlers {
+	return &PositionHandlers{positionClient: positionClient}
+}
+
+func (h *PositionHandlers) CreatePosition(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+
+	var createRequest proto.CreatePositionRequest
+	if err := c.BindJSON(&createRequest); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: create position: bind:": err.Error()})
+		return
+	}
+	createRequest.CompanyId = companyId
+
+	created, err := h.positionClient.CreatePosition(callContext(c), &createRequest)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: create position: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *PositionHandlers) ListPositions(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+
+	list, err := h.positionClient.ListPositions(callContext(c), &proto.ListPositionsRequest{CompanyId: companyId})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: list positions: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+func (h *PositionHandlers) UpdatePosition(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+	positionId, ok := int32Param(c, "position_id")
+	if !ok {
+		return
+	}
+
+	var updateRequest proto.UpdatePositionRequest
+	if err := c.BindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: update position: bind:": err.Error()})
+		return
+	}
+	updateRequest.CompanyId = companyId
+	updateRequest.Id = positionId
+
+	updated, err := h.positionClient.UpdatePosition(callContext(c), &updateRequest)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: update position: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+func (h *PositionHandlers) DeletePosition(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+	positionId, ok := int32Param(c, "position_id")
+	if !ok {
+		return
+	}
+
+	success, err := h.positionClient.DeletePosition(callContext(c),
+		&proto.DeletePositionRequest{CompanyId: companyId, Id: positionId})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: delete position: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, success)
+}
+
+func (h *PositionHandlers) GetVacancyReport(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+
+	report, err := h.positionClient.GetVacancyReport(callContext(c), &proto.VacancyReportRequest{CompanyId: companyId})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: vacancy report: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
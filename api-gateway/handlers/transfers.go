@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"api-gateway/proto"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (h *Handlers) TransferEmployee(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var transferRequest proto.TransferEmployeeRequest
+	if err := c.BindJSON(&transferRequest); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: transfer employee: bind:": err.Error()})
+		return
+	}
+	transferRequest.Id = id
+
+	transfer, err := h.employeeClient.TransferEmployee(callContext(c), &transferRequest)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: transfer employee: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, transfer)
+}
+
+func (h *Handlers) ListTransfers(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	transfers, err := h.employeeClient.ListTransfers(callContext(c), &proto.ListTransfersRequest{Id: id})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: list transfers: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transfers)
+}
+
+func (h *Handlers) CancelTransfer(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	transferId, ok := int32Param(c, "transfer_id")
+	if !ok {
+		return
+	}
+
+	transfer, err := h.employeeClient.CancelTransfer(callContext(c),
+		&proto.CancelTransferRequest{Id: id, TransferId: transferId})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: cancel transfer: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transfer)
+}
+
+func (h *Handlers) GetAssignmentHistory(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	history, err := h.employeeClient.GetAssignmentHistory(callContext(c), &proto.GetAssignmentHistoryRequest{Id: id})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: assignment history: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"api-gateway/proto"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type WebhookHandlers struct {
+	webhookClient proto.WebhookServiceClient
+}
+
+func NewWebhookHandler(webhookClient proto.WebhookServiceClient) *WebhookHandlers {
+	return &WebhookHandlers{webhookClient: webhookClient}
+}
+
+func (h *WebhookHandlers) CreateWebhook(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+
+	var createRequest proto.CreateWebhookRequest
+	if err := c.BindJSON(&createRequest); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: create webhook: bind:": err.Error()})
+		return
+	}
+	createRequest.CompanyId = companyId
+
+	created, err := h.webhookClient.CreateWebhook(callContext(c), &createRequest)
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: create webhook: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *WebhookHandlers) ListWebhooks(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+
+	list, err := h.webhookClient.ListWebhooks(callContext(c), &proto.ListWebhooksRequest{CompanyId: companyId})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: list webhooks: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+func (h *WebhookHandlers) DeleteWebhook(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+	webhookId, ok := int32Param(c, "webhook_id")
+	if !ok {
+		return
+	}
+
+	success, err := h.webhookClient.DeleteWebhook(callContext(c),
+		&proto.DeleteWebhookRequest{CompanyId: companyId, Id: webhookId})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: delete webhook: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, success)
+}
+
+func (h *WebhookHandlers) ListWebhookDeliveries(c *gin.Context) {
+	companyId, ok := int32Param(c, "id")
+	if !ok {
+		return
+	}
+	webhookId, ok := int32Param(c, "webhook_id")
+	if !ok {
+		return
+	}
+
+	var limit int64
+	if value := c.Query("limit"); value != "" {
+		var err error
+		if limit, err = strconv.ParseInt(value, 10, 32); err != nil {
+			c.JSON(http.StatusBadRequest, map[string]interface{}{"gw_handlers: limit query": err.Error()})
+			return
+		}
+	}
+
+	deliveries, err := h.webhookClient.ListWebhookDeliveries(callContext(c), &proto.ListWebhookDeliveriesRequest{
+		CompanyId: companyId,
+		WebhookId: webhookId,
+		Status:    c.Query("status"),
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		c.JSON(statusCode(err), map[string]interface{}{"gw_handlers: list webhook deliveries: client:": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
@@ -0,0 +1,101 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType is the media type of iCalendar data.
+const ContentType = "text/calendar; charset=utf-8"
+
+const (
+	productId = "-//employee-management//calendar feed//EN"
+	// maxLineLength is the limit of RFC 5545 content lines in octets, without
+	// the line break.
+	maxLineLength = 75
+	dateLayout    = "20060102"
+	stampLayout   = "20060102T150405Z"
+	// refreshInterval suggests how often calendar apps reload the feed.
+	refreshInterval = "PT1H"
+)
+
+// Calendar is a published calendar of all-day events.
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Event is an all-day event from Start to End, both inclusive.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Category    string
+	Start       time.Time
+	End         time.Time
+}
+
+// Marshal encodes the calendar as an RFC 5545 VCALENDAR object, with stamp as
+// the DTSTAMP of every event.
+func Marshal(calendar Calendar, stamp time.Time) []byte {
+	var buf bytes.Buffer
+	writeLine(&buf, "BEGIN:VCALENDAR")
+	writeLine(&buf, "VERSION:2.0")
+	writeLine(&buf, "PRODID:"+productId)
+	writeLine(&buf, "CALSCALE:GREGORIAN")
+	writeLine(&buf, "METHOD:PUBLISH")
+	if calendar.Name != "" {
+		writeLine(&buf, "NAME:"+escapeText(calendar.Name))
+		writeLine(&buf, "X-WR-CALNAME:"+escapeText(calendar.Name))
+	}
+	writeLine(&buf, "REFRESH-INTERVAL;VALUE=DURATION:"+refreshInterval)
+	writeLine(&buf, "X-PUBLISHED-TTL:"+refreshInterval)
+
+	for _, event := range calendar.Events {
+		writeLine(&buf, "BEGIN:VEVENT")
+		writeLine(&buf, "UID:"+escapeText(event.UID))
+		writeLine(&buf, "DTSTAMP:"+stamp.UTC().Format(stampLayout))
+		writeLine(&buf, "DTSTART;VALUE=DATE:"+event.Start.Format(dateLayout))
+		// DTEND of all-day events is exclusive.
+		writeLine(&buf, "DTEND;VALUE=DATE:"+event.End.AddDate(0, 0, 1).Format(dateLayout))
+		writeLine(&buf, "SUMMARY:"+escapeText(event.Summary))
+		if event.Description != "" {
+			writeLine(&buf, "DESCRIPTION:"+escapeText(event.Description))
+		}
+		if event.Category != "" {
+			writeLine(&buf, "CATEGORIES:"+escapeText(event.Category))
+		}
+		writeLine(&buf, "TRANSP:TRANSPARENT")
+		writeLine(&buf, "END:VEVENT")
+	}
+
+	writeLine(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// escapeText escapes a TEXT property value.
+func escapeText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(value)
+}
+
+// writeLine writes a content line terminated by CRLF, folding it into
+// continuation lines that start with a space once it exceeds maxLineLength
+// octets. Lines are only folded between UTF-8 sequences.
+func writeLine(buf *bytes.Buffer, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space of continuation lines counts towards the limit.
+		limit = maxLineLength - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
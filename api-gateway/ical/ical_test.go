@@ -0,0 +1,123 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "Ivan Ivanov", want: "Ivan Ivanov"},
+		{value: "Sales; Moscow", want: `Sales\; Moscow`},
+		{value: "Ivanov, Ivan", want: `Ivanov\, Ivan`},
+		{value: `C:\temp`, want: `C:\\temp`},
+		{value: `\;`, want: `\\\;`},
+		{value: "first\nsecond", want: `first\nsecond`},
+		{value: "first\r\nsecond", want: `first\nsecond`},
+		{value: "first\rsecond", want: `first\nsecond`},
+	}
+	for _, test := range tests {
+		if got := escapeText(test.value); got != test.want {
+			t.Errorf("escapeText(%q) = %q, want %q", test.value, got, test.want)
+		}
+	}
+}
+
+func TestWriteLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{name: "short", line: "SUMMARY:Start"},
+		{name: "exactly the limit", line: "SUMMARY:" + strings.Repeat("a", maxLineLength-len("SUMMARY:"))},
+		{name: "ascii", line: "SUMMARY:" + strings.Repeat("a", 200)},
+		{name: "two-byte runes", line: "SUMMARY:" + strings.Repeat("Иванов ", 30)},
+		{name: "three-byte runes", line: "SUMMARY:" + strings.Repeat("休暇", 40)},
+		{name: "four-byte runes", line: "SUMMARY:" + strings.Repeat("🏖", 50)},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		writeLine(&buf, test.line)
+		out := buf.String()
+
+		if !strings.HasSuffix(out, "\r\n") {
+			t.Errorf("%s: output %q does not end with CRLF", test.name, out)
+			continue
+		}
+		physical := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+		var unfolded strings.Builder
+		for i, line := range physical {
+			if len(line) > maxLineLength {
+				t.Errorf("%s: line %d is %d octets, want at most %d", test.name, i, len(line), maxLineLength)
+			}
+			if !utf8.ValidString(line) {
+				t.Errorf("%s: line %d %q splits a UTF-8 sequence", test.name, i, line)
+			}
+			if i > 0 {
+				if !strings.HasPrefix(line, " ") {
+					t.Errorf("%s: continuation line %d %q does not start with a space", test.name, i, line)
+				}
+				line = strings.TrimPrefix(line, " ")
+			}
+			unfolded.WriteString(line)
+		}
+		if unfolded.String() != test.line {
+			t.Errorf("%s: unfolded output = %q, want %q", test.name, unfolded.String(), test.line)
+		}
+		if wantFolded := len(test.line) > maxLineLength; (len(physical) > 1) != wantFolded {
+			t.Errorf("%s: folded into %d lines, want folded %t", test.name, len(physical), wantFolded)
+		}
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	stamp := time.Date(2025, 6, 2, 10, 30, 0, 0, time.FixedZone("MSK", 3*60*60))
+	calendar := Calendar{
+		Name: "Sales, Moscow",
+		Events: []Event{{
+			UID:      "absence-7@employee-management",
+			Summary:  "Ivanov; vacation",
+			Category: "absence",
+			Start:    time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC),
+			End:      time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC),
+		}, {
+			UID:     "departure-8@employee-management",
+			Summary: "Petrov",
+			Start:   time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+			End:     time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+		}},
+	}
+
+	out := string(Marshal(calendar, stamp))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"X-WR-CALNAME:Sales\\, Moscow\r\n",
+		"SUMMARY:Ivanov\\; vacation\r\n",
+		"CATEGORIES:absence\r\n",
+		"DTSTAMP:20250602T073000Z\r\n",
+		// DTEND is exclusive: the day after the last day, across a month and
+		// a year boundary.
+		"DTSTART;VALUE=DATE:20250630\r\nDTEND;VALUE=DATE:20250801\r\n",
+		"DTSTART;VALUE=DATE:20251231\r\nDTEND;VALUE=DATE:20260101\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Marshal output does not contain %q:\n%s", want, out)
+		}
+	}
+	if got := strings.Count(out, "BEGIN:VEVENT\r\n"); got != len(calendar.Events) {
+		t.Errorf("Marshal output has %d events, want %d", got, len(calendar.Events))
+	}
+	if strings.Contains(out, "DESCRIPTION:") {
+		t.Errorf("Marshal output has a DESCRIPTION for events without one:\n%s", out)
+	}
+	if !strings.HasSuffix(out, "END:VCALENDAR\r\n") {
+		t.Errorf("Marshal output does not end with END:VCALENDAR:\n%s", out)
+	}
+}
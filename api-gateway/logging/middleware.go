@@ -0,0 +1,40 @@
+package logging
+
+import (
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"time"
+)
+
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware accepts the X-Request-ID of the client or generates a new
+// one, echoes it in the response and stores it in the request context.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = NewRequestID()
+		}
+
+		c.Header(RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(WithRequestID(c.Request.Context(), requestID))
+		c.Next()
+	}
+}
+
+// AccessLogMiddleware logs every request once it has been served.
+func AccessLogMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		slog.InfoContext(c.Request.Context(), "http request",
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"route", c.FullPath(),
+			"status", c.Writer.Status(),
+			"duration_ms", time.Since(start).Milliseconds(),
+			"client_ip", c.ClientIP())
+	}
+}
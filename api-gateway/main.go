@@ -1,39 +1,193 @@
 package main
 
 import (
+	"api-gateway/auth"
 	"api-gateway/config"
 	"api-gateway/handlers"
+	"api-gateway/logging"
+	"api-gateway/metrics"
 	"api-gateway/proto"
+	"api-gateway/ratelimit"
+	"api-gateway/timeout"
+	"api-gateway/tracing"
+	"context"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
-	"log"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
 func main() {
+	logging.Setup()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		log.Fatalf("config.LoadConfig failed: %v", err)
+		fatal("config.LoadConfig failed", err)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		ServiceName:  "api-gateway",
+		Exporter:     cfg.TracingExporter,
+		OTLPEndpoint: cfg.TracingOTLPEndpoint,
+		FilePath:     cfg.TracingFilePath,
+	})
+	if err != nil {
+		fatal("tracing.Setup failed", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	callers, err := auth.LoadAPIKeys(cfg.APIKeys, cfg.APIKeysPath)
+	if err != nil {
+		fatal("auth.LoadAPIKeys failed", err)
+	}
+
+	gatewayToken, err := auth.LoadGatewayToken(cfg.GatewayToken, cfg.GatewayTokenPath)
+	if err != nil {
+		fatal("auth.LoadGatewayToken failed", err)
+	}
+
+	limits, err := ratelimit.ParseLimits(cfg.RateLimitDefault, cfg.RateLimitRoutes)
+	if err != nil {
+		fatal("ratelimit.ParseLimits failed", err)
+	}
+
+	ipLimit, err := ratelimit.ParseLimit(cfg.RateLimitPerIP)
+	if err != nil {
+		fatal("ratelimit.ParseLimit failed", err)
 	}
 
-	router := gin.Default()
+	timeouts, err := timeout.ParseTimeouts(cfg.RequestTimeoutDefault, cfg.RequestTimeoutRoutes)
+	if err != nil {
+		fatal("timeout.ParseTimeouts failed", err)
+	}
 
-	employeeConn, err := grpc.NewClient(cfg.Address+cfg.EmployeePort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	router := gin.New()
+	// Without trusted proxies the client IP is the remote address, so that
+	// clients cannot pick their rate limit bucket with X-Forwarded-For.
+	if err = router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		fatal("router.SetTrustedProxies failed", err)
+	}
+	limitStore := ratelimit.NewMemoryStore()
+	router.Use(logging.RequestIDMiddleware(), tracing.Middleware(), logging.AccessLogMiddleware(), metrics.Middleware(),
+		gin.Recovery())
+	router.Use(ratelimit.IPMiddleware(limitStore, ipLimit), auth.Middleware(callers),
+		ratelimit.Middleware(limitStore, limits), timeout.Middleware(timeouts))
+
+	employeeConn, err := grpc.NewClient(cfg.Address+cfg.EmployeePort,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(auth.GatewayCredentials{Token: gatewayToken}),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
 	if err != nil {
-		log.Fatalf("did not connect to employee service: %v", err)
+		fatal("did not connect to employee service", err)
 	}
 	defer employeeConn.Close()
 	employeeClient := proto.NewEmployeeServiceClient(employeeConn)
 
 	Handler := handlers.NewHandler(employeeClient)
+	HealthHandler := handlers.NewHealthHandler(healthpb.NewHealthClient(employeeConn))
+	WebhookHandler := handlers.NewWebhookHandler(proto.NewWebhookServiceClient(employeeConn))
+	PositionHandler := handlers.NewPositionHandler(proto.NewPositionServiceClient(employeeConn))
+	LeaveHandler := handlers.NewLeaveHandler(proto.NewLeaveServiceClient(employeeConn))
+	CalendarHandler := handlers.NewCalendarHandler(proto.NewCalendarServiceClient(employeeConn))
+	AttributeHandler := handlers.NewAttributeHandler(proto.NewAttributeServiceClient(employeeConn))
+
+	router.GET("/healthz", HealthHandler.Liveness)
+	router.GET("/readyz", HealthHandler.Readiness)
 
 	router.POST("/employees", Handler.AddEmployee)
 	router.DELETE("/employees", Handler.RemoveEmployee)
 	router.GET("/employees", Handler.GetEmployees)
 	router.PUT("/employees", Handler.UpdateEmployee)
+	router.POST("/employees:batch", Handler.BatchEmployees)
+	router.GET("/employees/:id/export", Handler.ExportEmployeeData)
+	router.POST("/employees/:id/erase", Handler.EraseEmployee)
+	router.GET("/employees/:id/reports", Handler.GetReports)
+	router.GET("/employees/:id/chain", Handler.GetReportingChain)
+	router.POST("/employees/:id/hire", Handler.HireEmployee)
+	router.POST("/employees/:id/terminate", Handler.TerminateEmployee)
+	router.POST("/employees/:id/rehire", Handler.RehireEmployee)
+	router.POST("/employees/:id/on-leave", Handler.StartLeave)
+	router.DELETE("/employees/:id/on-leave", Handler.EndLeave)
+	router.POST("/employees/:id/transfers", Handler.TransferEmployee)
+	router.GET("/employees/:id/transfers", Handler.ListTransfers)
+	router.DELETE("/employees/:id/transfers/:transfer_id", Handler.CancelTransfer)
+	router.GET("/employees/:id/assignments", Handler.GetAssignmentHistory)
+	router.POST("/employees/:id/emergency-contacts", Handler.AddEmergencyContact)
+	router.GET("/employees/:id/emergency-contacts", Handler.ListEmergencyContacts)
+	router.PUT("/employees/:id/emergency-contacts/:contact_id", Handler.UpdateEmergencyContact)
+	router.DELETE("/employees/:id/emergency-contacts/:contact_id", Handler.DeleteEmergencyContact)
+	router.GET("/companies/:id/employees/events", Handler.WatchEmployees)
+	router.GET("/companies/:id/orgchart", Handler.ExportOrgChart)
+
+	router.POST("/companies/:id/webhooks", WebhookHandler.CreateWebhook)
+	router.GET("/companies/:id/webhooks", WebhookHandler.ListWebhooks)
+	router.DELETE("/companies/:id/webhooks/:webhook_id", WebhookHandler.DeleteWebhook)
+	router.GET("/companies/:id/webhooks/:webhook_id/deliveries", WebhookHandler.ListWebhookDeliveries)
+
+	router.POST("/companies/:id/positions", PositionHandler.CreatePosition)
+	router.GET("/companies/:id/positions", PositionHandler.ListPositions)
+	router.PUT("/companies/:id/positions/:position_id", PositionHandler.UpdatePosition)
+	router.DELETE("/companies/:id/positions/:position_id", PositionHandler.DeletePosition)
+	router.GET("/companies/:id/vacancies", PositionHandler.GetVacancyReport)
+
+	router.POST("/companies/:id/leave-types", LeaveHandler.CreateLeaveType)
+	router.GET("/companies/:id/leave-types", LeaveHandler.ListLeaveTypes)
+	router.PUT("/companies/:id/leave-types/:type_id", LeaveHandler.UpdateLeaveType)
+	router.GET("/companies/:id/absences", LeaveHandler.ListAbsences)
+	router.GET("/employees/:id/leave-balances", LeaveHandler.GetLeaveBalances)
+	router.POST("/employees/:id/leave-balances/:type_id/adjustments", LeaveHandler.AdjustLeaveBalance)
+	router.POST("/employees/:id/leave-requests", LeaveHandler.SubmitLeave)
+	router.GET("/employees/:id/leave-requests", LeaveHandler.ListLeaveRequests)
+	router.POST("/leave-requests/:id/approve", LeaveHandler.ApproveLeave)
+	router.POST("/leave-requests/:id/reject", LeaveHandler.RejectLeave)
+	router.POST("/leave-requests/:id/cancel", LeaveHandler.CancelLeave)
+
+	router.POST("/companies/:id/calendar-feeds", CalendarHandler.CreateCalendarFeed)
+	router.GET("/companies/:id/calendar-feeds", CalendarHandler.ListCalendarFeeds)
+	router.DELETE("/companies/:id/calendar-feeds/:feed_id", CalendarHandler.RevokeCalendarFeed)
+	router.GET("/calendar.ics", CalendarHandler.GetCalendarFeed)
+
+	router.POST("/companies/:id/custom-attributes", AttributeHandler.CreateAttribute)
+	router.GET("/companies/:id/custom-attributes", AttributeHandler.ListAttributes)
+	router.PUT("/companies/:id/custom-attributes/:attribute_id", AttributeHandler.UpdateAttribute)
+	router.DELETE("/companies/:id/custom-attributes/:attribute_id", AttributeHandler.DeleteAttribute)
+
+	go metrics.Serve(cfg.MetricsPort)
+
+	server := &http.Server{Addr: cfg.GatewayPort, Handler: router}
+	server.RegisterOnShutdown(Handler.CloseStreams)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+	slog.Info("Gateway service is listening", "port", cfg.GatewayPort)
+
+	select {
+	case err := <-serveErr:
+		fatal("could not start gateway service", err)
+	case <-ctx.Done():
+	}
 
-	log.Printf("Gateway service is listening on port %s", cfg.GatewayPort)
-	if err := router.Run(cfg.GatewayPort); err != nil {
-		log.Fatalf("could not start gateway service: %v", err)
+	slog.Info("Shutting down gateway service", "timeout", cfg.ShutdownTimeout.String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Gateway shutdown did not finish in time", "error", err)
 	}
+	slog.Info("Gateway service stopped")
 }
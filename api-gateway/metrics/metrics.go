@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var httpRequestSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "gateway",
+	Subsystem: "http",
+	Name:      "request_duration_seconds",
+	Help:      "Time spent serving HTTP requests, by method, route and status.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"method", "route", "status"})
+
+// Middleware records the duration of every request. Requests that did not
+// match a route are recorded with route "unmatched" to keep cardinality low.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		httpRequestSeconds.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
+
+// Serve exposes the default registry on addr at /metrics until the server
+// fails.
+func Serve(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	slog.Info("Metrics server started", "port", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.Error("Metrics server failed", "error", err)
+	}
+}
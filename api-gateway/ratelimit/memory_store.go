@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time
+}
+
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	capacity := float64(limit.Requests)
+	rate := limit.rate()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.updated).Seconds()*rate)
+	b.updated = now
+
+	result := Result{}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = durationFor(1-b.tokens, rate)
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = durationFor(capacity-b.tokens, rate)
+	b.full = now.Add(result.Reset)
+
+	return result, nil
+}
+
+// sweep drops buckets that have refilled completely, since a new bucket would
+// be in the same state.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"api-gateway/auth"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Middleware applies the route limit to every request. Buckets are kept per
+// route and per client, where the client is the API key if one was sent, the
+// authenticated user otherwise and the client IP for anonymous requests. It
+// must run after auth.Middleware.
+func Middleware(store Store, limits Limits) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			c.Next()
+			return
+		}
+
+		limit := limits.For(c.Request.Method, route)
+		key := c.Request.Method + " " + route + "|" + clientKey(c)
+
+		result, err := store.Take(c.Request.Context(), key, limit)
+		if err != nil {
+			slog.ErrorContext(c.Request.Context(), "gw_ratelimit: take", "route", route, "error", err)
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(limit.Requests))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", seconds(result.Reset))
+
+		if !result.Allowed {
+			c.Header("Retry-After", seconds(result.RetryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, map[string]interface{}{"gw_ratelimit": "rate limit exceeded"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// IPMiddleware applies limit to all requests of each client IP. It runs in
+// front of auth.Middleware, so that requests with invalid API keys are limited
+// as well.
+func IPMiddleware(store Store, limit Limit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		result, err := store.Take(c.Request.Context(), "ip|"+c.ClientIP(), limit)
+		if err != nil {
+			slog.ErrorContext(c.Request.Context(), "gw_ratelimit: take ip", "error", err)
+			c.Next()
+			return
+		}
+
+		if !result.Allowed {
+			c.Header("Retry-After", seconds(result.RetryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, map[string]interface{}{"gw_ratelimit": "rate limit exceeded"})
+			return
+		}
+		c.Next()
+	}
+}
+
+func clientKey(c *gin.Context) string {
+	if key := c.GetHeader(auth.APIKeyHeader); key != "" {
+		return "key:" + key
+	}
+	if caller := auth.CallerFrom(c); caller.Id != "" {
+		return "user:" + caller.Id
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,118 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit allows Requests per Period with bursts of up to Requests.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Result is the state of a bucket after a Take.
+type Result struct {
+	Allowed   bool
+	Remaining int
+	// Reset is the time until the bucket is full again, RetryAfter the time
+	// until the next request would be allowed.
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// Store keeps token buckets. The in-memory store only limits a single gateway
+// instance; a shared backend can implement the same interface.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// Limits holds the default limit and per-route overrides keyed by
+// "METHOD /path" as registered in the router.
+type Limits struct {
+	Default Limit
+	Routes  map[string]Limit
+}
+
+func (l Limits) For(method, path string) Limit {
+	if limit, ok := l.Routes[method+" "+path]; ok {
+		return limit
+	}
+	return l.Default
+}
+
+// ParseLimits parses the default limit, e.g. "100/m", and a comma separated
+// list of route limits, e.g. "POST /employees=10/m,GET /employees=60/m".
+func ParseLimits(defaultLimit, routeLimits string) (Limits, error) {
+	limit, err := parseLimit(defaultLimit)
+	if err != nil {
+		return Limits{}, fmt.Errorf("ratelimit: default limit: %w", err)
+	}
+	limits := Limits{Default: limit, Routes: make(map[string]Limit)}
+
+	for _, entry := range strings.Split(routeLimits, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, spec, ok := strings.Cut(entry, "=")
+		if !ok {
+			return Limits{}, fmt.Errorf("ratelimit: route limit %q: expected ROUTE=LIMIT", entry)
+		}
+		limit, err := parseLimit(spec)
+		if err != nil {
+			return Limits{}, fmt.Errorf("ratelimit: route limit %q: %w", entry, err)
+		}
+		limits.Routes[strings.Join(strings.Fields(route), " ")] = limit
+	}
+	return limits, nil
+}
+
+var periods = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+}
+
+// ParseLimit parses a single limit, e.g. "600/m".
+func ParseLimit(spec string) (Limit, error) {
+	limit, err := parseLimit(spec)
+	if err != nil {
+		return Limit{}, fmt.Errorf("ratelimit: %w", err)
+	}
+	return limit, nil
+}
+
+func parseLimit(spec string) (Limit, error) {
+	count, unit, ok := strings.Cut(strings.TrimSpace(spec), "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("expected REQUESTS/PERIOD, got %q", spec)
+	}
+
+	requests, err := strconv.Atoi(count)
+	if err != nil || requests <= 0 {
+		return Limit{}, fmt.Errorf("invalid request count %q", count)
+	}
+
+	period, ok := periods[unit]
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid period %q, expected s, m or h", unit)
+	}
+	return Limit{Requests: requests, Period: period}, nil
+}
+
+func durationFor(tokens, rate float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(tokens / rate * float64(time.Second)))
+}
@@ -0,0 +1,86 @@
+package timeout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// Timeouts holds the default request timeout and per-route overrides keyed by
+// "METHOD /path" as registered in the router.
+type Timeouts struct {
+	Default time.Duration
+	Routes  map[string]time.Duration
+}
+
+func (t Timeouts) For(method, path string) time.Duration {
+	if timeout, ok := t.Routes[method+" "+path]; ok {
+		return timeout
+	}
+	return t.Default
+}
+
+// ParseTimeouts parses the default timeout, e.g. "5s", and a comma separated
+// list of route timeouts, e.g. "PUT /employees=10s,GET /employees=3s".
+func ParseTimeouts(defaultTimeout, routeTimeouts string) (Timeouts, error) {
+	timeout, err := time.ParseDuration(strings.TrimSpace(defaultTimeout))
+	if err != nil {
+		return Timeouts{}, fmt.Errorf("timeout: default timeout: %w", err)
+	}
+	timeouts := Timeouts{Default: timeout, Routes: make(map[string]time.Duration)}
+
+	for _, entry := range strings.Split(routeTimeouts, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, spec, ok := strings.Cut(entry, "=")
+		if !ok {
+			return Timeouts{}, fmt.Errorf("timeout: route timeout %q: expected ROUTE=DURATION", entry)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(spec))
+		if err != nil {
+			return Timeouts{}, fmt.Errorf("timeout: route timeout %q: %w", entry, err)
+		}
+		timeouts.Routes[strings.Join(strings.Fields(route), " ")] = timeout
+	}
+	return timeouts, nil
+}
+
+// Middleware bounds the request context with the route timeout. Handlers pass
+// the request context on to employee-service, so the deadline travels with
+// the gRPC call down to the database queries. A zero timeout leaves the route
+// unbounded, e.g. for event streams.
+func Middleware(timeouts Timeouts) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			c.Next()
+			return
+		}
+
+		timeout := timeouts.For(c.Request.Method, route)
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		switch err := ctx.Err(); {
+		case errors.Is(err, context.DeadlineExceeded):
+			slog.WarnContext(ctx, "gw_timeout: deadline exceeded", "method", c.Request.Method, "route", route)
+		case errors.Is(err, context.Canceled):
+			slog.InfoContext(ctx, "gw_timeout: cancelled by client", "method", c.Request.Method, "route", route)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strconv"
+	"strings"
+)
+
+// Metadata keys set by the API gateway after it has authenticated the caller.
+// They are trusted as is because interceptors.GatewayToken rejects every call
+// that does not carry the token shared with the gateway.
+const (
+	GatewayTokenKey      = "x-gateway-token"
+	CallerIdKey          = "x-caller-id"
+	CallerPermissionsKey = "x-caller-permissions"
+	// CallerEmployeeIdKey is set when the API key of the caller belongs to an
+	// employee.
+	CallerEmployeeIdKey = "x-caller-employee-id"
+)
+
+const (
+	PermissionPIIRead    = "pii:read"
+	PermissionGDPRManage = "gdpr:manage"
+	// PermissionWebhooksManage allows registering webhooks and reading their
+	// delivery log for any company.
+	PermissionWebhooksManage = "webhooks:manage"
+	// PermissionLeaveManage allows managing leave types and balances, and
+	// deciding or cancelling the leave requests of any employee.
+	PermissionLeaveManage = "leave:manage"
+	// PermissionCalendarManage allows creating, listing and revoking the
+	// calendar feeds of any company.
+	PermissionCalendarManage = "calendar:manage"
+	// PermissionEmergencyContactsManage allows reading and changing the
+	// emergency contacts of any employee. Employees always may manage their
+	// own.
+	PermissionEmergencyContactsManage = "emergency_contacts:manage"
+	// PermissionAttributesManage allows changing the custom attribute schema
+	// of any company. Reading the schema needs no permission.
+	PermissionAttributesManage = "attributes:manage"
+)
+
+type Caller struct {
+	Id          string
+	Permissions []string
+	// EmployeeId is 0 unless the caller is an employee.
+	EmployeeId int32
+}
+
+func CallerFromContext(ctx context.Context) Caller {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return Caller{}
+	}
+
+	var caller Caller
+	if values := md.Get(CallerIdKey); len(values) > 0 {
+		caller.Id = values[0]
+	}
+	if values := md.Get(CallerEmployeeIdKey); len(values) > 0 {
+		if id, err := strconv.ParseInt(values[0], 10, 32); err == nil {
+			caller.EmployeeId = int32(id)
+		}
+	}
+	for _, value := range md.Get(CallerPermissionsKey) {
+		for _, permission := range strings.Split(value, ",") {
+			if permission = strings.TrimSpace(permission); permission != "" {
+				caller.Permissions = append(caller.Permissions, permission)
+			}
+		}
+	}
+	return caller
+}
+
+func (c Caller) HasPermission(permission string) bool {
+	for _, p := range c.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// RequirePermission returns a PermissionDenied status error unless the caller
+// in ctx has permission.
+func RequirePermission(ctx context.Context, permission string) error {
+	if !CallerFromContext(ctx).HasPermission(permission) {
+		return status.Errorf(codes.PermissionDenied, "permission %q required", permission)
+	}
+	return nil
+}
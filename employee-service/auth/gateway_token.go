@@ -0,0 +1,23 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LoadGatewayToken returns token, or without it the contents of the file at
+// path, usually a secret mount.
+func LoadGatewayToken(token, path string) (string, error) {
+	if token == "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("auth: read gateway token: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	if token == "" {
+		return "", fmt.Errorf("auth: gateway token is empty")
+	}
+	return token, nil
+}
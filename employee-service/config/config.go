@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"github.com/spf13/viper"
+	"time"
 )
 
 type Config struct {
@@ -13,6 +14,36 @@ type Config struct {
 	PostgresDB          string
 	ServicesNetworkType string
 	EmployeePort        string
+	Keyring             string
+	KeyringPath         string
+	GatewayToken        string
+	GatewayTokenPath    string
+	MetricsPort         string
+	DBConnectTimeout    time.Duration
+	HealthCheckInterval time.Duration
+	ShutdownTimeout     time.Duration
+	IdempotencyKeyTTL   time.Duration
+
+	TracingExporter     string
+	TracingOTLPEndpoint string
+	TracingFilePath     string
+
+	EventsPublisher      string
+	EventsTopic          string
+	EventsFilePath       string
+	EventsNATSURL        string
+	EventsKafkaURL       string
+	EventsRelayInterval  time.Duration
+	EventsRelayBatchSize int
+	EventsRetention      time.Duration
+
+	WebhookDispatchInterval time.Duration
+	WebhookBatchSize        int
+	WebhookMaxAttempts      int32
+	WebhookTimeout          time.Duration
+
+	TransferScheduleInterval time.Duration
+	TransferBatchSize        int
 }
 
 func LoadConfig() (*Config, error) {
@@ -21,6 +52,27 @@ func LoadConfig() (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Key material and the gateway token come from the environment or a
+	// secret mount, never from config.env.
+	if err = viper.BindEnv("KEYRING"); err != nil {
+		return nil, err
+	}
+	if err = viper.BindEnv("KEYRING_PATH"); err != nil {
+		return nil, err
+	}
+	if err = viper.BindEnv("GATEWAY_TOKEN"); err != nil {
+		return nil, err
+	}
+	if err = viper.BindEnv("GATEWAY_TOKEN_PATH"); err != nil {
+		return nil, err
+	}
+	// The deployment picks the tracing exporter, see docker-compose.yml.
+	if err = viper.BindEnv("TRACING_EXPORTER"); err != nil {
+		return nil, err
+	}
+	if err = viper.BindEnv("TRACING_OTLP_ENDPOINT"); err != nil {
+		return nil, err
+	}
 
 	config := &Config{
 		PostgresHost:        viper.GetString("POSTGRES_HOST"),
@@ -30,6 +82,36 @@ func LoadConfig() (*Config, error) {
 		PostgresDB:          viper.GetString("POSTGRES_DB"),
 		ServicesNetworkType: viper.GetString("SERVICES_NETWORK_TYPE"),
 		EmployeePort:        viper.GetString("EMPLOYEE_PORT"),
+		Keyring:             viper.GetString("KEYRING"),
+		KeyringPath:         viper.GetString("KEYRING_PATH"),
+		GatewayToken:        viper.GetString("GATEWAY_TOKEN"),
+		GatewayTokenPath:    viper.GetString("GATEWAY_TOKEN_PATH"),
+		MetricsPort:         viper.GetString("METRICS_PORT"),
+		DBConnectTimeout:    viper.GetDuration("DB_CONNECT_TIMEOUT"),
+		HealthCheckInterval: viper.GetDuration("HEALTH_CHECK_INTERVAL"),
+		ShutdownTimeout:     viper.GetDuration("SHUTDOWN_TIMEOUT"),
+		IdempotencyKeyTTL:   viper.GetDuration("IDEMPOTENCY_KEY_TTL"),
+
+		TracingExporter:     viper.GetString("TRACING_EXPORTER"),
+		TracingOTLPEndpoint: viper.GetString("TRACING_OTLP_ENDPOINT"),
+		TracingFilePath:     viper.GetString("TRACING_FILE_PATH"),
+
+		EventsPublisher:      viper.GetString("EVENTS_PUBLISHER"),
+		EventsTopic:          viper.GetString("EVENTS_TOPIC"),
+		EventsFilePath:       viper.GetString("EVENTS_FILE_PATH"),
+		EventsNATSURL:        viper.GetString("EVENTS_NATS_URL"),
+		EventsKafkaURL:       viper.GetString("EVENTS_KAFKA_REST_URL"),
+		EventsRelayInterval:  viper.GetDuration("EVENTS_RELAY_INTERVAL"),
+		EventsRelayBatchSize: viper.GetInt("EVENTS_RELAY_BATCH_SIZE"),
+		EventsRetention:      viper.GetDuration("EVENTS_RETENTION"),
+
+		WebhookDispatchInterval: viper.GetDuration("WEBHOOK_DISPATCH_INTERVAL"),
+		WebhookBatchSize:        viper.GetInt("WEBHOOK_BATCH_SIZE"),
+		WebhookMaxAttempts:      viper.GetInt32("WEBHOOK_MAX_ATTEMPTS"),
+		WebhookTimeout:          viper.GetDuration("WEBHOOK_TIMEOUT"),
+
+		TransferScheduleInterval: viper.GetDuration("TRANSFER_SCHEDULE_INTERVAL"),
+		TransferBatchSize:        viper.GetInt("TRANSFER_BATCH_SIZE"),
 	}
 	return config, nil
 }
@@ -0,0 +1,201 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const keySize = 32
+
+// Envelope is a value encrypted with its own data key, where the data key is
+// wrapped by one of the keyring keys identified by KeyID.
+type Envelope struct {
+	KeyID      string
+	WrappedKey []byte
+	Ciphertext []byte
+}
+
+type keyringFile struct {
+	ActiveKey string            `json:"active_key"`
+	IndexKey  string            `json:"index_key"`
+	Keys      map[string]string `json:"keys"`
+}
+
+type Keyring struct {
+	activeKey string
+	indexKey  []byte
+	keys      map[string][]byte
+}
+
+// LoadKeyring reads a JSON keyring file, usually a secret mount.
+func LoadKeyring(path string) (*Keyring, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("keyring: read file: %w", err)
+	}
+	return ParseKeyring(data)
+}
+
+// ParseKeyring parses a JSON keyring. Every key, including the blind index
+// key, must be a base64 encoded 32-byte value. Old keys stay in the keyring
+// after rotation so that values wrapped with them can still be decrypted.
+func ParseKeyring(data []byte) (*Keyring, error) {
+	var file keyringFile
+	err := json.Unmarshal(data, &file)
+	if err != nil {
+		return nil, fmt.Errorf("keyring: parse: %w", err)
+	}
+
+	keyring := &Keyring{activeKey: file.ActiveKey, keys: make(map[string][]byte)}
+
+	keyring.indexKey, err = decodeKey(file.IndexKey)
+	if err != nil {
+		return nil, fmt.Errorf("keyring: index key: %w", err)
+	}
+
+	for id, encoded := range file.Keys {
+		key, err := decodeKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("keyring: key %s: %w", id, err)
+		}
+		keyring.keys[id] = key
+	}
+
+	if _, ok := keyring.keys[keyring.activeKey]; !ok {
+		return nil, fmt.Errorf("keyring: active key %q not found", keyring.activeKey)
+	}
+	return keyring, nil
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("expected %d bytes, got %d", keySize, len(key))
+	}
+	return key, nil
+}
+
+func (k *Keyring) ActiveKeyID() string {
+	return k.activeKey
+}
+
+// Encrypt seals plaintext with a fresh data key and wraps the data key with
+// the active keyring key.
+func (k *Keyring) Encrypt(plaintext string) (Envelope, error) {
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return Envelope{}, fmt.Errorf("keyring: encrypt: generate data key: %w", err)
+	}
+
+	ciphertext, err := seal(dataKey, []byte(plaintext))
+	if err != nil {
+		return Envelope{}, fmt.Errorf("keyring: encrypt: seal value: %w", err)
+	}
+
+	wrappedKey, err := seal(k.keys[k.activeKey], dataKey)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("keyring: encrypt: wrap data key: %w", err)
+	}
+
+	return Envelope{KeyID: k.activeKey, WrappedKey: wrappedKey, Ciphertext: ciphertext}, nil
+}
+
+func (k *Keyring) Decrypt(envelope Envelope) (string, error) {
+	dataKey, err := k.unwrap(envelope)
+	if err != nil {
+		return "", fmt.Errorf("keyring: decrypt: %w", err)
+	}
+
+	plaintext, err := open(dataKey, envelope.Ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("keyring: decrypt: open value: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// Rewrap re-encrypts the data key of envelope with the active key. The
+// ciphertext itself is left untouched, so rotation never sees the plaintext.
+func (k *Keyring) Rewrap(envelope Envelope) (Envelope, error) {
+	if envelope.KeyID == k.activeKey {
+		return envelope, nil
+	}
+
+	dataKey, err := k.unwrap(envelope)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("keyring: rewrap: %w", err)
+	}
+
+	wrappedKey, err := seal(k.keys[k.activeKey], dataKey)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("keyring: rewrap: wrap data key: %w", err)
+	}
+
+	return Envelope{KeyID: k.activeKey, WrappedKey: wrappedKey, Ciphertext: envelope.Ciphertext}, nil
+}
+
+// BlindIndex returns a deterministic keyed hash of value, used for uniqueness
+// and exact-match lookups without storing the plaintext.
+func (k *Keyring) BlindIndex(value string) string {
+	mac := hmac.New(sha256.New, k.indexKey)
+	mac.Write([]byte(strings.ToUpper(strings.Join(strings.Fields(value), ""))))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (k *Keyring) unwrap(envelope Envelope) ([]byte, error) {
+	key, ok := k.keys[envelope.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", envelope.KeyID)
+	}
+
+	dataKey, err := open(key, envelope.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("unwrap data key: %w", err)
+	}
+	return dataKey, nil
+}
+
+func seal(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(key, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
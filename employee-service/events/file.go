@@ -0,0 +1,52 @@
+package events
+
+import (
+	"context"
+	"employee-service/models"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// FilePublisher writes events as JSON lines, for local development and tests.
+type FilePublisher struct {
+	mu     sync.Mutex
+	writer io.Writer
+	closer io.Closer
+}
+
+func NewStdoutPublisher() *FilePublisher {
+	return &FilePublisher{writer: os.Stdout}
+}
+
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open event file: %w", err)
+	}
+	return &FilePublisher{writer: file, closer: file}, nil
+}
+
+func (p *FilePublisher) Publish(_ context.Context, events []models.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, event := range events {
+		body, err := encode(event)
+		if err != nil {
+			return fmt.Errorf("file publisher: %w", err)
+		}
+		if _, err = p.writer.Write(append(body, '\n')); err != nil {
+			return fmt.Errorf("file publisher: write event %d: %w", event.Id, err)
+		}
+	}
+	return nil
+}
+
+func (p *FilePublisher) Close() error {
+	if p.closer == nil {
+		return nil
+	}
+	return p.closer.Close()
+}
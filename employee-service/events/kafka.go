@@ -0,0 +1,91 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"employee-service/models"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const kafkaContentType = "application/vnd.kafka.json.v2+json"
+
+// KafkaPublisher produces events through a Kafka REST Proxy (v2 API). Records
+// are keyed by employee id, so the events of an employee share a partition.
+type KafkaPublisher struct {
+	endpoint string
+	client   *http.Client
+}
+
+func NewKafkaPublisher(restProxyURL, topic string) *KafkaPublisher {
+	return &KafkaPublisher{
+		endpoint: strings.TrimRight(restProxyURL, "/") + "/topics/" + url.PathEscape(topic),
+		client:   &http.Client{},
+	}
+}
+
+type kafkaRecord struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, events []models.Event) error {
+	records := make([]kafkaRecord, 0, len(events))
+	for _, event := range events {
+		body, err := encode(event)
+		if err != nil {
+			return fmt.Errorf("kafka publisher: %w", err)
+		}
+		records = append(records, kafkaRecord{Key: partitionKey(event), Value: body})
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"records": records})
+	if err != nil {
+		return fmt.Errorf("kafka publisher: marshal records: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("kafka publisher: new request: %w", err)
+	}
+	req.Header.Set("Content-Type", kafkaContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("kafka publisher: produce: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("kafka publisher: produce: status %d: %s", resp.StatusCode, bytes.TrimSpace(message))
+	}
+
+	var produced kafkaProduceResponse
+	if err = json.NewDecoder(resp.Body).Decode(&produced); err != nil {
+		return fmt.Errorf("kafka publisher: decode response: %w", err)
+	}
+	for i, offset := range produced.Offsets {
+		if offset.ErrorCode != nil {
+			return fmt.Errorf("kafka publisher: event %d: %s", events[i].Id, offset.Error)
+		}
+	}
+	return nil
+}
+
+func (p *KafkaPublisher) Close() error {
+	p.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,143 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"employee-service/models"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const natsDialTimeout = 5 * time.Second
+
+// NATSPublisher publishes events to core NATS on "<prefix>.<event type>". It
+// speaks the NATS client protocol directly and confirms each batch with a
+// PING, so Publish only succeeds once the server has processed every PUB.
+type NATSPublisher struct {
+	address string
+	prefix  string
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func NewNATSPublisher(natsURL, prefix string) *NATSPublisher {
+	address := natsURL
+	if u, err := url.Parse(natsURL); err == nil && u.Host != "" {
+		address = u.Host
+	}
+	return &NATSPublisher{address: address, prefix: prefix}
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, events []models.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.publish(ctx, events); err != nil {
+		p.disconnect()
+		return fmt.Errorf("nats publisher: %w", err)
+	}
+	return nil
+}
+
+func (p *NATSPublisher) publish(ctx context.Context, events []models.Event) error {
+	if p.conn == nil {
+		if err := p.connect(ctx); err != nil {
+			return err
+		}
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		p.conn.SetDeadline(deadline)
+	} else {
+		p.conn.SetDeadline(time.Time{})
+	}
+
+	writer := bufio.NewWriter(p.conn)
+	for _, event := range events {
+		body, err := encode(event)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(writer, "PUB %s.%s %d\r\n", p.prefix, event.Type, len(body))
+		writer.Write(body)
+		writer.WriteString("\r\n")
+	}
+	writer.WriteString("PING\r\n")
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+	return p.awaitPong()
+}
+
+func (p *NATSPublisher) connect(ctx context.Context) error {
+	dialer := net.Dialer{Timeout: natsDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", p.address)
+	if err != nil {
+		return fmt.Errorf("dial %s: %w", p.address, err)
+	}
+	p.conn = conn
+	p.reader = bufio.NewReader(conn)
+
+	conn.SetDeadline(time.Now().Add(natsDialTimeout))
+	line, err := p.readLine()
+	if err != nil {
+		return fmt.Errorf("read INFO: %w", err)
+	}
+	if !strings.HasPrefix(line, "INFO") {
+		return fmt.Errorf("unexpected greeting %q", line)
+	}
+
+	_, err = conn.Write([]byte(`CONNECT {"verbose":false,"pedantic":false,"name":"employee-service"}` + "\r\n"))
+	if err != nil {
+		return fmt.Errorf("write CONNECT: %w", err)
+	}
+	return nil
+}
+
+func (p *NATSPublisher) awaitPong() error {
+	for {
+		line, err := p.readLine()
+		if err != nil {
+			return fmt.Errorf("read: %w", err)
+		}
+
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err = p.conn.Write([]byte("PONG\r\n")); err != nil {
+				return fmt.Errorf("write PONG: %w", err)
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("server error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+func (p *NATSPublisher) readLine() (string, error) {
+	line, err := p.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (p *NATSPublisher) disconnect() {
+	if p.conn != nil {
+		p.conn.Close()
+		p.conn = nil
+	}
+}
+
+func (p *NATSPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.disconnect()
+	return nil
+}
@@ -0,0 +1,87 @@
+package events
+
+import (
+	"context"
+	"employee-service/models"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	PublisherNone   = "none"
+	PublisherStdout = "stdout"
+	PublisherFile   = "file"
+	PublisherNATS   = "nats"
+	PublisherKafka  = "kafka"
+)
+
+// Publisher delivers a batch of events in order. It returns nil only once
+// every event of the batch has been accepted by the broker; the relay then
+// retries the whole batch, so consumers must deduplicate by event id.
+type Publisher interface {
+	Publish(ctx context.Context, events []models.Event) error
+	Close() error
+}
+
+type Config struct {
+	// Publisher is one of PublisherNone, PublisherStdout, PublisherFile,
+	// PublisherNATS or PublisherKafka.
+	Publisher string
+	FilePath  string
+	NATSURL   string
+	KafkaURL  string
+	// Topic is the Kafka topic and the NATS subject prefix.
+	Topic string
+}
+
+func NewPublisher(cfg Config) (Publisher, error) {
+	switch cfg.Publisher {
+	case "", PublisherNone:
+		return nil, nil
+	case PublisherStdout:
+		return NewStdoutPublisher(), nil
+	case PublisherFile:
+		publisher, err := NewFilePublisher(cfg.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("events: %w", err)
+		}
+		return publisher, nil
+	case PublisherNATS:
+		return NewNATSPublisher(cfg.NATSURL, cfg.Topic), nil
+	case PublisherKafka:
+		return NewKafkaPublisher(cfg.KafkaURL, cfg.Topic), nil
+	default:
+		return nil, fmt.Errorf("events: unknown publisher %q", cfg.Publisher)
+	}
+}
+
+// message is the wire format of an event for every publisher.
+type message struct {
+	Id         int64           `json:"id"`
+	Type       string          `json:"type"`
+	EmployeeId int32           `json:"employee_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+func encode(event models.Event) ([]byte, error) {
+	body, err := json.Marshal(message{
+		Id:         event.Id,
+		Type:       event.Type,
+		EmployeeId: event.EmployeeId,
+		OccurredAt: event.CreatedAt.UTC(),
+		Data:       event.Payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encode event %d: %w", event.Id, err)
+	}
+	return body, nil
+}
+
+// partitionKey keeps the events of an employee on one partition, so brokers
+// that only order within a partition still deliver them in order.
+func partitionKey(event models.Event) string {
+	return strconv.Itoa(int(event.EmployeeId))
+}
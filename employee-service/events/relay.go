@@ -0,0 +1,64 @@
+package events
+
+import (
+	"context"
+	"employee-service/repositories"
+	"log/slog"
+	"time"
+)
+
+const cleanupInterval = time.Hour
+
+// Relay moves events from the outbox to the publisher. Events are published
+// at least once and, since a batch is only marked after it was accepted and
+// batches never overlap, in outbox order.
+type Relay struct {
+	outbox    *repositories.OutboxRepository
+	publisher Publisher
+	interval  time.Duration
+	batchSize int
+	retention time.Duration
+}
+
+func NewRelay(outbox *repositories.OutboxRepository, publisher Publisher, interval time.Duration, batchSize int,
+	retention time.Duration) *Relay {
+	return &Relay{outbox: outbox, publisher: publisher, interval: interval, batchSize: batchSize, retention: retention}
+}
+
+// Run publishes pending events every interval until ctx is done. A full batch
+// is followed immediately by the next one.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	lastCleanup := time.Time{}
+	for {
+		published, err := r.outbox.PublishPending(ctx, r.batchSize, r.publisher.Publish)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("Failed to publish outbox events", "error", err)
+		}
+
+		if time.Since(lastCleanup) >= cleanupInterval {
+			lastCleanup = time.Now()
+			deleted, err := r.outbox.DeletePublished(ctx, time.Now().Add(-r.retention))
+			if err != nil && ctx.Err() == nil {
+				slog.Error("Failed to delete published outbox events", "error", err)
+			} else if deleted > 0 {
+				slog.Info("Deleted published outbox events", "count", deleted)
+			}
+		}
+
+		if err == nil && published == r.batchSize {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"context"
+	"employee-service/auth"
+	"employee-service/models"
+	"employee-service/proto"
+	"employee-service/repositories"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"regexp"
+	"time"
+)
+
+// attributeKeyPattern restricts keys to identifiers that are safe in query
+// parameters and JSON paths.
+var attributeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+type AttributeHandlerInterface interface {
+	CreateAttribute(ctx context.Context, req *proto.CreateAttributeRequest) (*proto.AttributeResponse, error)
+	ListAttributes(ctx context.Context, req *proto.ListAttributesRequest) (*proto.ListAttributesResponse, error)
+	UpdateAttribute(ctx context.Context, req *proto.UpdateAttributeRequest) (*proto.AttributeResponse, error)
+	DeleteAttribute(ctx context.Context, req *proto.DeleteAttributeRequest) (*proto.DeleteAttributeResponse, error)
+}
+
+type AttributeHandler struct {
+	repo *repositories.AttributeRepository
+	proto.UnimplementedAttributeServiceServer
+}
+
+func NewAttributeHandler(repo *repositories.AttributeRepository) *AttributeHandler {
+	return &AttributeHandler{repo: repo}
+}
+
+func (h *AttributeHandler) CreateAttribute(ctx context.Context, req *proto.CreateAttributeRequest) (*proto.AttributeResponse, error) {
+	if err := auth.RequirePermission(ctx, auth.PermissionAttributesManage); err != nil {
+		return nil, err
+	}
+	if req.CompanyId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "company_id is required")
+	}
+	if !attributeKeyPattern.MatchString(req.Key) {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"key must start with a lowercase letter and contain up to 64 lowercase letters, digits and underscores, got %q",
+			req.Key)
+	}
+	if !models.IsAttributeType(req.Type) {
+		return nil, status.Errorf(codes.InvalidArgument, "type must be one of %q, got %q", models.AttributeTypes, req.Type)
+	}
+	if req.Type == models.AttributeEnum && len(req.Options) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "enum attributes need options")
+	}
+	if req.Type != models.AttributeEnum && len(req.Options) > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "%s attributes have no options", req.Type)
+	}
+	if err := validateAttributeOptions(req.Options); err != nil {
+		return nil, err
+	}
+
+	definition, err := h.repo.CreateAttribute(ctx, models.AttributeDefinition{
+		CompanyId: req.CompanyId,
+		Key:       req.Key,
+		Name:      req.Name,
+		Type:      req.Type,
+		Required:  req.Required,
+		Options:   req.Options,
+	})
+	if err != nil {
+		err = fmt.Errorf("attribute_handler: repo create attribute: %w", err)
+		slog.ErrorContext(ctx, "create attribute failed", "company_id", req.CompanyId, "error", err)
+		return nil, attributeStatusError(err)
+	}
+
+	return &proto.AttributeResponse{Attribute: toProtoAttribute(definition)}, nil
+}
+
+func (h *AttributeHandler) ListAttributes(ctx context.Context, req *proto.ListAttributesRequest) (*proto.ListAttributesResponse, error) {
+	definitions, err := h.repo.ListAttributes(ctx, req.CompanyId)
+	if err != nil {
+		err = fmt.Errorf("attribute_handler: repo list attributes: %w", err)
+		slog.ErrorContext(ctx, "list attributes failed", "company_id", req.CompanyId, "error", err)
+		return nil, err
+	}
+
+	resp := &proto.ListAttributesResponse{}
+	for _, definition := range definitions {
+		resp.Attributes = append(resp.Attributes, toProtoAttribute(definition))
+	}
+	return resp, nil
+}
+
+func (h *AttributeHandler) UpdateAttribute(ctx context.Context, req *proto.UpdateAttributeRequest) (*proto.AttributeResponse, error) {
+	if err := auth.RequirePermission(ctx, auth.PermissionAttributesManage); err != nil {
+		return nil, err
+	}
+	if err := validateAttributeOptions(req.Options); err != nil {
+		return nil, err
+	}
+
+	update := models.AttributeDefinitionUpdate{Name: req.Name, Required: req.Required}
+	if len(req.Options) > 0 {
+		update.Options = req.Options
+	}
+
+	definition, err := h.repo.UpdateAttribute(ctx, req.CompanyId, req.Id, update)
+	if err != nil {
+		err = fmt.Errorf("attribute_handler: repo update attribute: %w", err)
+		slog.ErrorContext(ctx, "update attribute failed", "id", req.Id, "error", err)
+		return nil, attributeStatusError(err)
+	}
+
+	return &proto.AttributeResponse{Attribute: toProtoAttribute(definition)}, nil
+}
+
+func (h *AttributeHandler) DeleteAttribute(ctx context.Context, req *proto.DeleteAttributeRequest) (*proto.DeleteAttributeResponse, error) {
+	if err := auth.RequirePermission(ctx, auth.PermissionAttributesManage); err != nil {
+		return &proto.DeleteAttributeResponse{Success: "Fail"}, err
+	}
+	if err := h.repo.DeleteAttribute(ctx, req.CompanyId, req.Id); err != nil {
+		err = fmt.Errorf("attribute_handler: repo delete attribute: %w", err)
+		slog.ErrorContext(ctx, "delete attribute failed", "id", req.Id, "error", err)
+		return &proto.DeleteAttributeResponse{Success: "Fail"}, attributeStatusError(err)
+	}
+
+	return &proto.DeleteAttributeResponse{Success: "Success"}, nil
+}
+
+func validateAttributeOptions(options []string) error {
+	seen := make(map[string]bool, len(options))
+	for i, option := range options {
+		if option == "" {
+			return status.Errorf(codes.InvalidArgument, "options[%d] is empty", i)
+		}
+		if seen[option] {
+			return status.Errorf(codes.InvalidArgument, "options[%d]: %q is repeated", i, option)
+		}
+		seen[option] = true
+	}
+	return nil
+}
+
+func toProtoAttribute(definition models.AttributeDefinition) *proto.AttributeDefinition {
+	return &proto.AttributeDefinition{
+		Id:        definition.Id,
+		CompanyId: definition.CompanyId,
+		Key:       definition.Key,
+		Name:      definition.Name,
+		Type:      definition.Type,
+		Required:  definition.Required,
+		Options:   definition.Options,
+		CreatedAt: definition.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func attributeStatusError(err error) error {
+	switch {
+	case errors.Is(err, repositories.ErrAttributeNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repositories.ErrAttributeExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, repositories.ErrInvalidAttributeDefinition):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, repositories.ErrAttributeValuesConflict):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
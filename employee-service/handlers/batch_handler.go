@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"employee-service/models"
+	"employee-service/proto"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+)
+
+const (
+	BatchModeAllOrNothing = "all_or_nothing"
+	BatchModeBestEffort   = "best_effort"
+
+	maxBatchSize = 1000
+)
+
+func (h *EmployeeHandler) BatchAddEmployees(ctx context.Context, req *proto.BatchAddEmployeesRequest) (*proto.BatchEmployeesResponse, error) {
+	allOrNothing, err := batchMode(req.Mode, len(req.Employees))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.AddItem, len(req.Employees))
+	for i, employee := range req.Employees {
+		key, err := idempotencyKey(ctx, employee)
+		if err != nil {
+			return nil, status.Errorf(status.Code(err), "item %d: %s", i, status.Convert(err).Message())
+		}
+		added, err := fromAddRequest(employee)
+		if err != nil {
+			return nil, status.Errorf(status.Code(err), "item %d: %s", i, status.Convert(err).Message())
+		}
+		items[i] = models.AddItem{Employee: added, IdempotencyKey: key}
+	}
+
+	results, err := h.repo.BatchAddEmployees(ctx, items, allOrNothing)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: repo batch add employees: %w", err)
+		slog.ErrorContext(ctx, "batch add employees failed", "count", len(items), "error", err)
+		return nil, statusError(err)
+	}
+	return toBatchResponse(ctx, "batch add employees", results), nil
+}
+
+func (h *EmployeeHandler) BatchUpdateEmployees(ctx context.Context, req *proto.BatchUpdateEmployeesRequest) (*proto.BatchEmployeesResponse, error) {
+	allOrNothing, err := batchMode(req.Mode, len(req.Employees))
+	if err != nil {
+		return nil, err
+	}
+
+	employees := make([]models.Employee, len(req.Employees))
+	for i, employee := range req.Employees {
+		if employees[i], err = fromUpdateRequest(employee); err != nil {
+			return nil, status.Errorf(status.Code(err), "item %d: %s", i, status.Convert(err).Message())
+		}
+	}
+
+	results, err := h.repo.BatchUpdateEmployees(ctx, employees, allOrNothing)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: repo batch update employees: %w", err)
+		slog.ErrorContext(ctx, "batch update employees failed", "count", len(employees), "error", err)
+		return nil, statusError(err)
+	}
+	return toBatchResponse(ctx, "batch update employees", results), nil
+}
+
+func (h *EmployeeHandler) BatchDeleteEmployees(ctx context.Context, req *proto.BatchDeleteEmployeesRequest) (*proto.BatchEmployeesResponse, error) {
+	allOrNothing, err := batchMode(req.Mode, len(req.Ids))
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := h.repo.BatchDeleteEmployees(ctx, req.Ids, allOrNothing)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: repo batch delete employees: %w", err)
+		slog.ErrorContext(ctx, "batch delete employees failed", "count", len(req.Ids), "error", err)
+		return nil, statusError(err)
+	}
+	return toBatchResponse(ctx, "batch delete employees", results), nil
+}
+
+// batchMode validates the batch and reports whether it is all-or-nothing.
+func batchMode(mode string, size int) (bool, error) {
+	if size == 0 {
+		return false, status.Error(codes.InvalidArgument, "batch is empty")
+	}
+	if size > maxBatchSize {
+		return false, status.Errorf(codes.InvalidArgument, "batch has %d items, at most %d allowed", size, maxBatchSize)
+	}
+
+	switch mode {
+	case "", BatchModeAllOrNothing:
+		return true, nil
+	case BatchModeBestEffort:
+		return false, nil
+	default:
+		return false, status.Errorf(codes.InvalidArgument, "unknown batch mode %q, expected %q or %q",
+			mode, BatchModeAllOrNothing, BatchModeBestEffort)
+	}
+}
+
+func toBatchResponse(ctx context.Context, op string, results []models.BatchResult) *proto.BatchEmployeesResponse {
+	resp := &proto.BatchEmployeesResponse{}
+	for i, result := range results {
+		item := &proto.BatchItemResult{
+			Index:    int32(i),
+			Id:       result.Id,
+			Success:  result.Err == nil,
+			Replayed: result.Replayed,
+			Code:     codes.OK.String(),
+		}
+		if result.Err != nil {
+			slog.WarnContext(ctx, op+" item failed", "index", i, "id", result.Id, "error", result.Err)
+			item.Code = status.Code(statusError(result.Err)).String()
+			item.Error = result.Err.Error()
+			resp.Failed++
+		} else {
+			resp.Succeeded++
+		}
+		resp.Results = append(resp.Results, item)
+	}
+	return resp
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"employee-service/auth"
+	"employee-service/models"
+	"employee-service/proto"
+	"employee-service/repositories"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// A feed covers the events of the last calendarFeedPast and the next
+// calendarFeedFuture, which calendar apps keep as they refresh it.
+const (
+	calendarFeedPast   = 90 * 24 * time.Hour
+	calendarFeedFuture = 365 * 24 * time.Hour
+)
+
+type CalendarHandlerInterface interface {
+	CreateCalendarFeed(ctx context.Context, req *proto.CreateCalendarFeedRequest) (*proto.CreateCalendarFeedResponse, error)
+	ListCalendarFeeds(ctx context.Context, req *proto.ListCalendarFeedsRequest) (*proto.ListCalendarFeedsResponse, error)
+	RevokeCalendarFeed(ctx context.Context, req *proto.RevokeCalendarFeedRequest) (*proto.CalendarFeedResponse, error)
+	GetCalendarFeedEvents(ctx context.Context, req *proto.GetCalendarFeedEventsRequest) (*proto.CalendarFeedEventsResponse, error)
+}
+
+type CalendarHandler struct {
+	repo *repositories.CalendarRepository
+	proto.UnimplementedCalendarServiceServer
+}
+
+func NewCalendarHandler(repo *repositories.CalendarRepository) *CalendarHandler {
+	return &CalendarHandler{repo: repo}
+}
+
+func (h *CalendarHandler) CreateCalendarFeed(ctx context.Context, req *proto.CreateCalendarFeedRequest) (*proto.CreateCalendarFeedResponse, error) {
+	if err := auth.RequirePermission(ctx, auth.PermissionCalendarManage); err != nil {
+		return nil, err
+	}
+	if req.CompanyId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "company_id is required")
+	}
+
+	feed := models.CalendarFeed{
+		CompanyId: req.CompanyId,
+		Name:      req.Name,
+		CreatedBy: auth.CallerFromContext(ctx).Id,
+	}
+	if req.Department != nil {
+		if req.Department.Name == "" {
+			return nil, status.Error(codes.InvalidArgument, "department name is required")
+		}
+		feed.Department = models.Department{Name: req.Department.Name, Phone: req.Department.Phone}
+	}
+
+	token, err := newFeedToken()
+	if err != nil {
+		return nil, fmt.Errorf("calendar_handler: create calendar feed: %w", err)
+	}
+
+	created, err := h.repo.CreateCalendarFeed(ctx, feed, token)
+	if err != nil {
+		err = fmt.Errorf("calendar_handler: repo create calendar feed: %w", err)
+		slog.ErrorContext(ctx, "create calendar feed failed", "company_id", req.CompanyId, "error", err)
+		return nil, err
+	}
+	return &proto.CreateCalendarFeedResponse{Feed: toProtoCalendarFeed(created), Token: token}, nil
+}
+
+func (h *CalendarHandler) ListCalendarFeeds(ctx context.Context, req *proto.ListCalendarFeedsRequest) (*proto.ListCalendarFeedsResponse, error) {
+	if err := auth.RequirePermission(ctx, auth.PermissionCalendarManage); err != nil {
+		return nil, err
+	}
+
+	feeds, err := h.repo.ListCalendarFeeds(ctx, req.CompanyId)
+	if err != nil {
+		err = fmt.Errorf("calendar_handler: repo list calendar feeds: %w", err)
+		slog.ErrorContext(ctx, "list calendar feeds failed", "company_id", req.CompanyId, "error", err)
+		return nil, err
+	}
+
+	resp := &proto.ListCalendarFeedsResponse{}
+	for _, feed := range feeds {
+		resp.Feeds = append(resp.Feeds, toProtoCalendarFeed(feed))
+	}
+	return resp, nil
+}
+
+func (h *CalendarHandler) RevokeCalendarFeed(ctx context.Context, req *proto.RevokeCalendarFeedRequest) (*proto.CalendarFeedResponse, error) {
+	if err := auth.RequirePermission(ctx, auth.PermissionCalendarManage); err != nil {
+		return nil, err
+	}
+
+	feed, err := h.repo.RevokeCalendarFeed(ctx, req.CompanyId, req.Id)
+	if err != nil {
+		err = fmt.Errorf("calendar_handler: repo revoke calendar feed: %w", err)
+		slog.ErrorContext(ctx, "revoke calendar feed failed", "id", req.Id, "error", err)
+		return nil, calendarStatusError(err)
+	}
+	return &proto.CalendarFeedResponse{Feed: toProtoCalendarFeed(feed)}, nil
+}
+
+// GetCalendarFeedEvents is authorized by the feed token alone, since calendar
+// apps cannot send API keys.
+func (h *CalendarHandler) GetCalendarFeedEvents(ctx context.Context, req *proto.GetCalendarFeedEventsRequest) (*proto.CalendarFeedEventsResponse, error) {
+	if req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+
+	now := time.Now().UTC().Truncate(24 * time.Hour)
+	feed, events, err := h.repo.CalendarFeedEvents(ctx, req.Token, now.Add(-calendarFeedPast), now.Add(calendarFeedFuture))
+	if err != nil {
+		err = fmt.Errorf("calendar_handler: repo calendar feed events: %w", err)
+		if !errors.Is(err, repositories.ErrCalendarFeedNotFound) {
+			slog.ErrorContext(ctx, "calendar feed events failed", "error", err)
+		}
+		return nil, calendarStatusError(err)
+	}
+
+	resp := &proto.CalendarFeedEventsResponse{Feed: toProtoCalendarFeed(feed)}
+	for _, event := range events {
+		resp.Events = append(resp.Events, toProtoCalendarEvent(event))
+	}
+	return resp, nil
+}
+
+func newFeedToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("new feed token: %w", err)
+	}
+	return "calfeed_" + hex.EncodeToString(token), nil
+}
+
+func toProtoCalendarFeed(feed models.CalendarFeed) *proto.CalendarFeed {
+	protoFeed := &proto.CalendarFeed{
+		Id:        feed.Id,
+		CompanyId: feed.CompanyId,
+		Name:      feed.Name,
+		CreatedBy: feed.CreatedBy,
+		CreatedAt: feed.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if feed.Department != (models.Department{}) {
+		protoFeed.Department = &proto.Employee_Department{Name: feed.Department.Name, Phone: feed.Department.Phone}
+	}
+	if feed.RevokedAt != nil {
+		protoFeed.RevokedAt = feed.RevokedAt.UTC().Format(time.RFC3339)
+	}
+	return protoFeed
+}
+
+// toProtoCalendarEvent describes the event for calendar apps. Absences do not
+// name the leave type, which may be sensitive.
+func toProtoCalendarEvent(event models.CalendarEvent) *proto.CalendarEvent {
+	employee := strings.TrimSpace(event.Name + " " + event.Surname)
+	protoEvent := &proto.CalendarEvent{
+		Kind:       event.Kind,
+		EmployeeId: event.EmployeeId,
+		StartDate:  formatDate(&event.StartDate),
+		EndDate:    formatDate(&event.EndDate),
+	}
+
+	switch event.Kind {
+	case models.CalendarEventStart:
+		protoEvent.Uid = fmt.Sprintf("start-%d-%s@employee-service", event.EmployeeId, protoEvent.StartDate)
+		protoEvent.Summary = employee + " starts"
+	case models.CalendarEventDeparture:
+		protoEvent.Uid = fmt.Sprintf("departure-%d-%s@employee-service", event.EmployeeId, protoEvent.StartDate)
+		protoEvent.Summary = employee + " leaves"
+	case models.CalendarEventTransfer:
+		protoEvent.Uid = fmt.Sprintf("transfer-%d@employee-service", event.SourceId)
+		protoEvent.Summary = employee + " transfers"
+		var targets []string
+		if event.Department.Name != "" {
+			targets = append(targets, "department "+event.Department.Name)
+		}
+		if event.CompanyId != 0 {
+			targets = append(targets, fmt.Sprintf("company %d", event.CompanyId))
+		}
+		if len(targets) > 0 {
+			protoEvent.Description = "Moves to " + strings.Join(targets, ", ")
+		}
+	case models.CalendarEventAbsence:
+		protoEvent.Uid = fmt.Sprintf("absence-%d@employee-service", event.SourceId)
+		protoEvent.Summary = employee + " out of office"
+	}
+	return protoEvent
+}
+
+func calendarStatusError(err error) error {
+	if errors.Is(err, repositories.ErrCalendarFeedNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
+}
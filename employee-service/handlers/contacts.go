@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"employee-service/models"
+	"employee-service/proto"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/mail"
+	"strings"
+)
+
+const (
+	maxContacts         = 20
+	maxAddresses        = 10
+	minPhoneDigits      = 5
+	maxPhoneLength      = 32
+	maxContactLength    = 255
+	maxPostalCodeLength = 16
+)
+
+// fromProtoContacts validates the contacts of a request. The first contact of
+// a kind without a primary one becomes primary.
+func fromProtoContacts(contacts []*proto.Contact) ([]models.Contact, error) {
+	if len(contacts) > maxContacts {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d contacts are allowed", maxContacts)
+	}
+
+	result := make([]models.Contact, 0, len(contacts))
+	hasPrimary := make(map[string]bool)
+	seen := make(map[models.Contact]bool)
+	for i, contact := range contacts {
+		if !models.IsContactKind(contact.Kind) {
+			return nil, status.Errorf(codes.InvalidArgument, "contacts[%d]: unknown kind %q, expected one of %v",
+				i, contact.Kind, models.ContactKinds)
+		}
+		value := strings.TrimSpace(contact.Value)
+		var err error
+		switch contact.Kind {
+		case models.ContactWorkEmail, models.ContactPersonalEmail:
+			value, err = normalizeEmail(value)
+		default:
+			err = validatePhone(value)
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "contacts[%d]: %s", i, err.Error())
+		}
+
+		key := models.Contact{Kind: contact.Kind, Value: strings.ToLower(value)}
+		if seen[key] {
+			return nil, status.Errorf(codes.InvalidArgument, "contacts[%d]: duplicate %s %q", i, contact.Kind, value)
+		}
+		seen[key] = true
+
+		if contact.Primary {
+			if hasPrimary[contact.Kind] {
+				return nil, status.Errorf(codes.InvalidArgument, "contacts[%d]: only one %s may be primary", i, contact.Kind)
+			}
+			hasPrimary[contact.Kind] = true
+		}
+		result = append(result, models.Contact{Kind: contact.Kind, Value: value, Primary: contact.Primary})
+	}
+
+	for i := range result {
+		if !hasPrimary[result[i].Kind] {
+			result[i].Primary = true
+			hasPrimary[result[i].Kind] = true
+		}
+	}
+	return result, nil
+}
+
+// fromProtoAddresses validates the addresses of a request, see
+// fromProtoContacts for primary addresses.
+func fromProtoAddresses(addresses []*proto.Address) ([]models.Address, error) {
+	if len(addresses) > maxAddresses {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d addresses are allowed", maxAddresses)
+	}
+
+	result := make([]models.Address, 0, len(addresses))
+	hasPrimary := make(map[string]bool)
+	for i, address := range addresses {
+		if !models.IsAddressKind(address.Kind) {
+			return nil, status.Errorf(codes.InvalidArgument, "addresses[%d]: unknown kind %q, expected one of %v",
+				i, address.Kind, models.AddressKinds)
+		}
+		converted := models.Address{
+			Kind:        address.Kind,
+			Line1:       strings.TrimSpace(address.Line1),
+			Line2:       strings.TrimSpace(address.Line2),
+			City:        strings.TrimSpace(address.City),
+			Region:      strings.TrimSpace(address.Region),
+			PostalCode:  strings.TrimSpace(address.PostalCode),
+			CountryCode: strings.ToUpper(strings.TrimSpace(address.CountryCode)),
+			Primary:     address.Primary,
+		}
+		if converted.Line1 == "" || converted.City == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "addresses[%d]: line1 and city are required", i)
+		}
+		for _, value := range []string{converted.Line1, converted.Line2, converted.City, converted.Region} {
+			if len(value) > maxContactLength {
+				return nil, status.Errorf(codes.InvalidArgument, "addresses[%d]: lines, city and region must be at most %d bytes",
+					i, maxContactLength)
+			}
+		}
+		if len(converted.PostalCode) > maxPostalCodeLength {
+			return nil, status.Errorf(codes.InvalidArgument, "addresses[%d]: postal_code must be at most %d bytes",
+				i, maxPostalCodeLength)
+		}
+		if !models.IsCountryCode(converted.CountryCode) {
+			return nil, status.Errorf(codes.InvalidArgument, "addresses[%d]: country_code must be an ISO 3166-1 alpha-2 code, got %q",
+				i, address.CountryCode)
+		}
+
+		if converted.Primary {
+			if hasPrimary[converted.Kind] {
+				return nil, status.Errorf(codes.InvalidArgument, "addresses[%d]: only one %s address may be primary",
+					i, converted.Kind)
+			}
+			hasPrimary[converted.Kind] = true
+		}
+		result = append(result, converted)
+	}
+
+	for i := range result {
+		if !hasPrimary[result[i].Kind] {
+			result[i].Primary = true
+			hasPrimary[result[i].Kind] = true
+		}
+	}
+	return result, nil
+}
+
+// normalizeEmail accepts a bare address such as "jane@example.com", without a
+// display name, and lower-cases its domain.
+func normalizeEmail(value string) (string, error) {
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Name != "" || address.Address != value || len(value) > maxContactLength {
+		return "", fmt.Errorf("invalid email address %q", value)
+	}
+	at := strings.LastIndex(value, "@")
+	if !strings.Contains(value[at+1:], ".") {
+		return "", fmt.Errorf("invalid email address %q", value)
+	}
+	return value[:at] + strings.ToLower(value[at:]), nil
+}
+
+// validatePhone accepts digits with an optional leading "+" and the usual
+// separators, e.g. "+49 (30) 1234-567".
+func validatePhone(value string) error {
+	digits := 0
+	for i, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '+' && i == 0:
+		case r == ' ' || r == '-' || r == '(' || r == ')' || r == '.':
+		default:
+			return fmt.Errorf("invalid phone number %q", value)
+		}
+	}
+	if digits < minPhoneDigits || len(value) > maxPhoneLength {
+		return fmt.Errorf("invalid phone number %q", value)
+	}
+	return nil
+}
+
+func toProtoContacts(contacts []models.Contact) []*proto.Contact {
+	var result []*proto.Contact
+	for _, contact := range contacts {
+		result = append(result, &proto.Contact{Kind: contact.Kind, Value: contact.Value, Primary: contact.Primary})
+	}
+	return result
+}
+
+func toProtoAddresses(addresses []models.Address) []*proto.Address {
+	var result []*proto.Address
+	for _, address := range addresses {
+		result = append(result, &proto.Address{
+			Kind:        address.Kind,
+			Line1:       address.Line1,
+			Line2:       address.Line2,
+			City:        address.City,
+			Region:      address.Region,
+			PostalCode:  address.PostalCode,
+			CountryCode: address.CountryCode,
+			Primary:     address.Primary,
+		})
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"employee-service/auth"
+	"employee-service/proto"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+func (h *EmployeeHandler) ExportEmployeeData(ctx context.Context, req *proto.ExportEmployeeDataRequest) (*proto.ExportEmployeeDataResponse, error) {
+	if err := auth.RequirePermission(ctx, auth.PermissionGDPRManage); err != nil {
+		return nil, err
+	}
+
+	dossier, err := h.repo.ExportEmployeeData(ctx, req.Id)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: repo export employee data: %w", err)
+		slog.ErrorContext(ctx, "export employee data failed", "id", req.Id, "error", err)
+		return nil, statusError(err)
+	}
+
+	resp := &proto.ExportEmployeeDataResponse{
+		Employee:   toProtoEmployee(dossier.Employee),
+		ExportedAt: dossier.ExportedAt.Format(time.RFC3339),
+	}
+	if dossier.ErasedAt != nil {
+		resp.ErasedAt = dossier.ErasedAt.UTC().Format(time.RFC3339)
+	}
+	for _, contact := range dossier.EmergencyContacts {
+		resp.EmergencyContacts = append(resp.EmergencyContacts, toProtoEmergencyContact(contact))
+	}
+	for _, balance := range dossier.LeaveBalances {
+		resp.LeaveBalances = append(resp.LeaveBalances, toProtoLeaveBalance(balance))
+	}
+	for _, request := range dossier.LeaveRequests {
+		resp.LeaveRequests = append(resp.LeaveRequests, toProtoLeaveRequest(request))
+	}
+	for _, entry := range dossier.LeaveLedger {
+		protoEntry := &proto.LeaveLedgerEntry{
+			Id:            entry.Id,
+			LeaveTypeId:   entry.LeaveTypeId,
+			LeaveTypeName: entry.LeaveTypeName,
+			Days:          entry.Days,
+			Kind:          entry.Kind,
+			Reason:        entry.Reason,
+			CallerId:      entry.CallerId,
+			CreatedAt:     entry.CreatedAt.UTC().Format(time.RFC3339),
+		}
+		if entry.LeaveRequestId != nil {
+			protoEntry.LeaveRequestId = *entry.LeaveRequestId
+		}
+		resp.LeaveLedger = append(resp.LeaveLedger, protoEntry)
+	}
+	for _, transfer := range dossier.Transfers {
+		resp.Transfers = append(resp.Transfers, toProtoTransfer(transfer))
+	}
+	for _, assignment := range dossier.Assignments {
+		resp.Assignments = append(resp.Assignments, toProtoAssignment(assignment))
+	}
+	for _, entry := range dossier.AuditHistory {
+		details, err := json.Marshal(entry.Details)
+		if err != nil {
+			return nil, fmt.Errorf("employee_handler: export employee data: marshal audit details: %w", err)
+		}
+		resp.AuditHistory = append(resp.AuditHistory, &proto.AuditEntry{
+			Id:         entry.Id,
+			EmployeeId: entry.EmployeeId,
+			Action:     entry.Action,
+			CallerId:   entry.CallerId,
+			Details:    string(details),
+			CreatedAt:  entry.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	return resp, nil
+}
+
+func (h *EmployeeHandler) EraseEmployee(ctx context.Context, req *proto.EraseEmployeeRequest) (*proto.EraseEmployeeResponse, error) {
+	if err := auth.RequirePermission(ctx, auth.PermissionGDPRManage); err != nil {
+		return &proto.EraseEmployeeResponse{Success: "Fail"}, err
+	}
+
+	if err := h.repo.EraseEmployee(ctx, req.Id); err != nil {
+		err = fmt.Errorf("employee_handler: repo erase employee: %w", err)
+		slog.ErrorContext(ctx, "erase employee failed", "id", req.Id, "error", err)
+		return &proto.EraseEmployeeResponse{Success: "Fail"}, statusError(err)
+	}
+
+	return &proto.EraseEmployeeResponse{Success: "Success"}, nil
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"employee-service/auth"
+	"employee-service/models"
+	"employee-service/proto"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const (
+	maxEmergencyContactPhones = 3
+	maxRelationLength         = 64
+)
+
+func (h *EmployeeHandler) AddEmergencyContact(ctx context.Context, req *proto.AddEmergencyContactRequest) (*proto.EmergencyContactResponse, error) {
+	if err := requireEmergencyContactAccess(ctx, req.EmployeeId); err != nil {
+		return nil, err
+	}
+	contact, err := fromEmergencyContactRequest(req.EmployeeId, req.Name, req.Relation, req.Phones)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := h.repo.AddEmergencyContact(ctx, contact)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: repo add emergency contact: %w", err)
+		slog.ErrorContext(ctx, "add emergency contact failed", "employee_id", req.EmployeeId, "error", err)
+		return nil, statusError(err)
+	}
+	return &proto.EmergencyContactResponse{EmergencyContact: toProtoEmergencyContact(created)}, nil
+}
+
+func (h *EmployeeHandler) ListEmergencyContacts(ctx context.Context, req *proto.ListEmergencyContactsRequest) (*proto.ListEmergencyContactsResponse, error) {
+	if err := requireEmergencyContactAccess(ctx, req.EmployeeId); err != nil {
+		return nil, err
+	}
+
+	contacts, err := h.repo.ListEmergencyContacts(ctx, req.EmployeeId)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: repo list emergency contacts: %w", err)
+		slog.ErrorContext(ctx, "list emergency contacts failed", "employee_id", req.EmployeeId, "error", err)
+		return nil, statusError(err)
+	}
+
+	resp := &proto.ListEmergencyContactsResponse{}
+	for _, contact := range contacts {
+		resp.EmergencyContacts = append(resp.EmergencyContacts, toProtoEmergencyContact(contact))
+	}
+	return resp, nil
+}
+
+func (h *EmployeeHandler) UpdateEmergencyContact(ctx context.Context, req *proto.UpdateEmergencyContactRequest) (*proto.EmergencyContactResponse, error) {
+	if err := requireEmergencyContactAccess(ctx, req.EmployeeId); err != nil {
+		return nil, err
+	}
+	contact, err := fromEmergencyContactRequest(req.EmployeeId, req.Name, req.Relation, req.Phones)
+	if err != nil {
+		return nil, err
+	}
+	contact.Id = req.Id
+
+	updated, err := h.repo.UpdateEmergencyContact(ctx, contact)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: repo update emergency contact: %w", err)
+		slog.ErrorContext(ctx, "update emergency contact failed", "id", req.Id, "error", err)
+		return nil, statusError(err)
+	}
+	return &proto.EmergencyContactResponse{EmergencyContact: toProtoEmergencyContact(updated)}, nil
+}
+
+func (h *EmployeeHandler) DeleteEmergencyContact(ctx context.Context, req *proto.DeleteEmergencyContactRequest) (*proto.DeleteEmergencyContactResponse, error) {
+	if err := requireEmergencyContactAccess(ctx, req.EmployeeId); err != nil {
+		return &proto.DeleteEmergencyContactResponse{Success: "Fail"}, err
+	}
+
+	if err := h.repo.DeleteEmergencyContact(ctx, req.EmployeeId, req.Id); err != nil {
+		err = fmt.Errorf("employee_handler: repo delete emergency contact: %w", err)
+		slog.ErrorContext(ctx, "delete emergency contact failed", "id", req.Id, "error", err)
+		return &proto.DeleteEmergencyContactResponse{Success: "Fail"}, statusError(err)
+	}
+	return &proto.DeleteEmergencyContactResponse{Success: "Success"}, nil
+}
+
+// requireEmergencyContactAccess lets the employee manage their own emergency
+// contacts, and HR admins those of everyone.
+func requireEmergencyContactAccess(ctx context.Context, employeeId int32) error {
+	caller := auth.CallerFromContext(ctx)
+	if caller.HasPermission(auth.PermissionEmergencyContactsManage) ||
+		(caller.EmployeeId != 0 && caller.EmployeeId == employeeId) {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "only employee %d or a caller with permission %q may access these emergency contacts",
+		employeeId, auth.PermissionEmergencyContactsManage)
+}
+
+func fromEmergencyContactRequest(employeeId int32, name, relation string, phones []string) (models.EmergencyContact, error) {
+	contact := models.EmergencyContact{
+		EmployeeId: employeeId,
+		Name:       strings.TrimSpace(name),
+		Relation:   strings.TrimSpace(relation),
+	}
+	if contact.Name == "" || len(contact.Name) > maxContactLength {
+		return models.EmergencyContact{}, status.Errorf(codes.InvalidArgument, "name is required and must be at most %d bytes",
+			maxContactLength)
+	}
+	if len(contact.Relation) > maxRelationLength {
+		return models.EmergencyContact{}, status.Errorf(codes.InvalidArgument, "relation must be at most %d bytes",
+			maxRelationLength)
+	}
+	if len(phones) == 0 || len(phones) > maxEmergencyContactPhones {
+		return models.EmergencyContact{}, status.Errorf(codes.InvalidArgument, "between 1 and %d phones are required",
+			maxEmergencyContactPhones)
+	}
+	for i, phone := range phones {
+		phone = strings.TrimSpace(phone)
+		if err := validatePhone(phone); err != nil {
+			return models.EmergencyContact{}, status.Errorf(codes.InvalidArgument, "phones[%d]: %s", i, err.Error())
+		}
+		contact.Phones = append(contact.Phones, phone)
+	}
+	return contact, nil
+}
+
+func toProtoEmergencyContact(contact models.EmergencyContact) *proto.EmergencyContact {
+	return &proto.EmergencyContact{
+		Id:         contact.Id,
+		EmployeeId: contact.EmployeeId,
+		Name:       contact.Name,
+		Relation:   contact.Relation,
+		Phones:     contact.Phones,
+		CreatedAt:  contact.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:  contact.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -2,11 +2,18 @@ package handlers
 
 import (
 	"context"
+	"employee-service/auth"
 	"employee-service/models"
 	"employee-service/proto"
 	"employee-service/repositories"
+	"employee-service/watch"
+	"errors"
 	"fmt"
-	"log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"log/slog"
 )
 
 type EmployeeHandlerInterface interface {
@@ -14,51 +21,61 @@ type EmployeeHandlerInterface interface {
 	DeleteEmployee(ctx context.Context, req *proto.DeleteEmployeeRequest) (*proto.DeleteEmployeeResponse, error)
 	ShowCompanyEmployees(ctx context.Context, req *proto.CompanyEmployeesRequest) (*proto.EmployeesResponse, error)
 	UpdateEmployee(ctx context.Context, req *proto.UpdateEmployeeRequest) (*proto.UpdateEmployeeResponse, error)
+	ExportEmployeeData(ctx context.Context, req *proto.ExportEmployeeDataRequest) (*proto.ExportEmployeeDataResponse, error)
+	EraseEmployee(ctx context.Context, req *proto.EraseEmployeeRequest) (*proto.EraseEmployeeResponse, error)
+	WatchEmployees(req *proto.WatchEmployeesRequest, stream proto.EmployeeService_WatchEmployeesServer) error
+	BatchAddEmployees(ctx context.Context, req *proto.BatchAddEmployeesRequest) (*proto.BatchEmployeesResponse, error)
+	BatchUpdateEmployees(ctx context.Context, req *proto.BatchUpdateEmployeesRequest) (*proto.BatchEmployeesResponse, error)
+	BatchDeleteEmployees(ctx context.Context, req *proto.BatchDeleteEmployeesRequest) (*proto.BatchEmployeesResponse, error)
+	GetDirectReports(ctx context.Context, req *proto.GetDirectReportsRequest) (*proto.EmployeesResponse, error)
+	GetReportingChain(ctx context.Context, req *proto.GetReportingChainRequest) (*proto.EmployeesResponse, error)
+	GetOrgSubtree(ctx context.Context, req *proto.GetOrgSubtreeRequest) (*proto.OrgSubtreeResponse, error)
+	ExportOrgChart(ctx context.Context, req *proto.ExportOrgChartRequest) (*proto.ExportOrgChartResponse, error)
+	HireEmployee(ctx context.Context, req *proto.HireEmployeeRequest) (*proto.EmploymentResponse, error)
+	TerminateEmployee(ctx context.Context, req *proto.TerminateEmployeeRequest) (*proto.EmploymentResponse, error)
+	RehireEmployee(ctx context.Context, req *proto.RehireEmployeeRequest) (*proto.EmploymentResponse, error)
+	SetOnLeave(ctx context.Context, req *proto.SetOnLeaveRequest) (*proto.EmploymentResponse, error)
+	TransferEmployee(ctx context.Context, req *proto.TransferEmployeeRequest) (*proto.TransferResponse, error)
+	CancelTransfer(ctx context.Context, req *proto.CancelTransferRequest) (*proto.TransferResponse, error)
+	ListTransfers(ctx context.Context, req *proto.ListTransfersRequest) (*proto.ListTransfersResponse, error)
+	GetAssignmentHistory(ctx context.Context, req *proto.GetAssignmentHistoryRequest) (*proto.AssignmentHistoryResponse, error)
+	AddEmergencyContact(ctx context.Context, req *proto.AddEmergencyContactRequest) (*proto.EmergencyContactResponse, error)
+	ListEmergencyContacts(ctx context.Context, req *proto.ListEmergencyContactsRequest) (*proto.ListEmergencyContactsResponse, error)
+	UpdateEmergencyContact(ctx context.Context, req *proto.UpdateEmergencyContactRequest) (*proto.EmergencyContactResponse, error)
+	DeleteEmergencyContact(ctx context.Context, req *proto.DeleteEmergencyContactRequest) (*proto.DeleteEmergencyContactResponse, error)
 }
 
 type EmployeeHandler struct {
-	repo repositories.EmployeeRepository
+	repo   repositories.EmployeeRepository
+	outbox *repositories.OutboxRepository
+	hub    *watch.Hub
 	proto.UnimplementedEmployeeServiceServer
 }
 
-func NewEmployeeHandler(repo repositories.EmployeeRepository) *EmployeeHandler {
-	return &EmployeeHandler{repo: repo}
+func NewEmployeeHandler(repo repositories.EmployeeRepository, outbox *repositories.OutboxRepository, hub *watch.Hub) *EmployeeHandler {
+	return &EmployeeHandler{repo: repo, outbox: outbox, hub: hub}
 }
 
 func (h *EmployeeHandler) AddEmployee(ctx context.Context, req *proto.AddEmployeeRequest) (*proto.AddEmployeeResponse, error) {
-	employee := models.Employee{
-		Name:      req.Name,
-		Surname:   req.Surname,
-		Phone:     req.Phone,
-		CompanyId: req.CompanyId,
-	}
-	if req.Passport != nil {
-		employee.Passport = models.Passport{
-			Type:   req.Passport.Type,
-			Number: req.Passport.Number,
-		}
-	} else {
-		employee.Passport = models.Passport{
-			Type:   "",
-			Number: "",
-		}
+	employee, err := fromAddRequest(req)
+	if err != nil {
+		return nil, err
 	}
-	if req.Department == nil {
-		employee.Department = models.Department{
-			Name:  "",
-			Phone: "",
-		}
-	} else {
-		employee.Department = models.Department{
-			Name:  req.Department.Name,
-			Phone: req.Department.Phone,
-		}
+
+	key, err := idempotencyKey(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
-	id, err := h.repo.AddEmployee(ctx, employee)
+	id, replayed, err := h.repo.AddEmployee(ctx, employee, key)
 	if err != nil {
-		log.Printf("employee_handler: repo add employee: %w", err)
-		return nil, fmt.Errorf("employee_handler: repo add employee: %w", err)
+		err = fmt.Errorf("employee_handler: repo add employee: %w", err)
+		slog.ErrorContext(ctx, "add employee failed", "error", err)
+		return nil, statusError(err)
+	}
+	if replayed {
+		slog.InfoContext(ctx, "add employee replayed", "id", id)
+		grpc.SetHeader(ctx, metadata.Pairs(IdempotentReplayedKey, "true"))
 	}
 
 	return &proto.AddEmployeeResponse{Id: id}, nil
@@ -67,47 +84,46 @@ func (h *EmployeeHandler) AddEmployee(ctx context.Context, req *proto.AddEmploye
 func (h *EmployeeHandler) DeleteEmployee(ctx context.Context, req *proto.DeleteEmployeeRequest) (*proto.DeleteEmployeeResponse, error) {
 	if err := h.repo.DeleteEmployee(ctx, req.Id); err != nil {
 		err = fmt.Errorf("employee_handler: repo delete employee: %w", err)
-		log.Printf("%w", err)
-		return &proto.DeleteEmployeeResponse{Success: "Fail"}, err
+		slog.ErrorContext(ctx, "delete employee failed", "id", req.Id, "error", err)
+		return &proto.DeleteEmployeeResponse{Success: "Fail"}, statusError(err)
 	}
 
 	return &proto.DeleteEmployeeResponse{Success: "Success"}, nil
 }
 
 func (h *EmployeeHandler) ShowCompanyEmployees(ctx context.Context, req *proto.CompanyEmployeesRequest) (*proto.EmployeesResponse, error) {
+	if err := validateFields(req.Fields); err != nil {
+		return &proto.EmployeesResponse{}, err
+	}
+
 	var department models.Department
-	if req.Department == nil {
+	if req.Department != nil {
 		department = models.Department{
-			Name:  "",
-			Phone: "",
+			Name:  req.Department.Name,
+			Phone: req.Department.Phone,
 		}
 	}
+
+	statuses, err := listingStatuses(req.Statuses)
+	if err != nil {
+		return &proto.EmployeesResponse{}, err
+	}
+
 	employees, err := h.repo.ShowCompanyEmployees(
-		ctx, req.CompanyId, department)
+		ctx, req.CompanyId, department, statuses, req.CustomAttributes)
 
 	if err != nil {
 		err = fmt.Errorf("employee_handler: repo show comp employees: %w", err)
-		log.Printf("%w", err)
-		return &proto.EmployeesResponse{}, err
+		slog.ErrorContext(ctx, "show company employees failed", "company_id", req.CompanyId, "error", err)
+		return &proto.EmployeesResponse{}, statusError(err)
 	}
 
+	caller := auth.CallerFromContext(ctx)
+
 	var resp = &proto.EmployeesResponse{}
 	for _, employee := range employees {
-		protoEmployee := &proto.Employee{
-			Id:        employee.Id,
-			Name:      employee.Name,
-			Surname:   employee.Surname,
-			Phone:     employee.Phone,
-			CompanyId: employee.CompanyId,
-			Passport: &proto.Employee_Passport{
-				Type:   employee.Passport.Type,
-				Number: employee.Passport.Number,
-			},
-			Department: &proto.Employee_Department{
-				Name:  employee.Department.Name,
-				Phone: employee.Department.Phone,
-			},
-		}
+		protoEmployee := toProtoEmployee(employee)
+		shapeEmployee(protoEmployee, caller, req.Fields)
 		resp.Employees = append(resp.Employees, protoEmployee)
 	}
 
@@ -115,12 +131,93 @@ func (h *EmployeeHandler) ShowCompanyEmployees(ctx context.Context, req *proto.C
 }
 
 func (h *EmployeeHandler) UpdateEmployee(ctx context.Context, req *proto.UpdateEmployeeRequest) (*proto.UpdateEmployeeResponse, error) {
+	employee, err := fromUpdateRequest(req)
+	if err != nil {
+		return &proto.UpdateEmployeeResponse{Success: "Fail"}, err
+	}
+
+	err = h.repo.UpdateEmployee(ctx, employee)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: update empl:repo err: %w", err)
+		slog.ErrorContext(ctx, "update employee failed", "id", req.Id, "error", err)
+		return &proto.UpdateEmployeeResponse{Success: "Fail"}, statusError(err)
+	}
+
+	return &proto.UpdateEmployeeResponse{Success: "Success"}, nil
+}
+
+func fromAddRequest(req *proto.AddEmployeeRequest) (models.Employee, error) {
+	employee := models.Employee{
+		Name:      req.Name,
+		Surname:   req.Surname,
+		Phone:     req.Phone,
+		CompanyId: req.CompanyId,
+	}
+	if req.ManagerId != 0 {
+		employee.ManagerId = &req.ManagerId
+	}
+	if req.PositionId != 0 {
+		employee.PositionId = &req.PositionId
+	}
+
+	switch req.Status {
+	case "", models.StatusActive, models.StatusOnboarding, models.StatusCandidate:
+		employee.Employment.Status = req.Status
+	default:
+		return models.Employee{}, status.Errorf(codes.InvalidArgument,
+			"new employees must be %s, %s or %s, got %q",
+			models.StatusCandidate, models.StatusOnboarding, models.StatusActive, req.Status)
+	}
+	hireDate, err := parseDate("hire_date", req.HireDate)
+	if err != nil {
+		return models.Employee{}, err
+	}
+	if hireDate != nil && req.Status == models.StatusCandidate {
+		return models.Employee{}, status.Error(codes.InvalidArgument, "candidates have no hire_date")
+	}
+	employee.Employment.HireDate = hireDate
+	if employee.Contacts, err = fromProtoContacts(req.Contacts); err != nil {
+		return models.Employee{}, err
+	}
+	if employee.Addresses, err = fromProtoAddresses(req.Addresses); err != nil {
+		return models.Employee{}, err
+	}
+	employee.CustomAttributes = req.CustomAttributes
+	if req.Passport != nil {
+		employee.Passport = models.Passport{
+			Type:   req.Passport.Type,
+			Number: req.Passport.Number,
+		}
+	} else {
+		employee.Passport = models.Passport{
+			Type:   "",
+			Number: "",
+		}
+	}
+	if req.Department == nil {
+		employee.Department = models.Department{
+			Name:  "",
+			Phone: "",
+		}
+	} else {
+		employee.Department = models.Department{
+			Name:  req.Department.Name,
+			Phone: req.Department.Phone,
+		}
+	}
+
+	return employee, nil
+}
+
+func fromUpdateRequest(req *proto.UpdateEmployeeRequest) (models.Employee, error) {
 	var employee models.Employee
 	employee.Id = req.Id
 	employee.Name = req.Name
 	employee.Surname = req.Surname
 	employee.Phone = req.Phone
 	employee.CompanyId = req.CompanyId
+	employee.ManagerId = req.ManagerId
+	employee.PositionId = req.PositionId
 	if req.Passport == nil {
 		employee.Passport = models.Passport{
 			Type:   "",
@@ -143,13 +240,78 @@ func (h *EmployeeHandler) UpdateEmployee(ctx context.Context, req *proto.UpdateE
 			Phone: req.Department.Phone,
 		}
 	}
+	if req.Contacts != nil {
+		contacts, err := fromProtoContacts(req.Contacts.Items)
+		if err != nil {
+			return models.Employee{}, err
+		}
+		employee.Contacts = contacts
+	}
+	if req.Addresses != nil {
+		addresses, err := fromProtoAddresses(req.Addresses.Items)
+		if err != nil {
+			return models.Employee{}, err
+		}
+		employee.Addresses = addresses
+	}
+	employee.CustomAttributes = req.CustomAttributes
 
-	err := h.repo.UpdateEmployee(ctx, employee)
-	if err != nil {
-		err = fmt.Errorf("employee_handler: update empl:repo err: %w", err)
-		log.Printf("%w", err)
-		return &proto.UpdateEmployeeResponse{Success: "Fail"}, err
+	return employee, nil
+}
+
+func toProtoEmployee(employee models.Employee) *proto.Employee {
+	protoEmployee := &proto.Employee{
+		Id:        employee.Id,
+		Name:      employee.Name,
+		Surname:   employee.Surname,
+		Phone:     employee.Phone,
+		CompanyId: employee.CompanyId,
+		Passport: &proto.Employee_Passport{
+			Type:   employee.Passport.Type,
+			Number: employee.Passport.Number,
+		},
+		Department: &proto.Employee_Department{
+			Name:  employee.Department.Name,
+			Phone: employee.Department.Phone,
+		},
+	}
+	if employee.ManagerId != nil {
+		protoEmployee.ManagerId = *employee.ManagerId
+	}
+	if employee.PositionId != nil {
+		protoEmployee.PositionId = *employee.PositionId
 	}
+	protoEmployee.Status = employee.Employment.Status
+	protoEmployee.HireDate = formatDate(employee.Employment.HireDate)
+	protoEmployee.TerminationDate = formatDate(employee.Employment.TerminationDate)
+	protoEmployee.TerminationReason = employee.Employment.TerminationReason
+	protoEmployee.Contacts = toProtoContacts(employee.Contacts)
+	protoEmployee.Addresses = toProtoAddresses(employee.Addresses)
+	protoEmployee.CustomAttributes = employee.CustomAttributes
+	return protoEmployee
+}
 
-	return &proto.UpdateEmployeeResponse{Success: "Success"}, nil
+// statusError converts well-known repository errors to gRPC status errors so
+// the gateway can map them to HTTP status codes.
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, repositories.ErrEmployeeNotFound), errors.Is(err, repositories.ErrTransferNotFound),
+		errors.Is(err, repositories.ErrEmergencyContactNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repositories.ErrInvalidCustomAttribute):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, repositories.ErrWorkEmailTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, repositories.ErrIdempotencyKeyReused):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, repositories.ErrInvalidManager), errors.Is(err, repositories.ErrInvalidPosition),
+		errors.Is(err, repositories.ErrInvalidTransition), errors.Is(err, repositories.ErrInvalidEmploymentDates),
+		errors.Is(err, repositories.ErrTransferPending), errors.Is(err, repositories.ErrTransferNotPending),
+		errors.Is(err, repositories.ErrTooManyEmergencyContacts):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, repositories.ErrBatchItemFailed):
+		return status.Error(codes.Aborted, err.Error())
+	default:
+		return err
+	}
 }
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"employee-service/models"
+	"employee-service/proto"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"time"
+)
+
+func (h *EmployeeHandler) HireEmployee(ctx context.Context, req *proto.HireEmployeeRequest) (*proto.EmploymentResponse, error) {
+	hireDate, err := parseDate("hire_date", req.HireDate)
+	if err != nil {
+		return nil, err
+	}
+
+	employment, err := h.repo.Hire(ctx, req.Id, hireDate, req.Onboarding)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: repo hire: %w", err)
+		slog.ErrorContext(ctx, "hire employee failed", "id", req.Id, "error", err)
+		return nil, statusError(err)
+	}
+	return toEmploymentResponse(req.Id, employment), nil
+}
+
+func (h *EmployeeHandler) TerminateEmployee(ctx context.Context, req *proto.TerminateEmployeeRequest) (*proto.EmploymentResponse, error) {
+	terminationDate, err := parseDate("termination_date", req.TerminationDate)
+	if err != nil {
+		return nil, err
+	}
+	if req.Reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+
+	employment, err := h.repo.Terminate(ctx, req.Id, terminationDate, req.Reason)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: repo terminate: %w", err)
+		slog.ErrorContext(ctx, "terminate employee failed", "id", req.Id, "error", err)
+		return nil, statusError(err)
+	}
+	return toEmploymentResponse(req.Id, employment), nil
+}
+
+func (h *EmployeeHandler) RehireEmployee(ctx context.Context, req *proto.RehireEmployeeRequest) (*proto.EmploymentResponse, error) {
+	hireDate, err := parseDate("hire_date", req.HireDate)
+	if err != nil {
+		return nil, err
+	}
+
+	employment, err := h.repo.Rehire(ctx, req.Id, hireDate, req.Onboarding)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: repo rehire: %w", err)
+		slog.ErrorContext(ctx, "rehire employee failed", "id", req.Id, "error", err)
+		return nil, statusError(err)
+	}
+	return toEmploymentResponse(req.Id, employment), nil
+}
+
+func (h *EmployeeHandler) SetOnLeave(ctx context.Context, req *proto.SetOnLeaveRequest) (*proto.EmploymentResponse, error) {
+	employment, err := h.repo.SetOnLeave(ctx, req.Id, req.OnLeave)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: repo set on leave: %w", err)
+		slog.ErrorContext(ctx, "set on leave failed", "id", req.Id, "error", err)
+		return nil, statusError(err)
+	}
+	return toEmploymentResponse(req.Id, employment), nil
+}
+
+// listingStatuses returns the statuses an employee listing is limited to:
+// active by default, none for "all".
+func listingStatuses(statuses []string) ([]string, error) {
+	if len(statuses) == 0 {
+		return []string{models.StatusActive}, nil
+	}
+	for _, s := range statuses {
+		if s == "all" {
+			return nil, nil
+		}
+		if !models.IsEmploymentStatus(s) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown employment status %q, expected one of %v or \"all\"",
+				s, models.EmploymentStatuses)
+		}
+	}
+	return statuses, nil
+}
+
+// parseDate parses an optional YYYY-MM-DD date of the named request field.
+func parseDate(field, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(models.DateLayout, value)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s must be a YYYY-MM-DD date, got %q", field, value)
+	}
+	return &date, nil
+}
+
+func formatDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(models.DateLayout)
+}
+
+func toEmploymentResponse(id int32, employment models.Employment) *proto.EmploymentResponse {
+	return &proto.EmploymentResponse{
+		Id:                id,
+		Status:            employment.Status,
+		HireDate:          formatDate(employment.HireDate),
+		TerminationDate:   formatDate(employment.TerminationDate),
+		TerminationReason: employment.TerminationReason,
+	}
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"employee-service/auth"
+	"employee-service/proto"
+	"fmt"
+	"log/slog"
+)
+
+func (h *EmployeeHandler) GetDirectReports(ctx context.Context, req *proto.GetDirectReportsRequest) (*proto.EmployeesResponse, error) {
+	if err := validateFields(req.Fields); err != nil {
+		return nil, err
+	}
+
+	employees, err := h.repo.DirectReports(ctx, req.Id)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: repo direct reports: %w", err)
+		slog.ErrorContext(ctx, "get direct reports failed", "id", req.Id, "error", err)
+		return nil, statusError(err)
+	}
+
+	caller := auth.CallerFromContext(ctx)
+
+	resp := &proto.EmployeesResponse{}
+	for _, employee := range employees {
+		protoEmployee := toProtoEmployee(employee)
+		shapeEmployee(protoEmployee, caller, req.Fields)
+		resp.Employees = append(resp.Employees, protoEmployee)
+	}
+	return resp, nil
+}
+
+func (h *EmployeeHandler) GetReportingChain(ctx context.Context, req *proto.GetReportingChainRequest) (*proto.EmployeesResponse, error) {
+	if err := validateFields(req.Fields); err != nil {
+		return nil, err
+	}
+
+	employees, err := h.repo.ReportingChain(ctx, req.Id)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: repo reporting chain: %w", err)
+		slog.ErrorContext(ctx, "get reporting chain failed", "id", req.Id, "error", err)
+		return nil, statusError(err)
+	}
+
+	caller := auth.CallerFromContext(ctx)
+
+	resp := &proto.EmployeesResponse{}
+	for _, employee := range employees {
+		protoEmployee := toProtoEmployee(employee)
+		shapeEmployee(protoEmployee, caller, req.Fields)
+		resp.Employees = append(resp.Employees, protoEmployee)
+	}
+	return resp, nil
+}
+
+func (h *EmployeeHandler) GetOrgSubtree(ctx context.Context, req *proto.GetOrgSubtreeRequest) (*proto.OrgSubtreeResponse, error) {
+	if err := validateFields(req.Fields); err != nil {
+		return nil, err
+	}
+
+	nodes, err := h.repo.OrgSubtree(ctx, req.Id, req.MaxDepth)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: repo org subtree: %w", err)
+		slog.ErrorContext(ctx, "get org subtree failed", "id", req.Id, "error", err)
+		return nil, statusError(err)
+	}
+
+	caller := auth.CallerFromContext(ctx)
+
+	resp := &proto.OrgSubtreeResponse{}
+	for _, node := range nodes {
+		protoEmployee := toProtoEmployee(node.Employee)
+		shapeEmployee(protoEmployee, caller, req.Fields)
+		resp.Nodes = append(resp.Nodes, &proto.OrgNode{Employee: protoEmployee, Depth: node.Depth})
+	}
+	return resp, nil
+}
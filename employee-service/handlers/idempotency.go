@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"employee-service/auth"
+	"employee-service/models"
+	"employee-service/proto"
+	"encoding/hex"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// IdempotentReplayedKey is set in the response header metadata when a retried
+// request returns the response of the original one.
+const IdempotentReplayedKey = "x-idempotent-replayed"
+
+const maxIdempotencyKeyLength = 255
+
+// idempotencyKey returns the idempotency key of the request, or nil if the
+// client did not send one. The request hash covers every field except the key.
+func idempotencyKey(ctx context.Context, req *proto.AddEmployeeRequest) (*models.IdempotencyKey, error) {
+	if req.IdempotencyKey == "" {
+		return nil, nil
+	}
+	if len(req.IdempotencyKey) > maxIdempotencyKeyLength {
+		return nil, status.Errorf(codes.InvalidArgument, "idempotency_key must be at most %d characters",
+			maxIdempotencyKeyLength)
+	}
+
+	unkeyed := protobuf.Clone(req).(*proto.AddEmployeeRequest)
+	unkeyed.IdempotencyKey = ""
+	body, err := protobuf.MarshalOptions{Deterministic: true}.Marshal(unkeyed)
+	if err != nil {
+		return nil, fmt.Errorf("employee_handler: idempotency key: marshal request: %w", err)
+	}
+	hash := sha256.Sum256(body)
+
+	return &models.IdempotencyKey{
+		CallerId:    auth.CallerFromContext(ctx).Id,
+		Key:         req.IdempotencyKey,
+		RequestHash: hex.EncodeToString(hash[:]),
+	}, nil
+}
@@ -0,0 +1,359 @@
+package handlers
+
+import (
+	"context"
+	"employee-service/auth"
+	"employee-service/models"
+	"employee-service/proto"
+	"employee-service/repositories"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"math"
+	"time"
+)
+
+// maxLeaveDays bounds the span of a leave request and of an absence calendar.
+const maxLeaveDays = 366
+
+type LeaveHandlerInterface interface {
+	CreateLeaveType(ctx context.Context, req *proto.CreateLeaveTypeRequest) (*proto.LeaveTypeResponse, error)
+	ListLeaveTypes(ctx context.Context, req *proto.ListLeaveTypesRequest) (*proto.ListLeaveTypesResponse, error)
+	UpdateLeaveType(ctx context.Context, req *proto.UpdateLeaveTypeRequest) (*proto.LeaveTypeResponse, error)
+	GetLeaveBalances(ctx context.Context, req *proto.GetLeaveBalancesRequest) (*proto.LeaveBalancesResponse, error)
+	AdjustLeaveBalance(ctx context.Context, req *proto.AdjustLeaveBalanceRequest) (*proto.LeaveBalanceResponse, error)
+	SubmitLeave(ctx context.Context, req *proto.SubmitLeaveRequest) (*proto.LeaveRequestResponse, error)
+	DecideLeave(ctx context.Context, req *proto.DecideLeaveRequest) (*proto.LeaveRequestResponse, error)
+	CancelLeave(ctx context.Context, req *proto.CancelLeaveRequest) (*proto.LeaveRequestResponse, error)
+	ListLeaveRequests(ctx context.Context, req *proto.ListLeaveRequestsRequest) (*proto.ListLeaveRequestsResponse, error)
+	ListAbsences(ctx context.Context, req *proto.ListAbsencesRequest) (*proto.ListAbsencesResponse, error)
+}
+
+type LeaveHandler struct {
+	repo *repositories.LeaveRepository
+	proto.UnimplementedLeaveServiceServer
+}
+
+func NewLeaveHandler(repo *repositories.LeaveRepository) *LeaveHandler {
+	return &LeaveHandler{repo: repo}
+}
+
+func (h *LeaveHandler) CreateLeaveType(ctx context.Context, req *proto.CreateLeaveTypeRequest) (*proto.LeaveTypeResponse, error) {
+	if err := auth.RequirePermission(ctx, auth.PermissionLeaveManage); err != nil {
+		return nil, err
+	}
+	if req.CompanyId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "company_id is required")
+	}
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if err := validateAccrual(req.AccrualDaysPerMonth, req.MaxBalance); err != nil {
+		return nil, err
+	}
+
+	leaveType := models.LeaveType{
+		CompanyId:           req.CompanyId,
+		Name:                req.Name,
+		AccrualDaysPerMonth: req.AccrualDaysPerMonth,
+		Unlimited:           req.Unlimited,
+	}
+	if req.MaxBalance != 0 {
+		leaveType.MaxBalance = &req.MaxBalance
+	}
+
+	created, err := h.repo.CreateLeaveType(ctx, leaveType)
+	if err != nil {
+		err = fmt.Errorf("leave_handler: repo create leave type: %w", err)
+		slog.ErrorContext(ctx, "create leave type failed", "company_id", req.CompanyId, "error", err)
+		return nil, leaveStatusError(err)
+	}
+	return &proto.LeaveTypeResponse{LeaveType: toProtoLeaveType(created)}, nil
+}
+
+func (h *LeaveHandler) ListLeaveTypes(ctx context.Context, req *proto.ListLeaveTypesRequest) (*proto.ListLeaveTypesResponse, error) {
+	leaveTypes, err := h.repo.ListLeaveTypes(ctx, req.CompanyId)
+	if err != nil {
+		err = fmt.Errorf("leave_handler: repo list leave types: %w", err)
+		slog.ErrorContext(ctx, "list leave types failed", "company_id", req.CompanyId, "error", err)
+		return nil, err
+	}
+
+	resp := &proto.ListLeaveTypesResponse{}
+	for _, leaveType := range leaveTypes {
+		resp.LeaveTypes = append(resp.LeaveTypes, toProtoLeaveType(leaveType))
+	}
+	return resp, nil
+}
+
+func (h *LeaveHandler) UpdateLeaveType(ctx context.Context, req *proto.UpdateLeaveTypeRequest) (*proto.LeaveTypeResponse, error) {
+	if err := auth.RequirePermission(ctx, auth.PermissionLeaveManage); err != nil {
+		return nil, err
+	}
+	if req.Name != nil && *req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name must not be empty")
+	}
+	if err := validateAccrual(req.GetAccrualDaysPerMonth(), req.GetMaxBalance()); err != nil {
+		return nil, err
+	}
+
+	updated, err := h.repo.UpdateLeaveType(ctx, req.CompanyId, req.Id, models.LeaveTypeUpdate{
+		Name:                req.Name,
+		AccrualDaysPerMonth: req.AccrualDaysPerMonth,
+		MaxBalance:          req.MaxBalance,
+	})
+	if err != nil {
+		err = fmt.Errorf("leave_handler: repo update leave type: %w", err)
+		slog.ErrorContext(ctx, "update leave type failed", "id", req.Id, "error", err)
+		return nil, leaveStatusError(err)
+	}
+	return &proto.LeaveTypeResponse{LeaveType: toProtoLeaveType(updated)}, nil
+}
+
+func (h *LeaveHandler) GetLeaveBalances(ctx context.Context, req *proto.GetLeaveBalancesRequest) (*proto.LeaveBalancesResponse, error) {
+	balances, err := h.repo.LeaveBalances(ctx, req.EmployeeId, leaveActor(ctx))
+	if err != nil {
+		err = fmt.Errorf("leave_handler: repo leave balances: %w", err)
+		slog.ErrorContext(ctx, "get leave balances failed", "employee_id", req.EmployeeId, "error", err)
+		return nil, leaveStatusError(err)
+	}
+
+	resp := &proto.LeaveBalancesResponse{}
+	for _, balance := range balances {
+		resp.Balances = append(resp.Balances, toProtoLeaveBalance(balance))
+	}
+	return resp, nil
+}
+
+func (h *LeaveHandler) AdjustLeaveBalance(ctx context.Context, req *proto.AdjustLeaveBalanceRequest) (*proto.LeaveBalanceResponse, error) {
+	if err := auth.RequirePermission(ctx, auth.PermissionLeaveManage); err != nil {
+		return nil, err
+	}
+	if req.Days == 0 || math.IsNaN(req.Days) || math.IsInf(req.Days, 0) {
+		return nil, status.Error(codes.InvalidArgument, "days must be a non-zero number")
+	}
+	if req.Reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+
+	balance, err := h.repo.AdjustLeaveBalance(ctx, req.EmployeeId, req.LeaveTypeId, req.Days, req.Reason)
+	if err != nil {
+		err = fmt.Errorf("leave_handler: repo adjust leave balance: %w", err)
+		slog.ErrorContext(ctx, "adjust leave balance failed", "employee_id", req.EmployeeId, "error", err)
+		return nil, leaveStatusError(err)
+	}
+	return &proto.LeaveBalanceResponse{Balance: toProtoLeaveBalance(balance)}, nil
+}
+
+func (h *LeaveHandler) SubmitLeave(ctx context.Context, req *proto.SubmitLeaveRequest) (*proto.LeaveRequestResponse, error) {
+	actor := leaveActor(ctx)
+	if !actor.Admin && (actor.EmployeeId == 0 || actor.EmployeeId != req.EmployeeId) {
+		return nil, status.Errorf(codes.PermissionDenied, "only employee %d or a caller with permission %q may request this leave",
+			req.EmployeeId, auth.PermissionLeaveManage)
+	}
+
+	startDate, endDate, err := parseDateRange("start_date", req.StartDate, "end_date", req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := h.repo.SubmitLeave(ctx, models.LeaveRequest{
+		EmployeeId:  req.EmployeeId,
+		LeaveTypeId: req.LeaveTypeId,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Reason:      req.Reason,
+	})
+	if err != nil {
+		err = fmt.Errorf("leave_handler: repo submit leave: %w", err)
+		slog.ErrorContext(ctx, "submit leave failed", "employee_id", req.EmployeeId, "error", err)
+		return nil, leaveStatusError(err)
+	}
+	return &proto.LeaveRequestResponse{LeaveRequest: toProtoLeaveRequest(request)}, nil
+}
+
+func (h *LeaveHandler) DecideLeave(ctx context.Context, req *proto.DecideLeaveRequest) (*proto.LeaveRequestResponse, error) {
+	request, err := h.repo.DecideLeave(ctx, req.Id, req.Approve, req.Comment, leaveActor(ctx))
+	if err != nil {
+		err = fmt.Errorf("leave_handler: repo decide leave: %w", err)
+		slog.ErrorContext(ctx, "decide leave failed", "id", req.Id, "error", err)
+		return nil, leaveStatusError(err)
+	}
+	return &proto.LeaveRequestResponse{LeaveRequest: toProtoLeaveRequest(request)}, nil
+}
+
+func (h *LeaveHandler) CancelLeave(ctx context.Context, req *proto.CancelLeaveRequest) (*proto.LeaveRequestResponse, error) {
+	request, err := h.repo.CancelLeave(ctx, req.Id, leaveActor(ctx))
+	if err != nil {
+		err = fmt.Errorf("leave_handler: repo cancel leave: %w", err)
+		slog.ErrorContext(ctx, "cancel leave failed", "id", req.Id, "error", err)
+		return nil, leaveStatusError(err)
+	}
+	return &proto.LeaveRequestResponse{LeaveRequest: toProtoLeaveRequest(request)}, nil
+}
+
+func (h *LeaveHandler) ListLeaveRequests(ctx context.Context, req *proto.ListLeaveRequestsRequest) (*proto.ListLeaveRequestsResponse, error) {
+	for _, s := range req.Statuses {
+		if !models.IsLeaveStatus(s) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown leave status %q, expected one of %v",
+				s, models.LeaveStatuses)
+		}
+	}
+
+	requests, err := h.repo.ListLeaveRequests(ctx, req.EmployeeId, req.Statuses, leaveActor(ctx))
+	if err != nil {
+		err = fmt.Errorf("leave_handler: repo list leave requests: %w", err)
+		slog.ErrorContext(ctx, "list leave requests failed", "employee_id", req.EmployeeId, "error", err)
+		return nil, leaveStatusError(err)
+	}
+
+	resp := &proto.ListLeaveRequestsResponse{}
+	for _, request := range requests {
+		resp.LeaveRequests = append(resp.LeaveRequests, toProtoLeaveRequest(request))
+	}
+	return resp, nil
+}
+
+func (h *LeaveHandler) ListAbsences(ctx context.Context, req *proto.ListAbsencesRequest) (*proto.ListAbsencesResponse, error) {
+	if req.From == "" || req.To == "" {
+		return nil, status.Error(codes.InvalidArgument, "from and to are required")
+	}
+	from, to, err := parseDateRange("from", req.From, "to", req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	var department models.Department
+	if req.Department != nil {
+		department = models.Department{Name: req.Department.Name, Phone: req.Department.Phone}
+	}
+
+	absences, err := h.repo.ListAbsences(ctx, req.CompanyId, from, to, department, req.IncludePending, leaveActor(ctx))
+	if err != nil {
+		err = fmt.Errorf("leave_handler: repo list absences: %w", err)
+		slog.ErrorContext(ctx, "list absences failed", "company_id", req.CompanyId, "error", err)
+		return nil, leaveStatusError(err)
+	}
+
+	resp := &proto.ListAbsencesResponse{}
+	for _, absence := range absences {
+		resp.Absences = append(resp.Absences, &proto.Absence{
+			LeaveRequestId: absence.LeaveRequestId,
+			EmployeeId:     absence.EmployeeId,
+			Name:           absence.Name,
+			Surname:        absence.Surname,
+			Department:     &proto.Employee_Department{Name: absence.Department.Name, Phone: absence.Department.Phone},
+			LeaveTypeName:  absence.LeaveTypeName,
+			StartDate:      formatDate(&absence.StartDate),
+			EndDate:        formatDate(&absence.EndDate),
+			Status:         absence.Status,
+		})
+	}
+	return resp, nil
+}
+
+func leaveActor(ctx context.Context) models.LeaveActor {
+	caller := auth.CallerFromContext(ctx)
+	return models.LeaveActor{EmployeeId: caller.EmployeeId, Admin: caller.HasPermission(auth.PermissionLeaveManage)}
+}
+
+func validateAccrual(accrualDaysPerMonth, maxBalance float64) error {
+	if accrualDaysPerMonth < 0 || accrualDaysPerMonth > 31 {
+		return status.Error(codes.InvalidArgument, "accrual_days_per_month must be between 0 and 31")
+	}
+	if maxBalance < 0 {
+		return status.Error(codes.InvalidArgument, "max_balance must not be negative")
+	}
+	return nil
+}
+
+// parseDateRange parses the required start and end dates of the named request
+// fields, which may span at most maxLeaveDays.
+func parseDateRange(startField, startValue, endField, endValue string) (time.Time, time.Time, error) {
+	start, err := parseDate(startField, startValue)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err := parseDate(endField, endValue)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if start == nil || end == nil {
+		return time.Time{}, time.Time{}, status.Errorf(codes.InvalidArgument, "%s and %s are required", startField, endField)
+	}
+	if end.Before(*start) {
+		return time.Time{}, time.Time{}, status.Errorf(codes.InvalidArgument, "%s must not be before %s", endField, startField)
+	}
+	if end.Sub(*start) >= maxLeaveDays*24*time.Hour {
+		return time.Time{}, time.Time{}, status.Errorf(codes.InvalidArgument, "%s and %s may be at most %d days apart",
+			startField, endField, maxLeaveDays-1)
+	}
+	return *start, *end, nil
+}
+
+func toProtoLeaveType(leaveType models.LeaveType) *proto.LeaveType {
+	protoLeaveType := &proto.LeaveType{
+		Id:                  leaveType.Id,
+		CompanyId:           leaveType.CompanyId,
+		Name:                leaveType.Name,
+		AccrualDaysPerMonth: leaveType.AccrualDaysPerMonth,
+		Unlimited:           leaveType.Unlimited,
+		CreatedAt:           leaveType.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if leaveType.MaxBalance != nil {
+		protoLeaveType.MaxBalance = *leaveType.MaxBalance
+	}
+	return protoLeaveType
+}
+
+func toProtoLeaveBalance(balance models.LeaveBalance) *proto.LeaveBalance {
+	return &proto.LeaveBalance{
+		LeaveType: toProtoLeaveType(balance.LeaveType),
+		Balance:   balance.Balance,
+		Pending:   balance.Pending,
+		Available: balance.Available(),
+	}
+}
+
+func toProtoLeaveRequest(request models.LeaveRequest) *proto.LeaveRequest {
+	protoRequest := &proto.LeaveRequest{
+		Id:              request.Id,
+		EmployeeId:      request.EmployeeId,
+		LeaveTypeId:     request.LeaveTypeId,
+		LeaveTypeName:   request.LeaveTypeName,
+		StartDate:       formatDate(&request.StartDate),
+		EndDate:         formatDate(&request.EndDate),
+		Days:            request.Days,
+		Reason:          request.Reason,
+		Status:          request.Status,
+		DecidedBy:       request.DecidedBy,
+		DecisionComment: request.DecisionComment,
+		CreatedAt:       request.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if request.DecidedAt != nil {
+		protoRequest.DecidedAt = request.DecidedAt.UTC().Format(time.RFC3339)
+	}
+	return protoRequest
+}
+
+func leaveStatusError(err error) error {
+	switch {
+	case errors.Is(err, repositories.ErrEmployeeNotFound), errors.Is(err, repositories.ErrLeaveTypeNotFound),
+		errors.Is(err, repositories.ErrLeaveRequestNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repositories.ErrLeaveTypeExists), errors.Is(err, repositories.ErrLeaveOverlap):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, repositories.ErrNoWorkingDays):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, repositories.ErrInsufficientBalance), errors.Is(err, repositories.ErrUnlimitedLeaveType),
+		errors.Is(err, repositories.ErrLeaveNotPending), errors.Is(err, repositories.ErrLeaveNotCancellable),
+		errors.Is(err, repositories.ErrEmployeeNotCurrent):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, repositories.ErrLeaveForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return err
+	}
+}
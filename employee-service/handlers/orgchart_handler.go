@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"employee-service/models"
+	"employee-service/orgchart"
+	"employee-service/proto"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+)
+
+func (h *EmployeeHandler) ExportOrgChart(ctx context.Context, req *proto.ExportOrgChartRequest) (*proto.ExportOrgChartResponse, error) {
+	format := req.Format
+	if format == "" {
+		format = orgchart.FormatJSON
+	}
+	contentType, ok := orgchart.ContentTypes[format]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown format %q, expected %q, %q or %q",
+			format, orgchart.FormatDOT, orgchart.FormatMermaid, orgchart.FormatJSON)
+	}
+	if req.MaxDepth < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max_depth must not be negative")
+	}
+	if req.RootEmployeeId != 0 && req.RootDepartment != nil {
+		return nil, status.Error(codes.InvalidArgument, "root_employee_id and root_department are mutually exclusive")
+	}
+
+	chart, err := h.orgChart(ctx, req)
+	if err != nil {
+		slog.ErrorContext(ctx, "export org chart failed", "company_id", req.CompanyId, "error", err)
+		return nil, err
+	}
+
+	content, err := orgchart.Render(chart, format)
+	if err != nil {
+		return nil, fmt.Errorf("employee_handler: export org chart: %w", err)
+	}
+	return &proto.ExportOrgChartResponse{ContentType: contentType, Content: content}, nil
+}
+
+// orgChart builds the chart below the requested root: an employee, a department
+// or the whole company.
+func (h *EmployeeHandler) orgChart(ctx context.Context, req *proto.ExportOrgChartRequest) (orgchart.Chart, error) {
+	if req.RootEmployeeId != 0 {
+		nodes, err := h.repo.OrgSubtree(ctx, req.RootEmployeeId, req.MaxDepth)
+		if err != nil {
+			return orgchart.Chart{}, statusError(fmt.Errorf("employee_handler: repo org subtree: %w", err))
+		}
+		root := nodes[0].Employee
+		if root.CompanyId != req.CompanyId {
+			return orgchart.Chart{}, status.Errorf(codes.NotFound, "employee %d does not work for company %d",
+				root.Id, req.CompanyId)
+		}
+
+		employees := make([]models.Employee, len(nodes))
+		for i, node := range nodes {
+			employees[i] = node.Employee
+		}
+		return orgchart.ForEmployee(root, employees, 0), nil
+	}
+
+	var department models.Department
+	if req.RootDepartment != nil {
+		department = models.Department{Name: req.RootDepartment.Name, Phone: req.RootDepartment.Phone}
+	}
+	employees, err := h.repo.ShowCompanyEmployees(ctx, req.CompanyId, department, models.CurrentStatuses, nil)
+	if err != nil {
+		return orgchart.Chart{}, fmt.Errorf("employee_handler: repo show comp employees: %w", err)
+	}
+
+	if req.RootDepartment == nil {
+		return orgchart.ForCompany(req.CompanyId, employees, int(req.MaxDepth)), nil
+	}
+
+	if len(employees) == 0 {
+		return orgchart.Chart{}, status.Errorf(codes.NotFound, "department %q not found in company %d",
+			department.Name, req.CompanyId)
+	}
+	// A name without a phone may match several departments.
+	for _, employee := range employees {
+		if employee.Department != employees[0].Department {
+			return orgchart.Chart{}, status.Errorf(codes.InvalidArgument,
+				"several departments are named %q, set the department phone", department.Name)
+		}
+	}
+	return orgchart.ForDepartment(employees[0].Department, employees, int(req.MaxDepth)), nil
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"context"
+	"employee-service/models"
+	"employee-service/proto"
+	"employee-service/repositories"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"time"
+)
+
+type PositionHandlerInterface interface {
+	CreatePosition(ctx context.Context, req *proto.CreatePositionRequest) (*proto.PositionResponse, error)
+	ListPositions(ctx context.Context, req *proto.ListPositionsRequest) (*proto.ListPositionsResponse, error)
+	UpdatePosition(ctx context.Context, req *proto.UpdatePositionRequest) (*proto.PositionResponse, error)
+	DeletePosition(ctx context.Context, req *proto.DeletePositionRequest) (*proto.DeletePositionResponse, error)
+	GetVacancyReport(ctx context.Context, req *proto.VacancyReportRequest) (*proto.VacancyReportResponse, error)
+}
+
+type PositionHandler struct {
+	repo *repositories.PositionRepository
+	proto.UnimplementedPositionServiceServer
+}
+
+func NewPositionHandler(repo *repositories.PositionRepository) *PositionHandler {
+	return &PositionHandler{repo: repo}
+}
+
+func (h *PositionHandler) CreatePosition(ctx context.Context, req *proto.CreatePositionRequest) (*proto.PositionResponse, error) {
+	if req.CompanyId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "company_id is required")
+	}
+	if req.Title == "" {
+		return nil, status.Error(codes.InvalidArgument, "title is required")
+	}
+	if req.Department == nil || req.Department.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "department name is required")
+	}
+	if req.BudgetedHeadcount < 0 {
+		return nil, status.Error(codes.InvalidArgument, "budgeted_headcount must not be negative")
+	}
+
+	position, err := h.repo.CreatePosition(ctx, models.Position{
+		CompanyId:         req.CompanyId,
+		Title:             req.Title,
+		Grade:             req.Grade,
+		Department:        models.Department{Name: req.Department.Name, Phone: req.Department.Phone},
+		BudgetedHeadcount: req.BudgetedHeadcount,
+	})
+	if err != nil {
+		err = fmt.Errorf("position_handler: repo create position: %w", err)
+		slog.ErrorContext(ctx, "create position failed", "company_id", req.CompanyId, "error", err)
+		return nil, positionStatusError(err)
+	}
+
+	return &proto.PositionResponse{Position: toProtoPosition(position)}, nil
+}
+
+func (h *PositionHandler) ListPositions(ctx context.Context, req *proto.ListPositionsRequest) (*proto.ListPositionsResponse, error) {
+	positions, err := h.repo.ListPositions(ctx, req.CompanyId)
+	if err != nil {
+		err = fmt.Errorf("position_handler: repo list positions: %w", err)
+		slog.ErrorContext(ctx, "list positions failed", "company_id", req.CompanyId, "error", err)
+		return nil, err
+	}
+
+	resp := &proto.ListPositionsResponse{}
+	for _, position := range positions {
+		resp.Positions = append(resp.Positions, toProtoPosition(position))
+	}
+	return resp, nil
+}
+
+func (h *PositionHandler) UpdatePosition(ctx context.Context, req *proto.UpdatePositionRequest) (*proto.PositionResponse, error) {
+	if req.BudgetedHeadcount != nil && *req.BudgetedHeadcount < 0 {
+		return nil, status.Error(codes.InvalidArgument, "budgeted_headcount must not be negative")
+	}
+
+	update := models.PositionUpdate{
+		Title:             req.Title,
+		Grade:             req.Grade,
+		BudgetedHeadcount: req.BudgetedHeadcount,
+	}
+	if req.Department != nil {
+		if req.Department.Name == "" {
+			return nil, status.Error(codes.InvalidArgument, "department name is required")
+		}
+		update.Department = models.Department{Name: req.Department.Name, Phone: req.Department.Phone}
+	}
+
+	position, err := h.repo.UpdatePosition(ctx, req.CompanyId, req.Id, update)
+	if err != nil {
+		err = fmt.Errorf("position_handler: repo update position: %w", err)
+		slog.ErrorContext(ctx, "update position failed", "id", req.Id, "error", err)
+		return nil, positionStatusError(err)
+	}
+
+	return &proto.PositionResponse{Position: toProtoPosition(position)}, nil
+}
+
+func (h *PositionHandler) DeletePosition(ctx context.Context, req *proto.DeletePositionRequest) (*proto.DeletePositionResponse, error) {
+	if err := h.repo.DeletePosition(ctx, req.CompanyId, req.Id); err != nil {
+		err = fmt.Errorf("position_handler: repo delete position: %w", err)
+		slog.ErrorContext(ctx, "delete position failed", "id", req.Id, "error", err)
+		return &proto.DeletePositionResponse{Success: "Fail"}, positionStatusError(err)
+	}
+
+	return &proto.DeletePositionResponse{Success: "Success"}, nil
+}
+
+func (h *PositionHandler) GetVacancyReport(ctx context.Context, req *proto.VacancyReportRequest) (*proto.VacancyReportResponse, error) {
+	report, err := h.repo.VacancyReport(ctx, req.CompanyId)
+	if err != nil {
+		err = fmt.Errorf("position_handler: repo vacancy report: %w", err)
+		slog.ErrorContext(ctx, "vacancy report failed", "company_id", req.CompanyId, "error", err)
+		return nil, err
+	}
+
+	resp := &proto.VacancyReportResponse{Total: &proto.DepartmentHeadcount{}}
+	for _, line := range report {
+		resp.Departments = append(resp.Departments, &proto.DepartmentHeadcount{
+			Department: &proto.Employee_Department{Name: line.Department.Name, Phone: line.Department.Phone},
+			Positions:  line.Positions,
+			Budgeted:   line.Budgeted,
+			Filled:     line.Filled,
+			Open:       line.Open,
+			Overfilled: line.Overfilled,
+		})
+		resp.Total.Positions += line.Positions
+		resp.Total.Budgeted += line.Budgeted
+		resp.Total.Filled += line.Filled
+		resp.Total.Open += line.Open
+		resp.Total.Overfilled += line.Overfilled
+	}
+	return resp, nil
+}
+
+func toProtoPosition(position models.Position) *proto.Position {
+	return &proto.Position{
+		Id:                position.Id,
+		CompanyId:         position.CompanyId,
+		Title:             position.Title,
+		Grade:             position.Grade,
+		Department:        &proto.Employee_Department{Name: position.Department.Name, Phone: position.Department.Phone},
+		BudgetedHeadcount: position.BudgetedHeadcount,
+		FilledHeadcount:   position.FilledHeadcount,
+		CreatedAt:         position.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func positionStatusError(err error) error {
+	switch {
+	case errors.Is(err, repositories.ErrPositionNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repositories.ErrPositionExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, repositories.ErrPositionFilled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"employee-service/auth"
+	"employee-service/models"
+	"employee-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+var employeeFields = map[string]bool{
+	"id":                true,
+	"name":              true,
+	"surname":           true,
+	"phone":             true,
+	"company_id":        true,
+	"passport":          true,
+	"department":        true,
+	"manager_id":        true,
+	"position_id":       true,
+	"status":            true,
+	"hire_date":         true,
+	"termination":       true,
+	"contacts":          true,
+	"addresses":         true,
+	"custom_attributes": true,
+}
+
+func validateFields(fields []string) error {
+	for _, field := range fields {
+		if !employeeFields[field] {
+			return status.Errorf(codes.InvalidArgument, "unknown employee field %q", field)
+		}
+	}
+	return nil
+}
+
+// shapeEmployee hides personal data the caller is not allowed to see and, when
+// fields is not empty, clears every attribute that was not requested.
+func shapeEmployee(employee *proto.Employee, caller auth.Caller, fields []string) {
+	if !caller.HasPermission(auth.PermissionPIIRead) {
+		employee.Phone = ""
+		employee.TerminationReason = ""
+		if employee.Passport != nil {
+			employee.Passport.Number = maskPassportNumber(employee.Passport.Number)
+		}
+		employee.Contacts = workContacts(employee.Contacts)
+		employee.Addresses = workAddresses(employee.Addresses)
+	}
+
+	if len(fields) == 0 {
+		return
+	}
+
+	selected := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		selected[field] = true
+	}
+	if !selected["id"] {
+		employee.Id = 0
+	}
+	if !selected["name"] {
+		employee.Name = ""
+	}
+	if !selected["surname"] {
+		employee.Surname = ""
+	}
+	if !selected["phone"] {
+		employee.Phone = ""
+	}
+	if !selected["company_id"] {
+		employee.CompanyId = 0
+	}
+	if !selected["passport"] {
+		employee.Passport = nil
+	}
+	if !selected["department"] {
+		employee.Department = nil
+	}
+	if !selected["manager_id"] {
+		employee.ManagerId = 0
+	}
+	if !selected["position_id"] {
+		employee.PositionId = 0
+	}
+	if !selected["status"] {
+		employee.Status = ""
+	}
+	if !selected["hire_date"] {
+		employee.HireDate = ""
+	}
+	if !selected["termination"] {
+		employee.TerminationDate = ""
+		employee.TerminationReason = ""
+	}
+	if !selected["contacts"] {
+		employee.Contacts = nil
+	}
+	if !selected["addresses"] {
+		employee.Addresses = nil
+	}
+	if !selected["custom_attributes"] {
+		employee.CustomAttributes = nil
+	}
+}
+
+// workContacts drops mobile numbers and personal emails.
+func workContacts(contacts []*proto.Contact) []*proto.Contact {
+	var work []*proto.Contact
+	for _, contact := range contacts {
+		if !models.IsPersonalContact(contact.Kind) {
+			work = append(work, contact)
+		}
+	}
+	return work
+}
+
+// workAddresses drops home and mailing addresses.
+func workAddresses(addresses []*proto.Address) []*proto.Address {
+	var work []*proto.Address
+	for _, address := range addresses {
+		if !models.IsPersonalAddress(address.Kind) {
+			work = append(work, address)
+		}
+	}
+	return work
+}
+
+// maskPassportNumber keeps only the last four characters, e.g. "****5678".
+func maskPassportNumber(number string) string {
+	if number == "" {
+		return ""
+	}
+	if len(number) <= 4 {
+		return strings.Repeat("*", len(number))
+	}
+	return "****" + number[len(number)-4:]
+}
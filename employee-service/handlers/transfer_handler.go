@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"context"
+	"employee-service/models"
+	"employee-service/proto"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"time"
+)
+
+func (h *EmployeeHandler) TransferEmployee(ctx context.Context, req *proto.TransferEmployeeRequest) (*proto.TransferResponse, error) {
+	if req.CompanyId < 0 {
+		return nil, status.Error(codes.InvalidArgument, "company_id must not be negative")
+	}
+	if req.CompanyId == 0 && (req.Department == nil || (req.Department.Name == "" && req.Department.Phone == "")) {
+		return nil, status.Error(codes.InvalidArgument, "company_id or department is required")
+	}
+
+	effectiveDate, err := parseDate("effective_date", req.EffectiveDate)
+	if err != nil {
+		return nil, err
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if effectiveDate == nil {
+		effectiveDate = &today
+	}
+	if effectiveDate.Before(today) {
+		return nil, status.Errorf(codes.InvalidArgument, "effective_date must not be in the past, got %s",
+			req.EffectiveDate)
+	}
+
+	transfer := models.Transfer{
+		EmployeeId:    req.Id,
+		CompanyId:     req.CompanyId,
+		PositionId:    req.PositionId,
+		ManagerId:     req.ManagerId,
+		EffectiveDate: *effectiveDate,
+		Reason:        req.Reason,
+	}
+	if req.Department != nil {
+		transfer.Department = models.Department{Name: req.Department.Name, Phone: req.Department.Phone}
+	}
+
+	transfer, err = h.repo.TransferEmployee(ctx, transfer)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: repo transfer employee: %w", err)
+		slog.ErrorContext(ctx, "transfer employee failed", "id", req.Id, "error", err)
+		return nil, statusError(err)
+	}
+	return &proto.TransferResponse{Transfer: toProtoTransfer(transfer)}, nil
+}
+
+func (h *EmployeeHandler) CancelTransfer(ctx context.Context, req *proto.CancelTransferRequest) (*proto.TransferResponse, error) {
+	transfer, err := h.repo.CancelTransfer(ctx, req.Id, req.TransferId)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: repo cancel transfer: %w", err)
+		slog.ErrorContext(ctx, "cancel transfer failed", "id", req.Id, "transfer_id", req.TransferId, "error", err)
+		return nil, statusError(err)
+	}
+	return &proto.TransferResponse{Transfer: toProtoTransfer(transfer)}, nil
+}
+
+func (h *EmployeeHandler) ListTransfers(ctx context.Context, req *proto.ListTransfersRequest) (*proto.ListTransfersResponse, error) {
+	transfers, err := h.repo.ListTransfers(ctx, req.Id)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: repo list transfers: %w", err)
+		slog.ErrorContext(ctx, "list transfers failed", "id", req.Id, "error", err)
+		return nil, statusError(err)
+	}
+
+	resp := &proto.ListTransfersResponse{}
+	for _, transfer := range transfers {
+		resp.Transfers = append(resp.Transfers, toProtoTransfer(transfer))
+	}
+	return resp, nil
+}
+
+func (h *EmployeeHandler) GetAssignmentHistory(ctx context.Context, req *proto.GetAssignmentHistoryRequest) (*proto.AssignmentHistoryResponse, error) {
+	assignments, err := h.repo.AssignmentHistory(ctx, req.Id)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: repo assignment history: %w", err)
+		slog.ErrorContext(ctx, "assignment history failed", "id", req.Id, "error", err)
+		return nil, statusError(err)
+	}
+
+	resp := &proto.AssignmentHistoryResponse{}
+	for _, assignment := range assignments {
+		resp.Assignments = append(resp.Assignments, toProtoAssignment(assignment))
+	}
+	return resp, nil
+}
+
+func toProtoAssignment(assignment models.Assignment) *proto.Assignment {
+	protoAssignment := &proto.Assignment{
+		CompanyId: assignment.CompanyId,
+		Department: &proto.Employee_Department{
+			Name:  assignment.Department.Name,
+			Phone: assignment.Department.Phone,
+		},
+		StartedOn: formatDate(&assignment.StartedOn),
+		EndedOn:   formatDate(assignment.EndedOn),
+	}
+	if assignment.PositionId != nil {
+		protoAssignment.PositionId = *assignment.PositionId
+	}
+	if assignment.TransferId != nil {
+		protoAssignment.TransferId = *assignment.TransferId
+	}
+	return protoAssignment
+}
+
+func toProtoTransfer(transfer models.Transfer) *proto.Transfer {
+	protoTransfer := &proto.Transfer{
+		Id:            transfer.Id,
+		EmployeeId:    transfer.EmployeeId,
+		CompanyId:     transfer.CompanyId,
+		PositionId:    transfer.PositionId,
+		ManagerId:     transfer.ManagerId,
+		EffectiveDate: formatDate(&transfer.EffectiveDate),
+		Reason:        transfer.Reason,
+		Status:        transfer.Status,
+		Failure:       transfer.Failure,
+		CreatedAt:     transfer.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if transfer.Department != (models.Department{}) {
+		protoTransfer.Department = &proto.Employee_Department{
+			Name:  transfer.Department.Name,
+			Phone: transfer.Department.Phone,
+		}
+	}
+	if transfer.AppliedAt != nil {
+		protoTransfer.AppliedAt = transfer.AppliedAt.UTC().Format(time.RFC3339)
+	}
+	return protoTransfer
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"context"
+	"employee-service/auth"
+	"employee-service/models"
+	"employee-service/proto"
+	"encoding/json"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	watchBatchSize = 100
+	// watchPollInterval bounds how long a change can go unnoticed when its
+	// notification arrived while an older transaction was still running.
+	watchPollInterval = 2 * time.Second
+)
+
+// WatchEmployees streams the changes of a company's employees. Every change
+// carries a resume token; passing the last one received continues the stream
+// after that change, an empty token streams only changes made from now on.
+func (h *EmployeeHandler) WatchEmployees(req *proto.WatchEmployeesRequest, stream proto.EmployeeService_WatchEmployeesServer) error {
+	ctx := stream.Context()
+	if req.CompanyId <= 0 {
+		return status.Error(codes.InvalidArgument, "company_id is required")
+	}
+
+	wakeups, unsubscribe := h.hub.Subscribe(req.CompanyId)
+	defer unsubscribe()
+
+	position, err := h.startPosition(ctx, req.ResumeToken)
+	if err != nil {
+		return err
+	}
+	// Tells the gateway the request was accepted before the first change.
+	if err = stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	caller := auth.CallerFromContext(ctx)
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	slog.InfoContext(ctx, "watch started", "company_id", req.CompanyId, "caller_id", caller.Id)
+	for {
+		events, err := h.outbox.ChangesSince(ctx, req.CompanyId, position, watchBatchSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			err = fmt.Errorf("employee_handler: watch employees: %w", err)
+			slog.ErrorContext(ctx, "watch employees failed", "company_id", req.CompanyId, "error", err)
+			return err
+		}
+
+		for _, event := range events {
+			change, err := toProtoChange(event, caller)
+			if err != nil {
+				return fmt.Errorf("employee_handler: watch employees: %w", err)
+			}
+			if err = stream.Send(change); err != nil {
+				return err
+			}
+			position = event.Position
+		}
+		if len(events) == watchBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-h.hub.Done():
+			return status.Error(codes.Unavailable, "server is shutting down, resume with the last resume_token")
+		case <-wakeups:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *EmployeeHandler) startPosition(ctx context.Context, token string) (models.ChangePosition, error) {
+	if token != "" {
+		return decodeResumeToken(token)
+	}
+
+	position, err := h.outbox.CurrentPosition(ctx)
+	if err != nil {
+		err = fmt.Errorf("employee_handler: watch employees: %w", err)
+		slog.ErrorContext(ctx, "watch employees failed", "error", err)
+		return models.ChangePosition{}, err
+	}
+	return position, nil
+}
+
+func encodeResumeToken(position models.ChangePosition) string {
+	return strconv.FormatUint(position.TxId, 10) + "-" + strconv.FormatInt(position.Id, 10)
+}
+
+func decodeResumeToken(token string) (models.ChangePosition, error) {
+	txId, id, ok := strings.Cut(token, "-")
+	if !ok {
+		return models.ChangePosition{}, status.Errorf(codes.InvalidArgument, "invalid resume_token %q", token)
+	}
+
+	var position models.ChangePosition
+	var err error
+	if position.TxId, err = strconv.ParseUint(txId, 10, 64); err != nil {
+		return models.ChangePosition{}, status.Errorf(codes.InvalidArgument, "invalid resume_token %q", token)
+	}
+	if position.Id, err = strconv.ParseInt(id, 10, 64); err != nil {
+		return models.ChangePosition{}, status.Errorf(codes.InvalidArgument, "invalid resume_token %q", token)
+	}
+	return position, nil
+}
+
+// toProtoChange converts an outbox event, hiding personal data the caller is
+// not allowed to see just like ShowCompanyEmployees does.
+func toProtoChange(event models.Event, caller auth.Caller) (*proto.EmployeeChange, error) {
+	var data models.EmployeeEventData
+	if err := json.Unmarshal(event.Payload, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal event %d: %w", event.Id, err)
+	}
+
+	change := &proto.EmployeeChange{
+		ResumeToken:   encodeResumeToken(event.Position),
+		Type:          event.Type,
+		EmployeeId:    event.EmployeeId,
+		ChangedFields: data.ChangedFields,
+		OccurredAt:    event.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if data.Employee != nil {
+		change.Employee = &proto.Employee{
+			Id:        data.EmployeeId,
+			Name:      data.Employee.Name,
+			Surname:   data.Employee.Surname,
+			CompanyId: data.CompanyId,
+			Status:    data.Employee.Status,
+			Passport:  &proto.Employee_Passport{Type: data.Employee.PassportType},
+			Department: &proto.Employee_Department{
+				Name:  data.Employee.DepartmentName,
+				Phone: data.Employee.DepartmentPhone,
+			},
+		}
+		if data.Employee.ManagerId != nil {
+			change.Employee.ManagerId = *data.Employee.ManagerId
+		}
+		if data.Employee.PositionId != nil {
+			change.Employee.PositionId = *data.Employee.PositionId
+		}
+		shapeEmployee(change.Employee, caller, nil)
+	}
+	return change, nil
+}
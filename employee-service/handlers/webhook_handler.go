@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"context"
+	"employee-service/auth"
+	"employee-service/models"
+	"employee-service/proto"
+	"employee-service/repositories"
+	"employee-service/webhooks"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultDeliveriesLimit = 50
+	maxDeliveriesLimit     = 500
+)
+
+var webhookEventTypes = []string{models.EventEmployeeCreated, models.EventEmployeeUpdated, models.EventEmployeeDeleted}
+
+type WebhookHandlerInterface interface {
+	CreateWebhook(ctx context.Context, req *proto.CreateWebhookRequest) (*proto.CreateWebhookResponse, error)
+	ListWebhooks(ctx context.Context, req *proto.ListWebhooksRequest) (*proto.ListWebhooksResponse, error)
+	DeleteWebhook(ctx context.Context, req *proto.DeleteWebhookRequest) (*proto.DeleteWebhookResponse, error)
+	ListWebhookDeliveries(ctx context.Context, req *proto.ListWebhookDeliveriesRequest) (*proto.ListWebhookDeliveriesResponse, error)
+}
+
+type WebhookHandler struct {
+	repo *repositories.WebhookRepository
+	proto.UnimplementedWebhookServiceServer
+}
+
+func NewWebhookHandler(repo *repositories.WebhookRepository) *WebhookHandler {
+	return &WebhookHandler{repo: repo}
+}
+
+func (h *WebhookHandler) CreateWebhook(ctx context.Context, req *proto.CreateWebhookRequest) (*proto.CreateWebhookResponse, error) {
+	if err := auth.RequirePermission(ctx, auth.PermissionWebhooksManage); err != nil {
+		return nil, err
+	}
+	if req.CompanyId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "company_id is required")
+	}
+	if err := validateWebhookURL(ctx, req.Url); err != nil {
+		return nil, err
+	}
+
+	eventTypes := req.EventTypes
+	if len(eventTypes) == 0 {
+		eventTypes = webhookEventTypes
+	}
+	for _, eventType := range eventTypes {
+		if !knownEventType(eventType) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown event type %q, expected one of %v",
+				eventType, webhookEventTypes)
+		}
+	}
+
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		return nil, fmt.Errorf("webhook_handler: create webhook: %w", err)
+	}
+
+	webhook, err := h.repo.CreateWebhook(ctx, models.Webhook{
+		CompanyId:  req.CompanyId,
+		URL:        req.Url,
+		EventTypes: eventTypes,
+		Secret:     secret,
+		CreatedBy:  auth.CallerFromContext(ctx).Id,
+	})
+	if err != nil {
+		err = fmt.Errorf("webhook_handler: repo create webhook: %w", err)
+		slog.ErrorContext(ctx, "create webhook failed", "company_id", req.CompanyId, "error", err)
+		return nil, err
+	}
+
+	return &proto.CreateWebhookResponse{Webhook: toProtoWebhook(webhook), Secret: webhook.Secret}, nil
+}
+
+func (h *WebhookHandler) ListWebhooks(ctx context.Context, req *proto.ListWebhooksRequest) (*proto.ListWebhooksResponse, error) {
+	if err := auth.RequirePermission(ctx, auth.PermissionWebhooksManage); err != nil {
+		return nil, err
+	}
+
+	list, err := h.repo.ListWebhooks(ctx, req.CompanyId)
+	if err != nil {
+		err = fmt.Errorf("webhook_handler: repo list webhooks: %w", err)
+		slog.ErrorContext(ctx, "list webhooks failed", "company_id", req.CompanyId, "error", err)
+		return nil, err
+	}
+
+	resp := &proto.ListWebhooksResponse{}
+	for _, webhook := range list {
+		resp.Webhooks = append(resp.Webhooks, toProtoWebhook(webhook))
+	}
+	return resp, nil
+}
+
+func (h *WebhookHandler) DeleteWebhook(ctx context.Context, req *proto.DeleteWebhookRequest) (*proto.DeleteWebhookResponse, error) {
+	if err := auth.RequirePermission(ctx, auth.PermissionWebhooksManage); err != nil {
+		return &proto.DeleteWebhookResponse{Success: "Fail"}, err
+	}
+
+	if err := h.repo.DeleteWebhook(ctx, req.CompanyId, req.Id); err != nil {
+		err = fmt.Errorf("webhook_handler: repo delete webhook: %w", err)
+		slog.ErrorContext(ctx, "delete webhook failed", "id", req.Id, "error", err)
+		return &proto.DeleteWebhookResponse{Success: "Fail"}, webhookStatusError(err)
+	}
+
+	return &proto.DeleteWebhookResponse{Success: "Success"}, nil
+}
+
+func (h *WebhookHandler) ListWebhookDeliveries(ctx context.Context, req *proto.ListWebhookDeliveriesRequest) (*proto.ListWebhookDeliveriesResponse, error) {
+	if err := auth.RequirePermission(ctx, auth.PermissionWebhooksManage); err != nil {
+		return nil, err
+	}
+
+	switch req.Status {
+	case "", models.DeliveryPending, models.DeliveryDelivered, models.DeliveryDead:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown delivery status %q", req.Status)
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultDeliveriesLimit
+	}
+	limit = min(limit, maxDeliveriesLimit)
+
+	deliveries, err := h.repo.ListDeliveries(ctx, req.CompanyId, req.WebhookId, req.Status, limit)
+	if err != nil {
+		err = fmt.Errorf("webhook_handler: repo list webhook deliveries: %w", err)
+		slog.ErrorContext(ctx, "list webhook deliveries failed", "webhook_id", req.WebhookId, "error", err)
+		return nil, webhookStatusError(err)
+	}
+
+	resp := &proto.ListWebhookDeliveriesResponse{}
+	for _, delivery := range deliveries {
+		protoDelivery := &proto.WebhookDelivery{
+			Id:            delivery.Id,
+			WebhookId:     delivery.WebhookId,
+			EventId:       delivery.EventId,
+			EventType:     delivery.EventType,
+			Status:        delivery.Status,
+			Attempts:      delivery.Attempts,
+			NextAttemptAt: delivery.NextAttemptAt.UTC().Format(time.RFC3339),
+			LastError:     delivery.LastError,
+			CreatedAt:     delivery.CreatedAt.UTC().Format(time.RFC3339),
+		}
+		if delivery.LastStatusCode != nil {
+			protoDelivery.LastStatusCode = *delivery.LastStatusCode
+		}
+		if delivery.DeliveredAt != nil {
+			protoDelivery.DeliveredAt = delivery.DeliveredAt.UTC().Format(time.RFC3339)
+		}
+		resp.Deliveries = append(resp.Deliveries, protoDelivery)
+	}
+	return resp, nil
+}
+
+func validateWebhookURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Hostname() == "" {
+		return status.Errorf(codes.InvalidArgument, "url must be an absolute http or https URL, got %q", rawURL)
+	}
+	if err = webhooks.CheckTarget(ctx, u.Hostname()); err != nil {
+		return status.Errorf(codes.InvalidArgument, "url must point to a public host: %v", err)
+	}
+	return nil
+}
+
+func knownEventType(eventType string) bool {
+	for _, known := range webhookEventTypes {
+		if eventType == known {
+			return true
+		}
+	}
+	return false
+}
+
+func toProtoWebhook(webhook models.Webhook) *proto.Webhook {
+	return &proto.Webhook{
+		Id:         webhook.Id,
+		CompanyId:  webhook.CompanyId,
+		Url:        webhook.URL,
+		EventTypes: webhook.EventTypes,
+		CreatedBy:  webhook.CreatedBy,
+		CreatedAt:  webhook.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func webhookStatusError(err error) error {
+	if errors.Is(err, repositories.ErrWebhookNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
+}
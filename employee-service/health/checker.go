@@ -0,0 +1,61 @@
+package health
+
+import (
+	"context"
+	"employee-service/proto"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"log/slog"
+	"time"
+)
+
+const pingTimeout = 2 * time.Second
+
+// Checker reports the service as NOT_SERVING through the standard gRPC health
+// service whenever the database cannot be pinged.
+type Checker struct {
+	pool     *pgxpool.Pool
+	server   *health.Server
+	interval time.Duration
+}
+
+func NewChecker(pool *pgxpool.Pool, server *health.Server, interval time.Duration) *Checker {
+	return &Checker{pool: pool, server: server, interval: interval}
+}
+
+// Run checks the database every interval until ctx is done.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	serving := false
+	for {
+		pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+		err := c.pool.Ping(pingCtx)
+		cancel()
+
+		if err != nil && serving {
+			slog.Warn("Database unavailable, reporting NOT_SERVING", "error", err)
+		} else if err == nil && !serving {
+			slog.Info("Database available, reporting SERVING")
+		}
+		serving = err == nil
+		c.setStatus(serving)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Checker) setStatus(serving bool) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+	c.server.SetServingStatus("", status)
+	c.server.SetServingStatus(proto.EmployeeService_ServiceDesc.ServiceName, status)
+}
@@ -0,0 +1,30 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+)
+
+// ContextErrors reports calls that failed because their deadline expired or the
+// client went away with the matching gRPC codes instead of Unknown, and logs
+// them separately from other failures.
+func ContextErrors(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+
+	switch ctxErr := ctx.Err(); {
+	case errors.Is(ctxErr, context.DeadlineExceeded):
+		slog.WarnContext(ctx, "deadline exceeded", "method", info.FullMethod, "error", err)
+		return resp, status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(ctxErr, context.Canceled):
+		slog.InfoContext(ctx, "cancelled by client", "method", info.FullMethod, "error", err)
+		return resp, status.Error(codes.Canceled, err.Error())
+	}
+	return resp, err
+}
@@ -0,0 +1,50 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/subtle"
+	"employee-service/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+// healthMethodPrefix is exempt from the gateway token, so that orchestrators
+// can probe the service.
+const healthMethodPrefix = "/grpc.health.v1.Health/"
+
+// GatewayToken rejects calls without the token shared with the API gateway.
+// The caller metadata of the gateway is trusted, so no other client may call
+// the service.
+func GatewayToken(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := checkGatewayToken(ctx, token, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamGatewayToken is GatewayToken for streaming calls.
+func StreamGatewayToken(token string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkGatewayToken(ss.Context(), token, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func checkGatewayToken(ctx context.Context, token, method string) error {
+	if strings.HasPrefix(method, healthMethodPrefix) {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(auth.GatewayTokenKey)
+	if len(values) != 1 || subtle.ConstantTimeCompare([]byte(values[0]), []byte(token)) != 1 {
+		return status.Error(codes.Unauthenticated, "calls must come through the API gateway")
+	}
+	return nil
+}
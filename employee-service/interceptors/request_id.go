@@ -0,0 +1,63 @@
+package interceptors
+
+import (
+	"context"
+	"employee-service/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"time"
+)
+
+// RequestID puts the request id forwarded by the gateway, or a new one for
+// direct calls, into the context so that every log line of the call carries
+// it, and logs the outcome of the call.
+func RequestID(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx = withRequestID(ctx)
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	slog.InfoContext(ctx, "grpc call",
+		"method", info.FullMethod,
+		"code", status.Code(err).String(),
+		"duration_ms", time.Since(start).Milliseconds())
+	return resp, err
+}
+
+// StreamRequestID is RequestID for streaming calls.
+func StreamRequestID(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := withRequestID(ss.Context())
+
+	start := time.Now()
+	err := handler(srv, &requestIDStream{ServerStream: ss, ctx: ctx})
+
+	slog.InfoContext(ctx, "grpc stream",
+		"method", info.FullMethod,
+		"code", status.Code(err).String(),
+		"duration_ms", time.Since(start).Milliseconds())
+	return err
+}
+
+type requestIDStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestIDStream) Context() context.Context {
+	return s.ctx
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(logging.RequestIDKey); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" {
+		requestID = logging.NewRequestID()
+	}
+	return logging.WithRequestID(ctx, requestID)
+}
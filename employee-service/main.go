@@ -2,23 +2,51 @@ package main
 
 import (
 	"context"
+	"employee-service/auth"
 	"employee-service/config"
+	"employee-service/encryption"
+	"employee-service/events"
 	"employee-service/handlers"
+	"employee-service/health"
+	"employee-service/interceptors"
+	"employee-service/logging"
+	"employee-service/metrics"
 	"employee-service/proto"
 	"employee-service/repositories"
+	"employee-service/tracing"
+	"employee-service/transfers"
+	"employee-service/watch"
+	"employee-service/webhooks"
 	"fmt"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
-	"log"
+	"log/slog"
 	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 )
 
 func NewDBPool(connString string) (*pgxpool.Pool, error) {
-	pool, err := pgxpool.Connect(context.Background(), connString)
+	poolConfig, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, err
+	}
+	poolConfig.ConnConfig.Logger = tracing.PgxLogger{}
+	poolConfig.ConnConfig.LogLevel = pgx.LogLevelInfo
+
+	pool, err := pgxpool.ConnectConfig(context.Background(), poolConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -33,44 +61,253 @@ func RunMigrations(connStr string) error {
 	if err != nil {
 		return fmt.Errorf("Error run migrations: %w", err)
 	}
+	defer m.Close()
 
 	if err = m.Up(); err != nil && err != migrate.ErrNoChange {
 		return fmt.Errorf("Error up migrations: %w", err)
 	}
-	log.Println("Migrations applied successfully")
+	slog.Info("Migrations applied successfully")
 	return nil
 }
 
+// retry calls fn with exponential backoff until it succeeds or maxWait has
+// passed, so the service can start before the database is ready.
+func retry(what string, maxWait time.Duration, fn func() error) error {
+	deadline := time.Now().Add(maxWait)
+	backoff := 500 * time.Millisecond
+
+	for {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if time.Now().Add(backoff).After(deadline) {
+			return fmt.Errorf("%s: giving up after %s: %w", what, maxWait, err)
+		}
+
+		slog.Warn("Retrying", "operation", what, "backoff", backoff.String(), "error", err)
+		time.Sleep(backoff)
+		backoff = min(backoff*2, 10*time.Second)
+	}
+}
+
+// expireIdempotencyKeys deletes idempotency keys older than ttl every hour
+// until ctx is done.
+func expireIdempotencyKeys(ctx context.Context, repo *repositories.EmployeeRepository, ttl time.Duration) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := repo.DeleteExpiredIdempotencyKeys(ctx, time.Now().Add(-ttl))
+		if err != nil && ctx.Err() == nil {
+			slog.Error("Failed to delete expired idempotency keys", "error", err)
+		} else if deleted > 0 {
+			slog.Info("Deleted expired idempotency keys", "count", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
 func main() {
+	logging.Setup()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		log.Fatalf("Failed to load config: %w", err)
+		fatal("Failed to load config", err)
 	}
 
-	if err = RunMigrations(cfg.PostgresURL()); err != nil {
-		log.Fatalf("Fail run migrations: %w", err)
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		ServiceName:  "employee-service",
+		Exporter:     cfg.TracingExporter,
+		OTLPEndpoint: cfg.TracingOTLPEndpoint,
+		FilePath:     cfg.TracingFilePath,
+	})
+	if err != nil {
+		fatal("Failed to set up tracing", err)
 	}
+	defer shutdownTracing(context.Background())
 
-	pool, err := NewDBPool(cfg.PostgresURL())
+	err = retry("run migrations", cfg.DBConnectTimeout, func() error {
+		return RunMigrations(cfg.PostgresURL())
+	})
 	if err != nil {
-		log.Fatalf("Error connecting to database: %w", err)
+		fatal("Fail run migrations", err)
+	}
+
+	var pool *pgxpool.Pool
+	err = retry("connect to database", cfg.DBConnectTimeout, func() error {
+		pool, err = NewDBPool(cfg.PostgresURL())
+		return err
+	})
+	if err != nil {
+		fatal("Error connecting to database", err)
 	}
 	defer pool.Close()
 
-	employeeRepo := repositories.NewEmployeeRepository(pool)
-	employeeHandler := handlers.NewEmployeeHandler(*employeeRepo)
+	var keyring *encryption.Keyring
+	if cfg.Keyring != "" {
+		keyring, err = encryption.ParseKeyring([]byte(cfg.Keyring))
+	} else {
+		keyring, err = encryption.LoadKeyring(cfg.KeyringPath)
+	}
+	if err != nil {
+		fatal("Failed to load keyring", err)
+	}
+
+	employeeRepo := repositories.NewEmployeeRepository(pool, keyring)
+
+	webhookRepo := repositories.NewWebhookRepository(pool, keyring)
 
-	grpcServer := grpc.NewServer()
+	if len(os.Args) > 1 && os.Args[1] == "encrypt-webhook-secrets" {
+		updated, err := webhookRepo.EncryptSecrets(context.Background())
+		if err != nil {
+			fatal("Failed to encrypt webhook secrets", err)
+		}
+		slog.Info("Encrypted or re-wrapped webhook secrets", "count", updated)
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "encrypt-passports" {
+		updated, err := employeeRepo.EncryptPassports(context.Background())
+		if err != nil {
+			fatal("Failed to encrypt passports", err)
+		}
+		slog.Info("Encrypted or re-wrapped passport numbers", "count", updated)
+		return
+	}
+
+	publisher, err := events.NewPublisher(events.Config{
+		Publisher: cfg.EventsPublisher,
+		FilePath:  cfg.EventsFilePath,
+		NATSURL:   cfg.EventsNATSURL,
+		KafkaURL:  cfg.EventsKafkaURL,
+		Topic:     cfg.EventsTopic,
+	})
+	if err != nil {
+		fatal("Failed to set up event publisher", err)
+	}
+
+	outboxRepo := repositories.NewOutboxRepository(pool)
+
+	// Background workers finish before the deferred pool.Close runs.
+	var workers sync.WaitGroup
+	if publisher != nil {
+		defer publisher.Close()
+		relay := events.NewRelay(outboxRepo, publisher,
+			cfg.EventsRelayInterval, cfg.EventsRelayBatchSize, cfg.EventsRetention)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			relay.Run(ctx)
+		}()
+	}
+
+	// Deliveries of a batch are sent one after another, each within the timeout.
+	webhookLease := time.Duration(cfg.WebhookBatchSize)*cfg.WebhookTimeout + time.Minute
+	dispatcher := webhooks.NewDispatcher(webhookRepo, webhooks.NewSender(webhooks.NewClient(cfg.WebhookTimeout)),
+		cfg.WebhookDispatchInterval, cfg.WebhookBatchSize, cfg.WebhookMaxAttempts, webhookLease)
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		dispatcher.Run(ctx)
+	}()
+
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		expireIdempotencyKeys(ctx, employeeRepo, cfg.IdempotencyKeyTTL)
+	}()
+
+	scheduler := transfers.NewScheduler(employeeRepo, cfg.TransferScheduleInterval, cfg.TransferBatchSize)
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		scheduler.Run(ctx)
+	}()
+
+	hub := watch.NewHub(pool)
+	go hub.Run(ctx)
+
+	employeeHandler := handlers.NewEmployeeHandler(*employeeRepo, outboxRepo, hub)
+
+	gatewayToken, err := auth.LoadGatewayToken(cfg.GatewayToken, cfg.GatewayTokenPath)
+	if err != nil {
+		fatal("Failed to load gateway token", err)
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(interceptors.RequestID, interceptors.GatewayToken(gatewayToken),
+			metrics.UnaryServerInterceptor, interceptors.ContextErrors),
+		grpc.ChainStreamInterceptor(interceptors.StreamRequestID, interceptors.StreamGatewayToken(gatewayToken)))
 	proto.RegisterEmployeeServiceServer(grpcServer, employeeHandler)
+	proto.RegisterWebhookServiceServer(grpcServer, handlers.NewWebhookHandler(webhookRepo))
+	proto.RegisterPositionServiceServer(grpcServer, handlers.NewPositionHandler(repositories.NewPositionRepository(pool)))
+	proto.RegisterLeaveServiceServer(grpcServer, handlers.NewLeaveHandler(repositories.NewLeaveRepository(pool)))
+	proto.RegisterCalendarServiceServer(grpcServer, handlers.NewCalendarHandler(repositories.NewCalendarRepository(pool)))
+	proto.RegisterAttributeServiceServer(grpcServer, handlers.NewAttributeHandler(repositories.NewAttributeRepository(pool)))
+
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	go health.NewChecker(pool, healthServer, cfg.HealthCheckInterval).Run(ctx)
 
 	reflection.Register(grpcServer)
 
+	prometheus.MustRegister(metrics.NewPoolCollector(pool))
+	go metrics.Serve(cfg.MetricsPort)
+
 	listener, err := net.Listen(cfg.ServicesNetworkType, cfg.EmployeePort)
 	if err != nil {
-		log.Fatalf("Failed to listen: %w", err)
+		fatal("Failed to listen", err)
 	}
-	log.Printf("Employee service started on %s", cfg.EmployeePort)
-	if err := grpcServer.Serve(listener); err != nil {
-		log.Fatalf("Failed to serve: %w", err)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- grpcServer.Serve(listener)
+	}()
+	slog.Info("Employee service started", "port", cfg.EmployeePort)
+
+	select {
+	case err := <-serveErr:
+		fatal("Failed to serve", err)
+	case <-ctx.Done():
+	}
+
+	slog.Info("Shutting down employee service", "timeout", cfg.ShutdownTimeout.String())
+	healthServer.Shutdown()
+	gracefulStop(grpcServer, cfg.ShutdownTimeout)
+	workers.Wait()
+	slog.Info("Employee service stopped")
+}
+
+// gracefulStop waits for in-flight RPCs to finish and cancels the remaining
+// ones once timeout has passed. The deferred pool.Close in main runs only after
+// it returns, so no RPC loses its connection mid-transaction.
+func gracefulStop(server *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		slog.Warn("Graceful stop timed out, cancelling in-flight RPCs")
+		server.Stop()
+		<-stopped
 	}
 }
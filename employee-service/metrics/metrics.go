@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+var (
+	grpcHandlingSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "employee",
+		Subsystem: "grpc",
+		Name:      "server_handling_seconds",
+		Help:      "Time spent handling gRPC calls, by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	queryDurationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "employee",
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "Time spent in repository methods, including waiting for a pool connection.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+)
+
+// UnaryServerInterceptor records the duration and status code of every call.
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	grpcHandlingSeconds.WithLabelValues(info.FullMethod, status.Code(err).String()).Observe(time.Since(start).Seconds())
+	return resp, err
+}
+
+// ObserveQuery records the time since start for a repository method. It is
+// meant to be deferred at the top of the method:
+//
+//	defer metrics.ObserveQuery("add_employee", time.Now())
+func ObserveQuery(method string, start time.Time) {
+	queryDurationSeconds.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// Serve exposes the default registry on addr at /metrics until the server
+// fails.
+func Serve(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	slog.Info("Metrics server started", "port", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.Error("Metrics server failed", "error", err)
+	}
+}
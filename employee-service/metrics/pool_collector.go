@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolCollector exports pgxpool statistics, read on every scrape.
+type PoolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	constructingConns    *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+	acquireCount         *prometheus.Desc
+	acquireWaitSeconds   *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+}
+
+func NewPoolCollector(pool *pgxpool.Pool) *PoolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("employee", "pgxpool", name), help, nil, nil)
+	}
+	return &PoolCollector{
+		pool:                 pool,
+		acquiredConns:        desc("acquired_conns", "Connections currently acquired from the pool."),
+		idleConns:            desc("idle_conns", "Idle connections in the pool."),
+		constructingConns:    desc("constructing_conns", "Connections currently being established."),
+		totalConns:           desc("total_conns", "Total connections in the pool."),
+		maxConns:             desc("max_conns", "Maximum size of the pool."),
+		acquireCount:         desc("acquire_count_total", "Successful connection acquisitions."),
+		acquireWaitSeconds:   desc("acquire_duration_seconds_total", "Total time spent waiting to acquire a connection."),
+		emptyAcquireCount:    desc("empty_acquire_count_total", "Acquisitions that had to wait because the pool was empty."),
+		canceledAcquireCount: desc("canceled_acquire_count_total", "Acquisitions cancelled by their context."),
+	}
+}
+
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.constructingConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.acquireWaitSeconds
+	ch <- c.emptyAcquireCount
+	ch <- c.canceledAcquireCount
+}
+
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.constructingConns, prometheus.GaugeValue, float64(stat.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireWaitSeconds, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquireCount, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+}
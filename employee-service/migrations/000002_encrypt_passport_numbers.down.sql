@@ -0,0 +1,19 @@
+-- Passport numbers can only be decrypted with the keyring, so rolling back is
+-- refused once any number exists only as ciphertext.
+DO $$
+BEGIN
+    IF EXISTS(SELECT 1 FROM passports WHERE number IS NULL AND number_ciphertext IS NOT NULL) THEN
+        RAISE EXCEPTION 'passport numbers are encrypted: this migration is irreversible';
+    END IF;
+END
+$$;
+
+DROP INDEX IF EXISTS idx_passports_number_key_id;
+
+DROP INDEX IF EXISTS idx_passports_number_index;
+
+ALTER TABLE passports
+    DROP COLUMN IF EXISTS number_index,
+    DROP COLUMN IF EXISTS number_key_id,
+    DROP COLUMN IF EXISTS number_dek,
+    DROP COLUMN IF EXISTS number_ciphertext;
@@ -0,0 +1,8 @@
+ALTER TABLE passports
+    ADD COLUMN number_ciphertext BYTEA,
+    ADD COLUMN number_dek        BYTEA,
+    ADD COLUMN number_key_id     VARCHAR(64),
+    ADD COLUMN number_index      VARCHAR(64);
+
+CREATE UNIQUE INDEX idx_passports_number_index ON passports (number_index);
+CREATE INDEX idx_passports_number_key_id ON passports (number_key_id);
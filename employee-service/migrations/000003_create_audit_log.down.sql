@@ -0,0 +1,4 @@
+ALTER TABLE employees
+    DROP COLUMN IF EXISTS erased_at;
+
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,14 @@
+CREATE TABLE audit_log
+(
+    id          BIGSERIAL PRIMARY KEY,
+    employee_id INT          NOT NULL,
+    action      VARCHAR(64)  NOT NULL,
+    caller_id   VARCHAR(255) NOT NULL DEFAULT '',
+    details     JSONB        NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_audit_log_employee_id ON audit_log (employee_id, created_at);
+
+ALTER TABLE employees
+    ADD COLUMN erased_at TIMESTAMPTZ;
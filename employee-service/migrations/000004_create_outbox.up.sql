@@ -0,0 +1,12 @@
+CREATE TABLE outbox
+(
+    id           BIGSERIAL PRIMARY KEY,
+    employee_id  INT         NOT NULL,
+    event_type   VARCHAR(64) NOT NULL,
+    payload      JSONB       NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    published_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_outbox_pending ON outbox (id) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_published_at ON outbox (published_at) WHERE published_at IS NOT NULL;
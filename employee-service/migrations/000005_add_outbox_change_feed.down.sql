@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_outbox_company_change;
+
+ALTER TABLE outbox
+    DROP COLUMN IF EXISTS tx_id,
+    DROP COLUMN IF EXISTS company_id;
@@ -0,0 +1,11 @@
+ALTER TABLE outbox
+    ADD COLUMN company_id INT  NOT NULL DEFAULT 0,
+    ADD COLUMN tx_id      XID8 NOT NULL DEFAULT pg_current_xact_id();
+
+UPDATE outbox
+SET company_id = (payload ->> 'company_id')::INT;
+
+ALTER TABLE outbox
+    ALTER COLUMN company_id DROP DEFAULT;
+
+CREATE INDEX idx_outbox_company_change ON outbox (company_id, tx_id, id);
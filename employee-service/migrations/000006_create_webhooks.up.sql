@@ -0,0 +1,32 @@
+CREATE TABLE webhooks
+(
+    id          SERIAL PRIMARY KEY,
+    company_id  INT           NOT NULL,
+    url         VARCHAR(2048) NOT NULL,
+    event_types VARCHAR(64)[] NOT NULL,
+    secret      VARCHAR(64)   NOT NULL,
+    created_by  VARCHAR(255)  NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_webhooks_company_id ON webhooks (company_id);
+
+CREATE TABLE webhook_deliveries
+(
+    id               BIGSERIAL PRIMARY KEY,
+    webhook_id       INT         NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_id         BIGINT      NOT NULL,
+    event_type       VARCHAR(64) NOT NULL,
+    payload          JSONB       NOT NULL,
+    status           VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts         INT         NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_status_code INT,
+    last_error       TEXT        NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    delivered_at     TIMESTAMPTZ,
+    UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries (webhook_id, id);
@@ -0,0 +1,11 @@
+CREATE TABLE idempotency_keys
+(
+    caller_id    VARCHAR(255) NOT NULL,
+    key          VARCHAR(255) NOT NULL,
+    request_hash CHAR(64)     NOT NULL,
+    employee_id  INT,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    PRIMARY KEY (caller_id, key)
+);
+
+CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys (created_at);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_employees_manager_id;
+
+ALTER TABLE employees
+    DROP CONSTRAINT IF EXISTS employees_manager_not_self,
+    DROP COLUMN IF EXISTS manager_id;
@@ -0,0 +1,5 @@
+ALTER TABLE employees
+    ADD COLUMN manager_id INT REFERENCES employees (id) ON DELETE SET NULL,
+    ADD CONSTRAINT employees_manager_not_self CHECK (manager_id <> id);
+
+CREATE INDEX idx_employees_manager_id ON employees (manager_id);
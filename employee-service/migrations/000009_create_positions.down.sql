@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_employees_position_id;
+
+ALTER TABLE employees
+    DROP COLUMN IF EXISTS position_id;
+
+DROP TABLE IF EXISTS positions;
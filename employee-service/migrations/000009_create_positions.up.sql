@@ -0,0 +1,18 @@
+CREATE TABLE positions
+(
+    id                 SERIAL PRIMARY KEY,
+    company_id         INT          NOT NULL,
+    title              VARCHAR(255) NOT NULL,
+    grade              VARCHAR(50)  NOT NULL DEFAULT '',
+    department_id      INT          NOT NULL REFERENCES departments (id),
+    budgeted_headcount INT          NOT NULL DEFAULT 0 CHECK (budgeted_headcount >= 0),
+    created_at         TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    UNIQUE (company_id, department_id, title, grade)
+);
+
+CREATE INDEX idx_positions_department_id ON positions (department_id);
+
+ALTER TABLE employees
+    ADD COLUMN position_id INT REFERENCES positions (id);
+
+CREATE INDEX idx_employees_position_id ON employees (position_id);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_employees_company_id_status;
+
+ALTER TABLE employees
+    DROP CONSTRAINT IF EXISTS employees_termination_after_hire,
+    DROP CONSTRAINT IF EXISTS employees_status_check,
+    DROP COLUMN IF EXISTS termination_reason,
+    DROP COLUMN IF EXISTS termination_date,
+    DROP COLUMN IF EXISTS hire_date,
+    DROP COLUMN IF EXISTS status;
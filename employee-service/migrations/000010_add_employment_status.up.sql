@@ -0,0 +1,11 @@
+ALTER TABLE employees
+    ADD COLUMN status             VARCHAR(16) NOT NULL DEFAULT 'active',
+    ADD COLUMN hire_date          DATE,
+    ADD COLUMN termination_date   DATE,
+    ADD COLUMN termination_reason TEXT        NOT NULL DEFAULT '',
+    ADD CONSTRAINT employees_status_check
+        CHECK (status IN ('candidate', 'onboarding', 'active', 'on_leave', 'terminated')),
+    ADD CONSTRAINT employees_termination_after_hire
+        CHECK (termination_date IS NULL OR hire_date IS NULL OR termination_date >= hire_date);
+
+CREATE INDEX idx_employees_company_id_status ON employees (company_id, status);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS employee_assignments;
+DROP TABLE IF EXISTS transfers;
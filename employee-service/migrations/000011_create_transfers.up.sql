@@ -0,0 +1,44 @@
+CREATE TABLE transfers
+(
+    id               SERIAL PRIMARY KEY,
+    employee_id      INT         NOT NULL REFERENCES employees (id) ON DELETE CASCADE,
+    company_id       INT,
+    department_name  VARCHAR(255),
+    department_phone VARCHAR(50),
+    position_id      INT,
+    manager_id       INT,
+    effective_date   DATE        NOT NULL,
+    reason           TEXT        NOT NULL DEFAULT '',
+    status           VARCHAR(16) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'applied', 'cancelled', 'failed')),
+    failure          TEXT        NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    applied_at       TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX idx_transfers_employee_id_pending ON transfers (employee_id) WHERE status = 'pending';
+CREATE INDEX idx_transfers_effective_date_pending ON transfers (effective_date) WHERE status = 'pending';
+
+CREATE TABLE employee_assignments
+(
+    id               SERIAL PRIMARY KEY,
+    employee_id      INT          NOT NULL REFERENCES employees (id) ON DELETE CASCADE,
+    company_id       INT          NOT NULL,
+    department_name  VARCHAR(255) NOT NULL,
+    department_phone VARCHAR(50)  NOT NULL,
+    position_id      INT,
+    started_on       DATE         NOT NULL,
+    ended_on         DATE,
+    transfer_id      INT REFERENCES transfers (id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX idx_employee_assignments_employee_id_open ON employee_assignments (employee_id)
+    WHERE ended_on IS NULL;
+
+INSERT INTO employee_assignments (employee_id, company_id, department_name, department_phone, position_id,
+                                  started_on, ended_on)
+SELECT e.id, e.company_id, COALESCE(d.name, ''), COALESCE(d.phone, ''), e.position_id,
+       COALESCE(e.hire_date, CURRENT_DATE),
+       CASE WHEN e.status = 'terminated' THEN COALESCE(e.termination_date, CURRENT_DATE) END
+FROM employees AS e
+JOIN departments AS d ON e.department_id = d.id;
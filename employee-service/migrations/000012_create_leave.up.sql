@@ -0,0 +1,57 @@
+CREATE TABLE leave_types
+(
+    id                     SERIAL PRIMARY KEY,
+    company_id             INT           NOT NULL,
+    name                   VARCHAR(100)  NOT NULL,
+    accrual_days_per_month NUMERIC(5, 2) NOT NULL DEFAULT 0 CHECK (accrual_days_per_month >= 0),
+    max_balance            NUMERIC(7, 2) CHECK (max_balance >= 0),
+    unlimited              BOOLEAN       NOT NULL DEFAULT false,
+    created_at             TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    UNIQUE (company_id, name)
+);
+
+CREATE TABLE leave_balances
+(
+    employee_id     INT           NOT NULL REFERENCES employees (id) ON DELETE CASCADE,
+    leave_type_id   INT           NOT NULL REFERENCES leave_types (id),
+    balance         NUMERIC(7, 2) NOT NULL DEFAULT 0,
+    accrued_through DATE          NOT NULL,
+    PRIMARY KEY (employee_id, leave_type_id)
+);
+
+CREATE TABLE leave_requests
+(
+    id               SERIAL PRIMARY KEY,
+    employee_id      INT           NOT NULL REFERENCES employees (id) ON DELETE CASCADE,
+    leave_type_id    INT           NOT NULL REFERENCES leave_types (id),
+    start_date       DATE          NOT NULL,
+    end_date         DATE          NOT NULL,
+    days             NUMERIC(7, 2) NOT NULL,
+    reason           TEXT          NOT NULL DEFAULT '',
+    status           VARCHAR(16)   NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'approved', 'rejected', 'cancelled')),
+    decided_by       VARCHAR(255)  NOT NULL DEFAULT '',
+    decision_comment TEXT          NOT NULL DEFAULT '',
+    decided_at       TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    CHECK (end_date >= start_date)
+);
+
+CREATE INDEX idx_leave_requests_employee_id ON leave_requests (employee_id, start_date);
+CREATE INDEX idx_leave_requests_dates ON leave_requests (start_date, end_date) WHERE status IN ('pending', 'approved');
+
+-- Every change of a balance, so that balances can be explained.
+CREATE TABLE leave_ledger
+(
+    id               BIGSERIAL PRIMARY KEY,
+    employee_id      INT           NOT NULL REFERENCES employees (id) ON DELETE CASCADE,
+    leave_type_id    INT           NOT NULL REFERENCES leave_types (id),
+    days             NUMERIC(7, 2) NOT NULL,
+    kind             VARCHAR(16)   NOT NULL CHECK (kind IN ('accrual', 'adjustment', 'taken', 'refund')),
+    leave_request_id INT REFERENCES leave_requests (id) ON DELETE SET NULL,
+    reason           TEXT          NOT NULL DEFAULT '',
+    caller_id        VARCHAR(255)  NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_leave_ledger_employee_id ON leave_ledger (employee_id, leave_type_id);
@@ -0,0 +1,16 @@
+CREATE TABLE calendar_feeds
+(
+    id               SERIAL PRIMARY KEY,
+    company_id       INT          NOT NULL,
+    -- NULL for a feed of the whole company.
+    department_name  VARCHAR(255),
+    department_phone VARCHAR(50),
+    name             VARCHAR(255) NOT NULL DEFAULT '',
+    -- SHA-256 of the feed token, which is only shown when the feed is created.
+    token_hash       BYTEA        NOT NULL UNIQUE,
+    created_by       VARCHAR(255) NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    revoked_at       TIMESTAMPTZ
+);
+
+CREATE INDEX idx_calendar_feeds_company_id ON calendar_feeds (company_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS employee_addresses;
+DROP TABLE IF EXISTS employee_contacts;
@@ -0,0 +1,33 @@
+CREATE TABLE employee_contacts
+(
+    id          SERIAL PRIMARY KEY,
+    employee_id INT          NOT NULL REFERENCES employees (id) ON DELETE CASCADE,
+    -- Copied from the employee, so that work emails are unique per company.
+    company_id  INT          NOT NULL,
+    kind        VARCHAR(16)  NOT NULL CHECK (kind IN ('work_phone', 'mobile', 'work_email', 'personal_email')),
+    value       VARCHAR(255) NOT NULL,
+    is_primary  BOOLEAN      NOT NULL DEFAULT false
+);
+
+CREATE INDEX idx_employee_contacts_employee_id ON employee_contacts (employee_id);
+CREATE UNIQUE INDEX idx_employee_contacts_primary ON employee_contacts (employee_id, kind) WHERE is_primary;
+CREATE UNIQUE INDEX idx_employee_contacts_work_email ON employee_contacts (company_id, lower(value))
+    WHERE kind = 'work_email';
+
+CREATE TABLE employee_addresses
+(
+    id           SERIAL PRIMARY KEY,
+    employee_id  INT          NOT NULL REFERENCES employees (id) ON DELETE CASCADE,
+    kind         VARCHAR(16)  NOT NULL CHECK (kind IN ('home', 'work', 'mailing')),
+    line1        VARCHAR(255) NOT NULL,
+    line2        VARCHAR(255) NOT NULL DEFAULT '',
+    city         VARCHAR(255) NOT NULL,
+    region       VARCHAR(255) NOT NULL DEFAULT '',
+    postal_code  VARCHAR(16)  NOT NULL DEFAULT '',
+    -- ISO 3166-1 alpha-2.
+    country_code CHAR(2)      NOT NULL,
+    is_primary   BOOLEAN      NOT NULL DEFAULT false
+);
+
+CREATE INDEX idx_employee_addresses_employee_id ON employee_addresses (employee_id);
+CREATE UNIQUE INDEX idx_employee_addresses_primary ON employee_addresses (employee_id, kind) WHERE is_primary;
@@ -0,0 +1,12 @@
+CREATE TABLE emergency_contacts
+(
+    id          SERIAL PRIMARY KEY,
+    employee_id INT            NOT NULL REFERENCES employees (id) ON DELETE CASCADE,
+    name        VARCHAR(255)   NOT NULL,
+    relation    VARCHAR(64)    NOT NULL DEFAULT '',
+    phones      VARCHAR(32)[]  NOT NULL CHECK (cardinality(phones) > 0),
+    created_at  TIMESTAMPTZ    NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ    NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_emergency_contacts_employee_id ON emergency_contacts (employee_id);
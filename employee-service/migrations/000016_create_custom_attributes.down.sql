@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_employees_custom_attributes;
+
+ALTER TABLE employees
+    DROP COLUMN IF EXISTS custom_attributes;
+
+DROP TABLE IF EXISTS attribute_definitions;
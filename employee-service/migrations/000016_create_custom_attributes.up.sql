@@ -0,0 +1,19 @@
+CREATE TABLE attribute_definitions
+(
+    id         SERIAL PRIMARY KEY,
+    company_id INT          NOT NULL,
+    key        VARCHAR(64)  NOT NULL,
+    name       VARCHAR(255) NOT NULL DEFAULT '',
+    type       VARCHAR(16)  NOT NULL CHECK (type IN ('string', 'number', 'date', 'enum')),
+    required   BOOLEAN      NOT NULL DEFAULT false,
+    -- The allowed values of enum attributes, empty for the other types.
+    options    TEXT[]       NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    UNIQUE (company_id, key),
+    CHECK ((type = 'enum') = (cardinality(options) > 0))
+);
+
+ALTER TABLE employees
+    ADD COLUMN custom_attributes JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_employees_custom_attributes ON employees USING GIN (custom_attributes jsonb_path_ops);
@@ -0,0 +1,17 @@
+-- Signing secrets can only be decrypted with the keyring, so rolling back is
+-- refused once any secret exists only as ciphertext.
+DO $$
+BEGIN
+    IF EXISTS(SELECT 1 FROM webhooks WHERE secret IS NULL AND secret_ciphertext IS NOT NULL) THEN
+        RAISE EXCEPTION 'webhook secrets are encrypted: this migration is irreversible';
+    END IF;
+END
+$$;
+
+DROP INDEX IF EXISTS idx_webhooks_secret_key_id;
+
+ALTER TABLE webhooks
+    ALTER COLUMN secret SET NOT NULL,
+    DROP COLUMN IF EXISTS secret_key_id,
+    DROP COLUMN IF EXISTS secret_dek,
+    DROP COLUMN IF EXISTS secret_ciphertext;
@@ -0,0 +1,7 @@
+ALTER TABLE webhooks
+    ADD COLUMN secret_ciphertext BYTEA,
+    ADD COLUMN secret_dek        BYTEA,
+    ADD COLUMN secret_key_id     VARCHAR(64),
+    ALTER COLUMN secret DROP NOT NULL;
+
+CREATE INDEX idx_webhooks_secret_key_id ON webhooks (secret_key_id);
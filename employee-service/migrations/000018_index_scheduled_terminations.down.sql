@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_employees_termination_date_scheduled;
@@ -0,0 +1,4 @@
+-- Employees who are not terminated only keep a termination date while their
+-- termination is scheduled.
+CREATE INDEX idx_employees_termination_date_scheduled ON employees (termination_date)
+    WHERE status <> 'terminated' AND termination_date IS NOT NULL;
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+const (
+	AttributeString = "string"
+	AttributeNumber = "number"
+	AttributeDate   = "date"
+	AttributeEnum   = "enum"
+)
+
+// AttributeTypes lists every type of custom attribute.
+var AttributeTypes = []string{AttributeString, AttributeNumber, AttributeDate, AttributeEnum}
+
+// AttributeDefinition is a custom attribute a company defines for its
+// employees. Options lists the allowed values of enum attributes.
+type AttributeDefinition struct {
+	Id        int32
+	CompanyId int32
+	Key       string
+	Name      string
+	Type      string
+	Required  bool
+	Options   []string
+	CreatedAt time.Time
+}
+
+// AttributeDefinitionUpdate lists the properties of a definition to change.
+// Nil fields are kept. The key and type of a definition never change, since
+// stored values depend on them.
+type AttributeDefinitionUpdate struct {
+	Name     *string
+	Required *bool
+	Options  []string
+}
+
+func IsAttributeType(attributeType string) bool {
+	for _, known := range AttributeTypes {
+		if attributeType == known {
+			return true
+		}
+	}
+	return false
+}
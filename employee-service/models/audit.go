@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+const (
+	AuditEmployeeCreated  = "employee.created"
+	AuditEmployeeUpdated  = "employee.updated"
+	AuditEmployeeDeleted  = "employee.deleted"
+	AuditEmployeeExported = "employee.exported"
+	AuditEmployeeErased   = "employee.erased"
+	// AuditEmploymentChanged records a status transition, with the old and
+	// new status as details.
+	AuditEmploymentChanged = "employee.employment_changed"
+	// AuditEmployeeTransferred records an applied transfer, with the changed
+	// fields and the transfer id as details.
+	AuditEmployeeTransferred = "employee.transferred"
+	// AuditEmergencyContactsChanged records an added, updated or deleted
+	// emergency contact, with the operation and contact id as details.
+	AuditEmergencyContactsChanged = "employee.emergency_contacts_changed"
+)
+
+// AuditEntry records who did what to an employee. Details never contain
+// personal data, so entries survive erasure unchanged.
+type AuditEntry struct {
+	Id         int64
+	EmployeeId int32
+	Action     string
+	CallerId   string
+	Details    map[string]interface{}
+	CreatedAt  time.Time
+}
+
+// EmployeeDossier is everything stored about an employee, as returned to a
+// data subject access request.
+type EmployeeDossier struct {
+	Employee          Employee
+	AuditHistory      []AuditEntry
+	EmergencyContacts []EmergencyContact
+	LeaveBalances     []LeaveBalance
+	LeaveRequests     []LeaveRequest
+	LeaveLedger       []LeaveLedgerEntry
+	Transfers         []Transfer
+	Assignments       []Assignment
+	ErasedAt          *time.Time
+	ExportedAt        time.Time
+}
@@ -0,0 +1,15 @@
+package models
+
+// BatchResult is the outcome of one item of a batch, in request order. Id is
+// the created employee for adds and the affected employee otherwise.
+type BatchResult struct {
+	Id       int32
+	Replayed bool
+	Err      error
+}
+
+// AddItem is one employee of a batch add, with its optional idempotency key.
+type AddItem struct {
+	Employee       Employee
+	IdempotencyKey *IdempotencyKey
+}
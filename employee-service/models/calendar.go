@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+const (
+	CalendarEventStart     = "start"
+	CalendarEventDeparture = "departure"
+	CalendarEventTransfer  = "transfer"
+	CalendarEventAbsence   = "absence"
+)
+
+// CalendarFeed publishes the dated events of a company, or of one of its
+// departments when Department is set, to calendar apps. The feed is read with a
+// token that is only returned when the feed is created.
+type CalendarFeed struct {
+	Id         int32
+	CompanyId  int32
+	Department Department
+	Name       string
+	CreatedBy  string
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+}
+
+// CalendarEvent is an all-day event of an employee from StartDate to EndDate
+// inclusive. SourceId identifies the transfer or leave request of the event,
+// and is 0 for starts and departures.
+type CalendarEvent struct {
+	Kind       string
+	SourceId   int32
+	EmployeeId int32
+	Name       string
+	Surname    string
+	StartDate  time.Time
+	EndDate    time.Time
+	// Department is where a transfer moves the employee. Empty fields are
+	// kept.
+	Department Department
+	CompanyId  int32
+}
@@ -0,0 +1,84 @@
+package models
+
+import "time"
+
+const (
+	ContactWorkPhone     = "work_phone"
+	ContactMobile        = "mobile"
+	ContactWorkEmail     = "work_email"
+	ContactPersonalEmail = "personal_email"
+)
+
+// ContactKinds lists every kind of contact.
+var ContactKinds = []string{ContactWorkPhone, ContactMobile, ContactWorkEmail, ContactPersonalEmail}
+
+const (
+	AddressHome    = "home"
+	AddressWork    = "work"
+	AddressMailing = "mailing"
+)
+
+// AddressKinds lists every kind of address.
+var AddressKinds = []string{AddressHome, AddressWork, AddressMailing}
+
+// Contact is a phone number or email address of an employee. At most one
+// contact of each kind is primary.
+type Contact struct {
+	Kind    string
+	Value   string
+	Primary bool
+}
+
+// Address is a postal address of an employee. CountryCode is an ISO 3166-1
+// alpha-2 code. At most one address of each kind is primary.
+type Address struct {
+	Kind        string
+	Line1       string
+	Line2       string
+	City        string
+	Region      string
+	PostalCode  string
+	CountryCode string
+	Primary     bool
+}
+
+// EmergencyContact is a person to call when something happens to an
+// employee. Phones lists their numbers in the order to try them.
+type EmergencyContact struct {
+	Id         int32
+	EmployeeId int32
+	Name       string
+	Relation   string
+	Phones     []string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func IsContactKind(kind string) bool {
+	for _, known := range ContactKinds {
+		if kind == known {
+			return true
+		}
+	}
+	return false
+}
+
+func IsAddressKind(kind string) bool {
+	for _, known := range AddressKinds {
+		if kind == known {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPersonalContact reports whether contacts of kind are personal data rather
+// than work contacts.
+func IsPersonalContact(kind string) bool {
+	return kind == ContactMobile || kind == ContactPersonalEmail
+}
+
+// IsPersonalAddress reports whether addresses of kind are personal data.
+func IsPersonalAddress(kind string) bool {
+	return kind != AddressWork
+}
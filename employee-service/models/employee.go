@@ -18,4 +18,26 @@ type Employee struct {
 	CompanyId  int32
 	Passport   Passport
 	Department Department
+	// ManagerId is nil for employees without a manager. In updates nil keeps
+	// the current manager and a pointer to 0 removes it.
+	ManagerId *int32
+	// PositionId follows the same rules as ManagerId.
+	PositionId *int32
+	// Contacts and Addresses replace the current ones in updates unless they
+	// are nil, so an empty non-nil slice removes them all.
+	Contacts  []Contact
+	Addresses []Address
+	// CustomAttributes holds the values of the custom attributes of the
+	// company by key. Updates merge them into the current values, and an empty
+	// value removes one.
+	CustomAttributes map[string]string
+	// Employment is only set by AddEmployee and the lifecycle operations,
+	// updates ignore it.
+	Employment Employment
+}
+
+// OrgNode is an employee in an org subtree, Depth levels below its root.
+type OrgNode struct {
+	Employee Employee
+	Depth    int32
 }
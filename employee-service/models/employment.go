@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+const (
+	StatusCandidate  = "candidate"
+	StatusOnboarding = "onboarding"
+	StatusActive     = "active"
+	StatusOnLeave    = "on_leave"
+	StatusTerminated = "terminated"
+)
+
+// EmploymentStatuses lists every status, in lifecycle order.
+var EmploymentStatuses = []string{StatusCandidate, StatusOnboarding, StatusActive, StatusOnLeave, StatusTerminated}
+
+// CurrentStatuses are the statuses of people who work for the company.
+var CurrentStatuses = []string{StatusOnboarding, StatusActive, StatusOnLeave}
+
+// DateLayout is the format of hire and termination dates in requests.
+const DateLayout = "2006-01-02"
+
+// Employment is the lifecycle state of an employee. HireDate is nil for
+// candidates, TerminationDate and TerminationReason are only set while the
+// employee is terminated or their termination is scheduled.
+type Employment struct {
+	Status            string
+	HireDate          *time.Time
+	TerminationDate   *time.Time
+	TerminationReason string
+}
+
+func IsEmploymentStatus(status string) bool {
+	for _, known := range EmploymentStatuses {
+		if status == known {
+			return true
+		}
+	}
+	return false
+}
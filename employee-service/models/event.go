@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+const (
+	EventEmployeeCreated = "EmployeeCreated"
+	EventEmployeeUpdated = "EmployeeUpdated"
+	EventEmployeeDeleted = "EmployeeDeleted"
+)
+
+// Event is an employee change written to the outbox in the transaction that
+// made the change. Payload is the JSON encoded EmployeeEventData.
+type Event struct {
+	Id         int64
+	Type       string
+	EmployeeId int32
+	Payload    []byte
+	CreatedAt  time.Time
+	Position   ChangePosition
+}
+
+// ChangePosition orders events in the change feed of a company. Events are
+// ordered by the id of the transaction that wrote them, not by Id, because a
+// transaction may commit after a later one.
+type ChangePosition struct {
+	TxId uint64
+	Id   int64
+}
+
+// EmployeeEventData is the state of the employee after the change. Passport
+// numbers and personal phone numbers are never included. Employee is nil for EmployeeDeleted.
+type EmployeeEventData struct {
+	EmployeeId    int32              `json:"employee_id"`
+	CompanyId     int32              `json:"company_id"`
+	Employee      *EmployeeEventView `json:"employee,omitempty"`
+	ChangedFields []string           `json:"changed_fields,omitempty"`
+}
+
+type EmployeeEventView struct {
+	Name            string `json:"name"`
+	Surname         string `json:"surname"`
+	PassportType    string `json:"passport_type"`
+	DepartmentName  string `json:"department_name"`
+	DepartmentPhone string `json:"department_phone"`
+	ManagerId       *int32 `json:"manager_id"`
+	PositionId      *int32 `json:"position_id"`
+	Status          string `json:"status"`
+}
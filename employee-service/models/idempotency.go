@@ -0,0 +1,9 @@
+package models
+
+// IdempotencyKey identifies the retries of a request. Keys are scoped to the
+// caller, and RequestHash detects a key reused for a different payload.
+type IdempotencyKey struct {
+	CallerId    string
+	Key         string
+	RequestHash string
+}
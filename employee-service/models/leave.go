@@ -0,0 +1,114 @@
+package models
+
+import "time"
+
+const (
+	LeavePending   = "pending"
+	LeaveApproved  = "approved"
+	LeaveRejected  = "rejected"
+	LeaveCancelled = "cancelled"
+)
+
+// LeaveStatuses lists every status of a leave request.
+var LeaveStatuses = []string{LeavePending, LeaveApproved, LeaveRejected, LeaveCancelled}
+
+const (
+	LedgerAccrual    = "accrual"
+	LedgerAdjustment = "adjustment"
+	LedgerTaken      = "taken"
+	LedgerRefund     = "refund"
+)
+
+// LeaveType is a kind of leave of a company. Balances grow by
+// AccrualDaysPerMonth at the start of every month, up to MaxBalance when it is
+// set. Unlimited types, such as sick leave, have no balance.
+type LeaveType struct {
+	Id                  int32
+	CompanyId           int32
+	Name                string
+	AccrualDaysPerMonth float64
+	MaxBalance          *float64
+	Unlimited           bool
+	CreatedAt           time.Time
+}
+
+// LeaveTypeUpdate lists the attributes of a leave type to change. Nil fields
+// are kept, a MaxBalance of 0 removes the cap.
+type LeaveTypeUpdate struct {
+	Name                *string
+	AccrualDaysPerMonth *float64
+	MaxBalance          *float64
+}
+
+// LeaveBalance is the balance of an employee for a leave type. Pending counts
+// the days of requests awaiting a decision, which are not available.
+type LeaveBalance struct {
+	LeaveType LeaveType
+	Balance   float64
+	Pending   float64
+}
+
+func (b LeaveBalance) Available() float64 {
+	return b.Balance - b.Pending
+}
+
+// LeaveRequest is a leave of an employee from StartDate to EndDate inclusive.
+// Days counts the working days in between.
+type LeaveRequest struct {
+	Id              int32
+	EmployeeId      int32
+	LeaveTypeId     int32
+	LeaveTypeName   string
+	StartDate       time.Time
+	EndDate         time.Time
+	Days            float64
+	Reason          string
+	Status          string
+	DecidedBy       string
+	DecisionComment string
+	DecidedAt       *time.Time
+	CreatedAt       time.Time
+}
+
+// LeaveLedgerEntry is a change of a balance: an accrual, a manual adjustment,
+// or days taken by or refunded from a leave request.
+type LeaveLedgerEntry struct {
+	Id             int64
+	LeaveTypeId    int32
+	LeaveTypeName  string
+	Days           float64
+	Kind           string
+	LeaveRequestId *int32
+	Reason         string
+	CallerId       string
+	CreatedAt      time.Time
+}
+
+// LeaveActor is who acts on a leave request: the employee bound to the API key
+// of the caller, if any, and whether the caller may manage any leave.
+type LeaveActor struct {
+	EmployeeId int32
+	Admin      bool
+}
+
+// Absence is an approved or pending leave shown on a team calendar.
+type Absence struct {
+	LeaveRequestId int32
+	EmployeeId     int32
+	Name           string
+	Surname        string
+	Department     Department
+	LeaveTypeName  string
+	StartDate      time.Time
+	EndDate        time.Time
+	Status         string
+}
+
+func IsLeaveStatus(status string) bool {
+	for _, known := range LeaveStatuses {
+		if status == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Position is a job in a department of a company. BudgetedHeadcount is the
+// number of employees planned for it, FilledHeadcount the number assigned.
+type Position struct {
+	Id                int32
+	CompanyId         int32
+	Title             string
+	Grade             string
+	Department        Department
+	BudgetedHeadcount int32
+	FilledHeadcount   int32
+	CreatedAt         time.Time
+}
+
+// PositionUpdate lists the attributes of a position to change. Nil fields and
+// an empty Title or Department are kept.
+type PositionUpdate struct {
+	Title             string
+	Grade             *string
+	Department        Department
+	BudgetedHeadcount *int32
+}
+
+// DepartmentHeadcount compares the budgeted and filled headcount of the
+// positions of a department. Open counts unfilled places and Overfilled the
+// employees beyond the budget, per position.
+type DepartmentHeadcount struct {
+	Department Department
+	Positions  int32
+	Budgeted   int32
+	Filled     int32
+	Open       int32
+	Overfilled int32
+}
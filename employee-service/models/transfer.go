@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+const (
+	TransferPending   = "pending"
+	TransferApplied   = "applied"
+	TransferCancelled = "cancelled"
+	TransferFailed    = "failed"
+)
+
+// Transfer moves an employee to another company or department on
+// EffectiveDate. Zero values keep the current assignment; PositionId and
+// ManagerId follow the rules of Employee.
+type Transfer struct {
+	Id            int32
+	EmployeeId    int32
+	CompanyId     int32
+	Department    Department
+	PositionId    *int32
+	ManagerId     *int32
+	EffectiveDate time.Time
+	Reason        string
+	Status        string
+	// Failure explains why a scheduled transfer could not be applied.
+	Failure   string
+	CreatedAt time.Time
+	AppliedAt *time.Time
+}
+
+// Assignment is the company, department and position an employee held from
+// StartedOn until EndedOn, which is nil for the current assignment.
+type Assignment struct {
+	CompanyId  int32
+	Department Department
+	PositionId *int32
+	StartedOn  time.Time
+	EndedOn    *time.Time
+	TransferId *int32
+}
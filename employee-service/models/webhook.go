@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	// DeliveryDead marks deliveries that ran out of attempts. They stay in the
+	// delivery log and are not retried.
+	DeliveryDead = "dead"
+)
+
+// Webhook is a partner endpoint notified about employee events of a company.
+// Secret signs the deliveries and is only returned when the webhook is created.
+type Webhook struct {
+	Id         int32
+	CompanyId  int32
+	URL        string
+	EventTypes []string
+	Secret     string
+	CreatedBy  string
+	CreatedAt  time.Time
+}
+
+type WebhookDelivery struct {
+	Id             int64
+	WebhookId      int32
+	EventId        int64
+	EventType      string
+	Payload        []byte
+	Status         string
+	Attempts       int32
+	NextAttemptAt  time.Time
+	LastStatusCode *int32
+	LastError      string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+// DeliveryAttempt is the outcome of sending a delivery once. Delivered is set
+// for 2xx responses; StatusCode is 0 when no response was received.
+type DeliveryAttempt struct {
+	Delivered  bool
+	StatusCode int32
+	Error      string
+}
+
+// DueDelivery is a pending delivery claimed by a dispatcher, together with the
+// webhook it is sent to.
+type DueDelivery struct {
+	Webhook  Webhook
+	Delivery WebhookDelivery
+}
+
+// DeliveryResult is the state of a claimed delivery after an attempt. Status
+// is DeliveryPending with the delay before the next attempt in RetryIn, or
+// DeliveryDelivered or DeliveryDead.
+type DeliveryResult struct {
+	DeliveryId int64
+	Attempts   int32
+	Attempt    DeliveryAttempt
+	Status     string
+	RetryIn    time.Duration
+}
@@ -0,0 +1,198 @@
+package orgchart
+
+import (
+	"employee-service/models"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	NodeCompany    = "company"
+	NodeDepartment = "department"
+	NodeEmployee   = "employee"
+)
+
+// Node is a box of the chart. Employees are children of their manager when the
+// manager is in the same department, and of their department otherwise.
+type Node struct {
+	Id         string  `json:"id"`
+	Type       string  `json:"type"`
+	Label      string  `json:"label"`
+	EmployeeId int32   `json:"employee_id,omitempty"`
+	ManagerId  int32   `json:"manager_id,omitempty"`
+	Children   []*Node `json:"children,omitempty"`
+}
+
+// Link is a manager relationship that is not an edge of the tree, because the
+// manager works in another department.
+type Link struct {
+	ManagerId  string
+	EmployeeId string
+}
+
+// Chart is a tree of nodes plus the manager links between its branches.
+type Chart struct {
+	Root  *Node
+	Links []Link
+}
+
+// ForCompany builds the company -> department -> employee tree of employees,
+// who all work for companyId. maxDepth limits the levels below the company,
+// 0 means no limit.
+func ForCompany(companyId int32, employees []models.Employee, maxDepth int) Chart {
+	root := &Node{Id: fmt.Sprintf("company_%d", companyId), Type: NodeCompany, Label: fmt.Sprintf("Company %d", companyId)}
+	b := newBuilder(employees, false)
+
+	for i, department := range b.departments {
+		node := &Node{Id: fmt.Sprintf("department_%d", i+1), Type: NodeDepartment, Label: departmentLabel(department)}
+		if within(1, maxDepth) {
+			root.Children = append(root.Children, node)
+			b.addEmployees(node, b.departmentTops[department], 2, maxDepth)
+		}
+	}
+	return b.chart(root)
+}
+
+// ForDepartment builds the tree of a single department. employees must all work
+// in it.
+func ForDepartment(department models.Department, employees []models.Employee, maxDepth int) Chart {
+	root := &Node{Id: "department_1", Type: NodeDepartment, Label: departmentLabel(department)}
+	b := newBuilder(employees, false)
+	b.addEmployees(root, b.departmentTops[department], 1, maxDepth)
+	return b.chart(root)
+}
+
+// ForEmployee builds the tree of root and the employees reporting to it, in any
+// department. Every employee but root must have its manager in employees.
+func ForEmployee(root models.Employee, employees []models.Employee, maxDepth int) Chart {
+	b := newBuilder(employees, true)
+	node := b.employeeNode(root)
+	b.addEmployees(node, b.reports[root.Id], 1, maxDepth)
+	return b.chart(node)
+}
+
+type builder struct {
+	departments    []models.Department
+	departmentTops map[models.Department][]models.Employee
+	reports        map[int32][]models.Employee
+	managers       map[int32]models.Employee
+
+	rendered map[int32]bool
+}
+
+// newBuilder arranges employees under their managers. Unless acrossDepartments
+// is set, only managers in the same department are parents in the tree.
+func newBuilder(employees []models.Employee, acrossDepartments bool) *builder {
+	b := &builder{
+		departmentTops: make(map[models.Department][]models.Employee),
+		reports:        make(map[int32][]models.Employee),
+		managers:       make(map[int32]models.Employee),
+		rendered:       make(map[int32]bool),
+	}
+
+	sorted := append([]models.Employee(nil), employees...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Id < sorted[j].Id })
+
+	byId := make(map[int32]models.Employee, len(sorted))
+	for _, employee := range sorted {
+		byId[employee.Id] = employee
+	}
+
+	seen := make(map[models.Department]bool)
+	for _, employee := range sorted {
+		if !seen[employee.Department] {
+			seen[employee.Department] = true
+			b.departments = append(b.departments, employee.Department)
+		}
+
+		manager, ok := b.manager(employee, byId)
+		switch {
+		case !ok:
+			b.departmentTops[employee.Department] = append(b.departmentTops[employee.Department], employee)
+		case acrossDepartments || manager.Department == employee.Department:
+			b.reports[manager.Id] = append(b.reports[manager.Id], employee)
+		default:
+			// Shown in its own department and linked to the manager.
+			b.departmentTops[employee.Department] = append(b.departmentTops[employee.Department], employee)
+			b.managers[employee.Id] = manager
+		}
+	}
+
+	sort.Slice(b.departments, func(i, j int) bool {
+		if b.departments[i].Name != b.departments[j].Name {
+			return b.departments[i].Name < b.departments[j].Name
+		}
+		return b.departments[i].Phone < b.departments[j].Phone
+	})
+	return b
+}
+
+func (b *builder) manager(employee models.Employee, byId map[int32]models.Employee) (models.Employee, bool) {
+	if employee.ManagerId == nil {
+		return models.Employee{}, false
+	}
+	manager, ok := byId[*employee.ManagerId]
+	return manager, ok
+}
+
+// addEmployees adds employees and their reports below parent, which is depth-1
+// levels below the root.
+func (b *builder) addEmployees(parent *Node, employees []models.Employee, depth, maxDepth int) {
+	if !within(depth, maxDepth) {
+		return
+	}
+	for _, employee := range employees {
+		node := b.employeeNode(employee)
+		parent.Children = append(parent.Children, node)
+		b.addEmployees(node, b.reports[employee.Id], depth+1, maxDepth)
+	}
+}
+
+func (b *builder) employeeNode(employee models.Employee) *Node {
+	b.rendered[employee.Id] = true
+	node := &Node{
+		Id:         employeeNodeId(employee.Id),
+		Type:       NodeEmployee,
+		Label:      strings.TrimSpace(employee.Name + " " + employee.Surname),
+		EmployeeId: employee.Id,
+	}
+	if employee.ManagerId != nil {
+		node.ManagerId = *employee.ManagerId
+	}
+	return node
+}
+
+// chart links the rendered employees to their rendered managers in other
+// departments.
+func (b *builder) chart(root *Node) Chart {
+	ids := make([]int32, 0, len(b.managers))
+	for id := range b.managers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	chart := Chart{Root: root}
+	for _, id := range ids {
+		manager := b.managers[id]
+		if b.rendered[id] && b.rendered[manager.Id] {
+			chart.Links = append(chart.Links, Link{ManagerId: employeeNodeId(manager.Id), EmployeeId: employeeNodeId(id)})
+		}
+	}
+	return chart
+}
+
+func within(depth, maxDepth int) bool {
+	return maxDepth <= 0 || depth <= maxDepth
+}
+
+func employeeNodeId(id int32) string {
+	return fmt.Sprintf("employee_%d", id)
+}
+
+func departmentLabel(department models.Department) string {
+	if department.Phone == "" {
+		return department.Name
+	}
+	return fmt.Sprintf("%s (%s)", department.Name, department.Phone)
+}
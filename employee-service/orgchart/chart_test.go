@@ -0,0 +1,138 @@
+package orgchart
+
+import (
+	"employee-service/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var (
+	sales = models.Department{Name: "Sales", Phone: "+74950000001"}
+	it    = models.Department{Name: "IT"}
+)
+
+func employee(id int32, name string, department models.Department, managerId int32) models.Employee {
+	e := models.Employee{Id: id, Name: name, CompanyId: 1, Department: department}
+	if managerId != 0 {
+		e.ManagerId = &managerId
+	}
+	return e
+}
+
+// testEmployees are Anna heading Sales with Boris and then Vera below her, and
+// Gleb heading IT with Dina below him. Gleb reports to Anna and Egor, also in
+// IT, reports to Boris, so both are linked across departments.
+func testEmployees() []models.Employee {
+	return []models.Employee{
+		employee(6, "Egor", it, 2),
+		employee(3, "Vera", sales, 2),
+		employee(1, "Anna", sales, 0),
+		employee(5, "Dina", it, 4),
+		employee(2, "Boris", sales, 1),
+		employee(4, "Gleb", it, 1),
+	}
+}
+
+// tree lists the nodes of chart depth first as "parent>node", "node" for the
+// root.
+func tree(chart Chart) string {
+	var edges []string
+	walk(chart.Root, func(node, parent *Node) {
+		if parent == nil {
+			edges = append(edges, node.Id)
+		} else {
+			edges = append(edges, parent.Id+">"+node.Id)
+		}
+	})
+	return strings.Join(edges, " ")
+}
+
+func TestForCompany(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxDepth int
+		tree     string
+		links    []Link
+	}{
+		{
+			name:     "unlimited",
+			maxDepth: 0,
+			tree: "company_1 company_1>department_1 department_1>employee_4 employee_4>employee_5 " +
+				"department_1>employee_6 company_1>department_2 department_2>employee_1 employee_1>employee_2 " +
+				"employee_2>employee_3",
+			links: []Link{
+				{ManagerId: "employee_1", EmployeeId: "employee_4"},
+				{ManagerId: "employee_2", EmployeeId: "employee_6"},
+			},
+		},
+		{
+			// Boris is cut off, so Egor is shown without the link to him.
+			name:     "department heads only",
+			maxDepth: 2,
+			tree: "company_1 company_1>department_1 department_1>employee_4 department_1>employee_6 " +
+				"company_1>department_2 department_2>employee_1",
+			links: []Link{{ManagerId: "employee_1", EmployeeId: "employee_4"}},
+		},
+		{
+			name:     "departments only",
+			maxDepth: 1,
+			tree:     "company_1 company_1>department_1 company_1>department_2",
+		},
+	}
+	for _, test := range tests {
+		chart := ForCompany(1, testEmployees(), test.maxDepth)
+		if got := tree(chart); got != test.tree {
+			t.Errorf("%s: tree = %q, want %q", test.name, got, test.tree)
+		}
+		if !reflect.DeepEqual(chart.Links, test.links) {
+			t.Errorf("%s: links = %v, want %v", test.name, chart.Links, test.links)
+		}
+	}
+
+	chart := ForCompany(1, testEmployees(), 0)
+	if got := chart.Root.Children[1].Label; got != "Sales (+74950000001)" {
+		t.Errorf("department label = %q, want %q", got, "Sales (+74950000001)")
+	}
+	if got := chart.Root.Children[0].Children[0].ManagerId; got != 1 {
+		t.Errorf("manager id of Gleb = %d, want 1", got)
+	}
+}
+
+func TestForEmployee(t *testing.T) {
+	employees := testEmployees()
+	var root models.Employee
+	for _, e := range employees {
+		if e.Id == 1 {
+			root = e
+		}
+	}
+
+	tests := []struct {
+		name     string
+		maxDepth int
+		tree     string
+	}{
+		{
+			name:     "unlimited",
+			maxDepth: 0,
+			tree: "employee_1 employee_1>employee_2 employee_2>employee_3 employee_2>employee_6 " +
+				"employee_1>employee_4 employee_4>employee_5",
+		},
+		{
+			name:     "direct reports",
+			maxDepth: 1,
+			tree:     "employee_1 employee_1>employee_2 employee_1>employee_4",
+		},
+	}
+	for _, test := range tests {
+		chart := ForEmployee(root, employees, test.maxDepth)
+		if got := tree(chart); got != test.tree {
+			t.Errorf("%s: tree = %q, want %q", test.name, got, test.tree)
+		}
+		// Reports in other departments are children, not links.
+		if len(chart.Links) != 0 {
+			t.Errorf("%s: links = %v, want none", test.name, chart.Links)
+		}
+	}
+}
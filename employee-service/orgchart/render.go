@@ -0,0 +1,110 @@
+package orgchart
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+	FormatJSON    = "json"
+)
+
+// ContentTypes are the media types of the rendered formats.
+var ContentTypes = map[string]string{
+	FormatDOT:     "text/vnd.graphviz; charset=utf-8",
+	FormatMermaid: "text/plain; charset=utf-8",
+	FormatJSON:    "application/json; charset=utf-8",
+}
+
+// Render renders chart in format.
+func Render(chart Chart, format string) (string, error) {
+	switch format {
+	case FormatDOT:
+		return DOT(chart), nil
+	case FormatMermaid:
+		return Mermaid(chart), nil
+	case FormatJSON:
+		return JSON(chart)
+	default:
+		return "", fmt.Errorf("unknown org chart format %q", format)
+	}
+}
+
+// DOT renders chart as a Graphviz digraph. Manager links across departments
+// are dashed.
+func DOT(chart Chart) string {
+	var b strings.Builder
+	b.WriteString("digraph orgchart {\n")
+	b.WriteString("  rankdir=TB;\n")
+	b.WriteString("  node [shape=box, style=rounded];\n")
+
+	walk(chart.Root, func(node, parent *Node) {
+		attrs := ""
+		if node.Type != NodeEmployee {
+			attrs = ", style=\"rounded,bold\""
+		}
+		fmt.Fprintf(&b, "  %s [label=%s%s];\n", node.Id, dotQuote(node.Label), attrs)
+		if parent != nil {
+			fmt.Fprintf(&b, "  %s -> %s;\n", parent.Id, node.Id)
+		}
+	})
+	for _, link := range chart.Links {
+		fmt.Fprintf(&b, "  %s -> %s [style=dashed];\n", link.ManagerId, link.EmployeeId)
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid renders chart as a Mermaid flowchart. Manager links across
+// departments are dotted.
+func Mermaid(chart Chart) string {
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+
+	walk(chart.Root, func(node, parent *Node) {
+		fmt.Fprintf(&b, "  %s[%s]\n", node.Id, mermaidQuote(node.Label))
+		if parent != nil {
+			fmt.Fprintf(&b, "  %s --> %s\n", parent.Id, node.Id)
+		}
+	})
+	for _, link := range chart.Links {
+		fmt.Fprintf(&b, "  %s -.-> %s\n", link.ManagerId, link.EmployeeId)
+	}
+	return b.String()
+}
+
+// JSON renders the tree of chart. Manager links are kept as the manager_id of
+// employee nodes.
+func JSON(chart Chart) (string, error) {
+	data, err := json.MarshalIndent(chart.Root, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("render org chart: %w", err)
+	}
+	return string(data), nil
+}
+
+// walk calls fn for every node in depth-first order, parents first.
+func walk(node *Node, fn func(node, parent *Node)) {
+	var visit func(node, parent *Node)
+	visit = func(node, parent *Node) {
+		fn(node, parent)
+		for _, child := range node.Children {
+			visit(child, node)
+		}
+	}
+	visit(node, nil)
+}
+
+func dotQuote(label string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(label) + `"`
+}
+
+// mermaidQuote quotes label for a node shape. Mermaid has no escape character
+// inside quotes, only HTML entities.
+func mermaidQuote(label string) string {
+	return `"` + strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(label) + `"`
+}
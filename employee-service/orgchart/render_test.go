@@ -0,0 +1,76 @@
+package orgchart
+
+import (
+	"strings"
+	"testing"
+)
+
+func testChart() Chart {
+	gleb := &Node{Id: "employee_4", Type: NodeEmployee, Label: `Gleb "the boss"`, EmployeeId: 4, ManagerId: 1}
+	anna := &Node{Id: "employee_1", Type: NodeEmployee, Label: "Anna", EmployeeId: 1}
+	return Chart{
+		Root: &Node{Id: "company_1", Type: NodeCompany, Label: "Company 1", Children: []*Node{
+			{Id: "department_1", Type: NodeDepartment, Label: "IT", Children: []*Node{gleb}},
+			{Id: "department_2", Type: NodeDepartment, Label: "Sales", Children: []*Node{anna}},
+		}},
+		Links: []Link{{ManagerId: "employee_1", EmployeeId: "employee_4"}},
+	}
+}
+
+func TestDOT(t *testing.T) {
+	want := `digraph orgchart {
+  rankdir=TB;
+  node [shape=box, style=rounded];
+  company_1 [label="Company 1", style="rounded,bold"];
+  department_1 [label="IT", style="rounded,bold"];
+  company_1 -> department_1;
+  employee_4 [label="Gleb \"the boss\""];
+  department_1 -> employee_4;
+  department_2 [label="Sales", style="rounded,bold"];
+  company_1 -> department_2;
+  employee_1 [label="Anna"];
+  department_2 -> employee_1;
+  employee_1 -> employee_4 [style=dashed];
+}
+`
+	if got := DOT(testChart()); got != want {
+		t.Errorf("DOT() = %q, want %q", got, want)
+	}
+}
+
+func TestMermaid(t *testing.T) {
+	want := `flowchart TD
+  company_1["Company 1"]
+  department_1["IT"]
+  company_1 --> department_1
+  employee_4["Gleb #quot;the boss#quot;"]
+  department_1 --> employee_4
+  department_2["Sales"]
+  company_1 --> department_2
+  employee_1["Anna"]
+  department_2 --> employee_1
+  employee_1 -.-> employee_4
+`
+	if got := Mermaid(testChart()); got != want {
+		t.Errorf("Mermaid() = %q, want %q", got, want)
+	}
+}
+
+// TestRenderCutOffManager checks that a chart cut off by maxDepth renders no
+// link to a manager outside of it, which Graphviz and Mermaid would draw as a
+// stray node.
+func TestRenderCutOffManager(t *testing.T) {
+	chart := ForCompany(1, testEmployees(), 2)
+	for _, format := range []string{FormatDOT, FormatMermaid} {
+		out, err := Render(chart, format)
+		if err != nil {
+			t.Fatalf("Render(%s) error = %v", format, err)
+		}
+		if strings.Contains(out, "employee_2") {
+			t.Errorf("Render(%s) mentions the cut off employee_2:\n%s", format, out)
+		}
+		if !strings.Contains(out, "employee_6") {
+			t.Errorf("Render(%s) does not contain employee_6:\n%s", format, out)
+		}
+	}
+}
@@ -19,10 +19,32 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	EmployeeService_AddEmployee_FullMethodName          = "/proto.EmployeeService/AddEmployee"
-	EmployeeService_DeleteEmployee_FullMethodName       = "/proto.EmployeeService/DeleteEmployee"
-	EmployeeService_ShowCompanyEmployees_FullMethodName = "/proto.EmployeeService/ShowCompanyEmployees"
-	EmployeeService_UpdateEmployee_FullMethodName       = "/proto.EmployeeService/UpdateEmployee"
+	EmployeeService_AddEmployee_FullMethodName            = "/proto.EmployeeService/AddEmployee"
+	EmployeeService_DeleteEmployee_FullMethodName         = "/proto.EmployeeService/DeleteEmployee"
+	EmployeeService_ShowCompanyEmployees_FullMethodName   = "/proto.EmployeeService/ShowCompanyEmployees"
+	EmployeeService_UpdateEmployee_FullMethodName         = "/proto.EmployeeService/UpdateEmployee"
+	EmployeeService_ExportEmployeeData_FullMethodName     = "/proto.EmployeeService/ExportEmployeeData"
+	EmployeeService_EraseEmployee_FullMethodName          = "/proto.EmployeeService/EraseEmployee"
+	EmployeeService_WatchEmployees_FullMethodName         = "/proto.EmployeeService/WatchEmployees"
+	EmployeeService_BatchAddEmployees_FullMethodName      = "/proto.EmployeeService/BatchAddEmployees"
+	EmployeeService_BatchUpdateEmployees_FullMethodName   = "/proto.EmployeeService/BatchUpdateEmployees"
+	EmployeeService_BatchDeleteEmployees_FullMethodName   = "/proto.EmployeeService/BatchDeleteEmployees"
+	EmployeeService_GetDirectReports_FullMethodName       = "/proto.EmployeeService/GetDirectReports"
+	EmployeeService_GetReportingChain_FullMethodName      = "/proto.EmployeeService/GetReportingChain"
+	EmployeeService_GetOrgSubtree_FullMethodName          = "/proto.EmployeeService/GetOrgSubtree"
+	EmployeeService_ExportOrgChart_FullMethodName         = "/proto.EmployeeService/ExportOrgChart"
+	EmployeeService_HireEmployee_FullMethodName           = "/proto.EmployeeService/HireEmployee"
+	EmployeeService_TerminateEmployee_FullMethodName      = "/proto.EmployeeService/TerminateEmployee"
+	EmployeeService_RehireEmployee_FullMethodName         = "/proto.EmployeeService/RehireEmployee"
+	EmployeeService_SetOnLeave_FullMethodName             = "/proto.EmployeeService/SetOnLeave"
+	EmployeeService_TransferEmployee_FullMethodName       = "/proto.EmployeeService/TransferEmployee"
+	EmployeeService_CancelTransfer_FullMethodName         = "/proto.EmployeeService/CancelTransfer"
+	EmployeeService_ListTransfers_FullMethodName          = "/proto.EmployeeService/ListTransfers"
+	EmployeeService_GetAssignmentHistory_FullMethodName   = "/proto.EmployeeService/GetAssignmentHistory"
+	EmployeeService_AddEmergencyContact_FullMethodName    = "/proto.EmployeeService/AddEmergencyContact"
+	EmployeeService_ListEmergencyContacts_FullMethodName  = "/proto.EmployeeService/ListEmergencyContacts"
+	EmployeeService_UpdateEmergencyContact_FullMethodName = "/proto.EmployeeService/UpdateEmergencyContact"
+	EmployeeService_DeleteEmergencyContact_FullMethodName = "/proto.EmployeeService/DeleteEmergencyContact"
 )
 
 // EmployeeServiceClient is the client API for EmployeeService service.
@@ -33,6 +55,28 @@ type EmployeeServiceClient interface {
 	DeleteEmployee(ctx context.Context, in *DeleteEmployeeRequest, opts ...grpc.CallOption) (*DeleteEmployeeResponse, error)
 	ShowCompanyEmployees(ctx context.Context, in *CompanyEmployeesRequest, opts ...grpc.CallOption) (*EmployeesResponse, error)
 	UpdateEmployee(ctx context.Context, in *UpdateEmployeeRequest, opts ...grpc.CallOption) (*UpdateEmployeeResponse, error)
+	ExportEmployeeData(ctx context.Context, in *ExportEmployeeDataRequest, opts ...grpc.CallOption) (*ExportEmployeeDataResponse, error)
+	EraseEmployee(ctx context.Context, in *EraseEmployeeRequest, opts ...grpc.CallOption) (*EraseEmployeeResponse, error)
+	WatchEmployees(ctx context.Context, in *WatchEmployeesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EmployeeChange], error)
+	BatchAddEmployees(ctx context.Context, in *BatchAddEmployeesRequest, opts ...grpc.CallOption) (*BatchEmployeesResponse, error)
+	BatchUpdateEmployees(ctx context.Context, in *BatchUpdateEmployeesRequest, opts ...grpc.CallOption) (*BatchEmployeesResponse, error)
+	BatchDeleteEmployees(ctx context.Context, in *BatchDeleteEmployeesRequest, opts ...grpc.CallOption) (*BatchEmployeesResponse, error)
+	GetDirectReports(ctx context.Context, in *GetDirectReportsRequest, opts ...grpc.CallOption) (*EmployeesResponse, error)
+	GetReportingChain(ctx context.Context, in *GetReportingChainRequest, opts ...grpc.CallOption) (*EmployeesResponse, error)
+	GetOrgSubtree(ctx context.Context, in *GetOrgSubtreeRequest, opts ...grpc.CallOption) (*OrgSubtreeResponse, error)
+	ExportOrgChart(ctx context.Context, in *ExportOrgChartRequest, opts ...grpc.CallOption) (*ExportOrgChartResponse, error)
+	HireEmployee(ctx context.Context, in *HireEmployeeRequest, opts ...grpc.CallOption) (*EmploymentResponse, error)
+	TerminateEmployee(ctx context.Context, in *TerminateEmployeeRequest, opts ...grpc.CallOption) (*EmploymentResponse, error)
+	RehireEmployee(ctx context.Context, in *RehireEmployeeRequest, opts ...grpc.CallOption) (*EmploymentResponse, error)
+	SetOnLeave(ctx context.Context, in *SetOnLeaveRequest, opts ...grpc.CallOption) (*EmploymentResponse, error)
+	TransferEmployee(ctx context.Context, in *TransferEmployeeRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	CancelTransfer(ctx context.Context, in *CancelTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*ListTransfersResponse, error)
+	GetAssignmentHistory(ctx context.Context, in *GetAssignmentHistoryRequest, opts ...grpc.CallOption) (*AssignmentHistoryResponse, error)
+	AddEmergencyContact(ctx context.Context, in *AddEmergencyContactRequest, opts ...grpc.CallOption) (*EmergencyContactResponse, error)
+	ListEmergencyContacts(ctx context.Context, in *ListEmergencyContactsRequest, opts ...grpc.CallOption) (*ListEmergencyContactsResponse, error)
+	UpdateEmergencyContact(ctx context.Context, in *UpdateEmergencyContactRequest, opts ...grpc.CallOption) (*EmergencyContactResponse, error)
+	DeleteEmergencyContact(ctx context.Context, in *DeleteEmergencyContactRequest, opts ...grpc.CallOption) (*DeleteEmergencyContactResponse, error)
 }
 
 type employeeServiceClient struct {
@@ -83,6 +127,235 @@ func (c *employeeServiceClient) UpdateEmployee(ctx context.Context, in *UpdateEm
 	return out, nil
 }
 
+func (c *employeeServiceClient) ExportEmployeeData(ctx context.Context, in *ExportEmployeeDataRequest, opts ...grpc.CallOption) (*ExportEmployeeDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportEmployeeDataResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_ExportEmployeeData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) EraseEmployee(ctx context.Context, in *EraseEmployeeRequest, opts ...grpc.CallOption) (*EraseEmployeeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EraseEmployeeResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_EraseEmployee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) WatchEmployees(ctx context.Context, in *WatchEmployeesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EmployeeChange], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EmployeeService_ServiceDesc.Streams[0], EmployeeService_WatchEmployees_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchEmployeesRequest, EmployeeChange]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EmployeeService_WatchEmployeesClient = grpc.ServerStreamingClient[EmployeeChange]
+
+func (c *employeeServiceClient) BatchAddEmployees(ctx context.Context, in *BatchAddEmployeesRequest, opts ...grpc.CallOption) (*BatchEmployeesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchEmployeesResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_BatchAddEmployees_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) BatchUpdateEmployees(ctx context.Context, in *BatchUpdateEmployeesRequest, opts ...grpc.CallOption) (*BatchEmployeesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchEmployeesResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_BatchUpdateEmployees_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) BatchDeleteEmployees(ctx context.Context, in *BatchDeleteEmployeesRequest, opts ...grpc.CallOption) (*BatchEmployeesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchEmployeesResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_BatchDeleteEmployees_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) GetDirectReports(ctx context.Context, in *GetDirectReportsRequest, opts ...grpc.CallOption) (*EmployeesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmployeesResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_GetDirectReports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) GetReportingChain(ctx context.Context, in *GetReportingChainRequest, opts ...grpc.CallOption) (*EmployeesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmployeesResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_GetReportingChain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) GetOrgSubtree(ctx context.Context, in *GetOrgSubtreeRequest, opts ...grpc.CallOption) (*OrgSubtreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrgSubtreeResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_GetOrgSubtree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) ExportOrgChart(ctx context.Context, in *ExportOrgChartRequest, opts ...grpc.CallOption) (*ExportOrgChartResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportOrgChartResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_ExportOrgChart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) HireEmployee(ctx context.Context, in *HireEmployeeRequest, opts ...grpc.CallOption) (*EmploymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmploymentResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_HireEmployee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) TerminateEmployee(ctx context.Context, in *TerminateEmployeeRequest, opts ...grpc.CallOption) (*EmploymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmploymentResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_TerminateEmployee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) RehireEmployee(ctx context.Context, in *RehireEmployeeRequest, opts ...grpc.CallOption) (*EmploymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmploymentResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_RehireEmployee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) SetOnLeave(ctx context.Context, in *SetOnLeaveRequest, opts ...grpc.CallOption) (*EmploymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmploymentResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_SetOnLeave_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) TransferEmployee(ctx context.Context, in *TransferEmployeeRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_TransferEmployee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) CancelTransfer(ctx context.Context, in *CancelTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_CancelTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*ListTransfersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTransfersResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_ListTransfers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) GetAssignmentHistory(ctx context.Context, in *GetAssignmentHistoryRequest, opts ...grpc.CallOption) (*AssignmentHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssignmentHistoryResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_GetAssignmentHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) AddEmergencyContact(ctx context.Context, in *AddEmergencyContactRequest, opts ...grpc.CallOption) (*EmergencyContactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmergencyContactResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_AddEmergencyContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) ListEmergencyContacts(ctx context.Context, in *ListEmergencyContactsRequest, opts ...grpc.CallOption) (*ListEmergencyContactsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEmergencyContactsResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_ListEmergencyContacts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) UpdateEmergencyContact(ctx context.Context, in *UpdateEmergencyContactRequest, opts ...grpc.CallOption) (*EmergencyContactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmergencyContactResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_UpdateEmergencyContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) DeleteEmergencyContact(ctx context.Context, in *DeleteEmergencyContactRequest, opts ...grpc.CallOption) (*DeleteEmergencyContactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteEmergencyContactResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_DeleteEmergencyContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EmployeeServiceServer is the server API for EmployeeService service.
 // All implementations must embed UnimplementedEmployeeServiceServer
 // for forward compatibility.
@@ -91,6 +364,28 @@ type EmployeeServiceServer interface {
 	DeleteEmployee(context.Context, *DeleteEmployeeRequest) (*DeleteEmployeeResponse, error)
 	ShowCompanyEmployees(context.Context, *CompanyEmployeesRequest) (*EmployeesResponse, error)
 	UpdateEmployee(context.Context, *UpdateEmployeeRequest) (*UpdateEmployeeResponse, error)
+	ExportEmployeeData(context.Context, *ExportEmployeeDataRequest) (*ExportEmployeeDataResponse, error)
+	EraseEmployee(context.Context, *EraseEmployeeRequest) (*EraseEmployeeResponse, error)
+	WatchEmployees(*WatchEmployeesRequest, grpc.ServerStreamingServer[EmployeeChange]) error
+	BatchAddEmployees(context.Context, *BatchAddEmployeesRequest) (*BatchEmployeesResponse, error)
+	BatchUpdateEmployees(context.Context, *BatchUpdateEmployeesRequest) (*BatchEmployeesResponse, error)
+	BatchDeleteEmployees(context.Context, *BatchDeleteEmployeesRequest) (*BatchEmployeesResponse, error)
+	GetDirectReports(context.Context, *GetDirectReportsRequest) (*EmployeesResponse, error)
+	GetReportingChain(context.Context, *GetReportingChainRequest) (*EmployeesResponse, error)
+	GetOrgSubtree(context.Context, *GetOrgSubtreeRequest) (*OrgSubtreeResponse, error)
+	ExportOrgChart(context.Context, *ExportOrgChartRequest) (*ExportOrgChartResponse, error)
+	HireEmployee(context.Context, *HireEmployeeRequest) (*EmploymentResponse, error)
+	TerminateEmployee(context.Context, *TerminateEmployeeRequest) (*EmploymentResponse, error)
+	RehireEmployee(context.Context, *RehireEmployeeRequest) (*EmploymentResponse, error)
+	SetOnLeave(context.Context, *SetOnLeaveRequest) (*EmploymentResponse, error)
+	TransferEmployee(context.Context, *TransferEmployeeRequest) (*TransferResponse, error)
+	CancelTransfer(context.Context, *CancelTransferRequest) (*TransferResponse, error)
+	ListTransfers(context.Context, *ListTransfersRequest) (*ListTransfersResponse, error)
+	GetAssignmentHistory(context.Context, *GetAssignmentHistoryRequest) (*AssignmentHistoryResponse, error)
+	AddEmergencyContact(context.Context, *AddEmergencyContactRequest) (*EmergencyContactResponse, error)
+	ListEmergencyContacts(context.Context, *ListEmergencyContactsRequest) (*ListEmergencyContactsResponse, error)
+	UpdateEmergencyContact(context.Context, *UpdateEmergencyContactRequest) (*EmergencyContactResponse, error)
+	DeleteEmergencyContact(context.Context, *DeleteEmergencyContactRequest) (*DeleteEmergencyContactResponse, error)
 	mustEmbedUnimplementedEmployeeServiceServer()
 }
 
@@ -113,6 +408,72 @@ func (UnimplementedEmployeeServiceServer) ShowCompanyEmployees(context.Context,
 func (UnimplementedEmployeeServiceServer) UpdateEmployee(context.Context, *UpdateEmployeeRequest) (*UpdateEmployeeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateEmployee not implemented")
 }
+func (UnimplementedEmployeeServiceServer) ExportEmployeeData(context.Context, *ExportEmployeeDataRequest) (*ExportEmployeeDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportEmployeeData not implemented")
+}
+func (UnimplementedEmployeeServiceServer) EraseEmployee(context.Context, *EraseEmployeeRequest) (*EraseEmployeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseEmployee not implemented")
+}
+func (UnimplementedEmployeeServiceServer) WatchEmployees(*WatchEmployeesRequest, grpc.ServerStreamingServer[EmployeeChange]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEmployees not implemented")
+}
+func (UnimplementedEmployeeServiceServer) BatchAddEmployees(context.Context, *BatchAddEmployeesRequest) (*BatchEmployeesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchAddEmployees not implemented")
+}
+func (UnimplementedEmployeeServiceServer) BatchUpdateEmployees(context.Context, *BatchUpdateEmployeesRequest) (*BatchEmployeesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateEmployees not implemented")
+}
+func (UnimplementedEmployeeServiceServer) BatchDeleteEmployees(context.Context, *BatchDeleteEmployeesRequest) (*BatchEmployeesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteEmployees not implemented")
+}
+func (UnimplementedEmployeeServiceServer) GetDirectReports(context.Context, *GetDirectReportsRequest) (*EmployeesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDirectReports not implemented")
+}
+func (UnimplementedEmployeeServiceServer) GetReportingChain(context.Context, *GetReportingChainRequest) (*EmployeesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReportingChain not implemented")
+}
+func (UnimplementedEmployeeServiceServer) GetOrgSubtree(context.Context, *GetOrgSubtreeRequest) (*OrgSubtreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrgSubtree not implemented")
+}
+func (UnimplementedEmployeeServiceServer) ExportOrgChart(context.Context, *ExportOrgChartRequest) (*ExportOrgChartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportOrgChart not implemented")
+}
+func (UnimplementedEmployeeServiceServer) HireEmployee(context.Context, *HireEmployeeRequest) (*EmploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HireEmployee not implemented")
+}
+func (UnimplementedEmployeeServiceServer) TerminateEmployee(context.Context, *TerminateEmployeeRequest) (*EmploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TerminateEmployee not implemented")
+}
+func (UnimplementedEmployeeServiceServer) RehireEmployee(context.Context, *RehireEmployeeRequest) (*EmploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RehireEmployee not implemented")
+}
+func (UnimplementedEmployeeServiceServer) SetOnLeave(context.Context, *SetOnLeaveRequest) (*EmploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOnLeave not implemented")
+}
+func (UnimplementedEmployeeServiceServer) TransferEmployee(context.Context, *TransferEmployeeRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferEmployee not implemented")
+}
+func (UnimplementedEmployeeServiceServer) CancelTransfer(context.Context, *CancelTransferRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTransfer not implemented")
+}
+func (UnimplementedEmployeeServiceServer) ListTransfers(context.Context, *ListTransfersRequest) (*ListTransfersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransfers not implemented")
+}
+func (UnimplementedEmployeeServiceServer) GetAssignmentHistory(context.Context, *GetAssignmentHistoryRequest) (*AssignmentHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAssignmentHistory not implemented")
+}
+func (UnimplementedEmployeeServiceServer) AddEmergencyContact(context.Context, *AddEmergencyContactRequest) (*EmergencyContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddEmergencyContact not implemented")
+}
+func (UnimplementedEmployeeServiceServer) ListEmergencyContacts(context.Context, *ListEmergencyContactsRequest) (*ListEmergencyContactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEmergencyContacts not implemented")
+}
+func (UnimplementedEmployeeServiceServer) UpdateEmergencyContact(context.Context, *UpdateEmergencyContactRequest) (*EmergencyContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEmergencyContact not implemented")
+}
+func (UnimplementedEmployeeServiceServer) DeleteEmergencyContact(context.Context, *DeleteEmergencyContactRequest) (*DeleteEmergencyContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEmergencyContact not implemented")
+}
 func (UnimplementedEmployeeServiceServer) mustEmbedUnimplementedEmployeeServiceServer() {}
 func (UnimplementedEmployeeServiceServer) testEmbeddedByValue()                         {}
 
@@ -206,28 +567,1853 @@ func _EmployeeService_UpdateEmployee_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
-// EmployeeService_ServiceDesc is the grpc.ServiceDesc for EmployeeService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var EmployeeService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "proto.EmployeeService",
-	HandlerType: (*EmployeeServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "AddEmployee",
-			Handler:    _EmployeeService_AddEmployee_Handler,
-		},
-		{
-			MethodName: "DeleteEmployee",
-			Handler:    _EmployeeService_DeleteEmployee_Handler,
-		},
-		{
-			MethodName: "ShowCompanyEmployees",
-			Handler:    _EmployeeService_ShowCompanyEmployees_Handler,
-		},
-		{
-			MethodName: "UpdateEmployee",
-			Handler:    _EmployeeService_UpdateEmployee_Handler,
+func _EmployeeService_ExportEmployeeData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportEmployeeDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).ExportEmployeeData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_ExportEmployeeData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).ExportEmployeeData(ctx, req.(*ExportEmployeeDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_EraseEmployee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseEmployeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).EraseEmployee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_EraseEmployee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).EraseEmployee(ctx, req.(*EraseEmployeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_WatchEmployees_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEmployeesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EmployeeServiceServer).WatchEmployees(m, &grpc.GenericServerStream[WatchEmployeesRequest, EmployeeChange]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EmployeeService_WatchEmployeesServer = grpc.ServerStreamingServer[EmployeeChange]
+
+func _EmployeeService_BatchAddEmployees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchAddEmployeesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).BatchAddEmployees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_BatchAddEmployees_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).BatchAddEmployees(ctx, req.(*BatchAddEmployeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_BatchUpdateEmployees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateEmployeesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).BatchUpdateEmployees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_BatchUpdateEmployees_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).BatchUpdateEmployees(ctx, req.(*BatchUpdateEmployeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_BatchDeleteEmployees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteEmployeesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).BatchDeleteEmployees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_BatchDeleteEmployees_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).BatchDeleteEmployees(ctx, req.(*BatchDeleteEmployeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_GetDirectReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDirectReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).GetDirectReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_GetDirectReports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).GetDirectReports(ctx, req.(*GetDirectReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_GetReportingChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReportingChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).GetReportingChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_GetReportingChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).GetReportingChain(ctx, req.(*GetReportingChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_GetOrgSubtree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrgSubtreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).GetOrgSubtree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_GetOrgSubtree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).GetOrgSubtree(ctx, req.(*GetOrgSubtreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_ExportOrgChart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportOrgChartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).ExportOrgChart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_ExportOrgChart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).ExportOrgChart(ctx, req.(*ExportOrgChartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_HireEmployee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HireEmployeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).HireEmployee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_HireEmployee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).HireEmployee(ctx, req.(*HireEmployeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_TerminateEmployee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TerminateEmployeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).TerminateEmployee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_TerminateEmployee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).TerminateEmployee(ctx, req.(*TerminateEmployeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_RehireEmployee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RehireEmployeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).RehireEmployee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_RehireEmployee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).RehireEmployee(ctx, req.(*RehireEmployeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_SetOnLeave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOnLeaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).SetOnLeave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_SetOnLeave_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).SetOnLeave(ctx, req.(*SetOnLeaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_TransferEmployee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferEmployeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).TransferEmployee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_TransferEmployee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).TransferEmployee(ctx, req.(*TransferEmployeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_CancelTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).CancelTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_CancelTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).CancelTransfer(ctx, req.(*CancelTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_ListTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).ListTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_ListTransfers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).ListTransfers(ctx, req.(*ListTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_GetAssignmentHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAssignmentHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).GetAssignmentHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_GetAssignmentHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).GetAssignmentHistory(ctx, req.(*GetAssignmentHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_AddEmergencyContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddEmergencyContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).AddEmergencyContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_AddEmergencyContact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).AddEmergencyContact(ctx, req.(*AddEmergencyContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_ListEmergencyContacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEmergencyContactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).ListEmergencyContacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_ListEmergencyContacts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).ListEmergencyContacts(ctx, req.(*ListEmergencyContactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_UpdateEmergencyContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEmergencyContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).UpdateEmergencyContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_UpdateEmergencyContact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).UpdateEmergencyContact(ctx, req.(*UpdateEmergencyContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_DeleteEmergencyContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEmergencyContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).DeleteEmergencyContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_DeleteEmergencyContact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).DeleteEmergencyContact(ctx, req.(*DeleteEmergencyContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EmployeeService_ServiceDesc is the grpc.ServiceDesc for EmployeeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EmployeeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.EmployeeService",
+	HandlerType: (*EmployeeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddEmployee",
+			Handler:    _EmployeeService_AddEmployee_Handler,
+		},
+		{
+			MethodName: "DeleteEmployee",
+			Handler:    _EmployeeService_DeleteEmployee_Handler,
+		},
+		{
+			MethodName: "ShowCompanyEmployees",
+			Handler:    _EmployeeService_ShowCompanyEmployees_Handler,
+		},
+		{
+			MethodName: "UpdateEmployee",
+			Handler:    _EmployeeService_UpdateEmployee_Handler,
+		},
+		{
+			MethodName: "ExportEmployeeData",
+			Handler:    _EmployeeService_ExportEmployeeData_Handler,
+		},
+		{
+			MethodName: "EraseEmployee",
+			Handler:    _EmployeeService_EraseEmployee_Handler,
+		},
+		{
+			MethodName: "BatchAddEmployees",
+			Handler:    _EmployeeService_BatchAddEmployees_Handler,
+		},
+		{
+			MethodName: "BatchUpdateEmployees",
+			Handler:    _EmployeeService_BatchUpdateEmployees_Handler,
+		},
+		{
+			MethodName: "BatchDeleteEmployees",
+			Handler:    _EmployeeService_BatchDeleteEmployees_Handler,
+		},
+		{
+			MethodName: "GetDirectReports",
+			Handler:    _EmployeeService_GetDirectReports_Handler,
+		},
+		{
+			MethodName: "GetReportingChain",
+			Handler:    _EmployeeService_GetReportingChain_Handler,
+		},
+		{
+			MethodName: "GetOrgSubtree",
+			Handler:    _EmployeeService_GetOrgSubtree_Handler,
+		},
+		{
+			MethodName: "ExportOrgChart",
+			Handler:    _EmployeeService_ExportOrgChart_Handler,
+		},
+		{
+			MethodName: "HireEmployee",
+			Handler:    _EmployeeService_HireEmployee_Handler,
+		},
+		{
+			MethodName: "TerminateEmployee",
+			Handler:    _EmployeeService_TerminateEmployee_Handler,
+		},
+		{
+			MethodName: "RehireEmployee",
+			Handler:    _EmployeeService_RehireEmployee_Handler,
+		},
+		{
+			MethodName: "SetOnLeave",
+			Handler:    _EmployeeService_SetOnLeave_Handler,
+		},
+		{
+			MethodName: "TransferEmployee",
+			Handler:    _EmployeeService_TransferEmployee_Handler,
+		},
+		{
+			MethodName: "CancelTransfer",
+			Handler:    _EmployeeService_CancelTransfer_Handler,
+		},
+		{
+			MethodName: "ListTransfers",
+			Handler:    _EmployeeService_ListTransfers_Handler,
+		},
+		{
+			MethodName: "GetAssignmentHistory",
+			Handler:    _EmployeeService_GetAssignmentHistory_Handler,
+		},
+		{
+			MethodName: "AddEmergencyContact",
+			Handler:    _EmployeeService_AddEmergencyContact_Handler,
+		},
+		{
+			MethodName: "ListEmergencyContacts",
+			Handler:    _EmployeeService_ListEmergencyContacts_Handler,
+		},
+		{
+			MethodName: "UpdateEmergencyContact",
+			Handler:    _EmployeeService_UpdateEmergencyContact_Handler,
+		},
+		{
+			MethodName: "DeleteEmergencyContact",
+			Handler:    _EmployeeService_DeleteEmergencyContact_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEmployees",
+			Handler:       _EmployeeService_WatchEmployees_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/employee.proto",
+}
+
+const (
+	WebhookService_CreateWebhook_FullMethodName         = "/proto.WebhookService/CreateWebhook"
+	WebhookService_ListWebhooks_FullMethodName          = "/proto.WebhookService/ListWebhooks"
+	WebhookService_DeleteWebhook_FullMethodName         = "/proto.WebhookService/DeleteWebhook"
+	WebhookService_ListWebhookDeliveries_FullMethodName = "/proto.WebhookService/ListWebhookDeliveries"
+)
+
+// WebhookServiceClient is the client API for WebhookService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WebhookServiceClient interface {
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+}
+
+type webhookServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebhookServiceClient(cc grpc.ClientConnInterface) WebhookServiceClient {
+	return &webhookServiceClient{cc}
+}
+
+func (c *webhookServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateWebhookResponse)
+	err := c.cc.Invoke(ctx, WebhookService_CreateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, WebhookService_ListWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, WebhookService_DeleteWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, WebhookService_ListWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WebhookServiceServer is the server API for WebhookService service.
+// All implementations must embed UnimplementedWebhookServiceServer
+// for forward compatibility.
+type WebhookServiceServer interface {
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	mustEmbedUnimplementedWebhookServiceServer()
+}
+
+// UnimplementedWebhookServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWebhookServiceServer struct{}
+
+func (UnimplementedWebhookServiceServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedWebhookServiceServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedWebhookServiceServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedWebhookServiceServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedWebhookServiceServer) mustEmbedUnimplementedWebhookServiceServer() {}
+func (UnimplementedWebhookServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeWebhookServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WebhookServiceServer will
+// result in compilation errors.
+type UnsafeWebhookServiceServer interface {
+	mustEmbedUnimplementedWebhookServiceServer()
+}
+
+func RegisterWebhookServiceServer(s grpc.ServiceRegistrar, srv WebhookServiceServer) {
+	// If the following call pancis, it indicates UnimplementedWebhookServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WebhookService_ServiceDesc, srv)
+}
+
+func _WebhookService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WebhookService_ServiceDesc is the grpc.ServiceDesc for WebhookService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WebhookService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.WebhookService",
+	HandlerType: (*WebhookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _WebhookService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _WebhookService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _WebhookService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _WebhookService_ListWebhookDeliveries_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/employee.proto",
+}
+
+const (
+	PositionService_CreatePosition_FullMethodName   = "/proto.PositionService/CreatePosition"
+	PositionService_ListPositions_FullMethodName    = "/proto.PositionService/ListPositions"
+	PositionService_UpdatePosition_FullMethodName   = "/proto.PositionService/UpdatePosition"
+	PositionService_DeletePosition_FullMethodName   = "/proto.PositionService/DeletePosition"
+	PositionService_GetVacancyReport_FullMethodName = "/proto.PositionService/GetVacancyReport"
+)
+
+// PositionServiceClient is the client API for PositionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PositionServiceClient interface {
+	CreatePosition(ctx context.Context, in *CreatePositionRequest, opts ...grpc.CallOption) (*PositionResponse, error)
+	ListPositions(ctx context.Context, in *ListPositionsRequest, opts ...grpc.CallOption) (*ListPositionsResponse, error)
+	UpdatePosition(ctx context.Context, in *UpdatePositionRequest, opts ...grpc.CallOption) (*PositionResponse, error)
+	DeletePosition(ctx context.Context, in *DeletePositionRequest, opts ...grpc.CallOption) (*DeletePositionResponse, error)
+	GetVacancyReport(ctx context.Context, in *VacancyReportRequest, opts ...grpc.CallOption) (*VacancyReportResponse, error)
+}
+
+type positionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPositionServiceClient(cc grpc.ClientConnInterface) PositionServiceClient {
+	return &positionServiceClient{cc}
+}
+
+func (c *positionServiceClient) CreatePosition(ctx context.Context, in *CreatePositionRequest, opts ...grpc.CallOption) (*PositionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PositionResponse)
+	err := c.cc.Invoke(ctx, PositionService_CreatePosition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *positionServiceClient) ListPositions(ctx context.Context, in *ListPositionsRequest, opts ...grpc.CallOption) (*ListPositionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPositionsResponse)
+	err := c.cc.Invoke(ctx, PositionService_ListPositions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *positionServiceClient) UpdatePosition(ctx context.Context, in *UpdatePositionRequest, opts ...grpc.CallOption) (*PositionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PositionResponse)
+	err := c.cc.Invoke(ctx, PositionService_UpdatePosition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *positionServiceClient) DeletePosition(ctx context.Context, in *DeletePositionRequest, opts ...grpc.CallOption) (*DeletePositionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletePositionResponse)
+	err := c.cc.Invoke(ctx, PositionService_DeletePosition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *positionServiceClient) GetVacancyReport(ctx context.Context, in *VacancyReportRequest, opts ...grpc.CallOption) (*VacancyReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VacancyReportResponse)
+	err := c.cc.Invoke(ctx, PositionService_GetVacancyReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PositionServiceServer is the server API for PositionService service.
+// All implementations must embed UnimplementedPositionServiceServer
+// for forward compatibility.
+type PositionServiceServer interface {
+	CreatePosition(context.Context, *CreatePositionRequest) (*PositionResponse, error)
+	ListPositions(context.Context, *ListPositionsRequest) (*ListPositionsResponse, error)
+	UpdatePosition(context.Context, *UpdatePositionRequest) (*PositionResponse, error)
+	DeletePosition(context.Context, *DeletePositionRequest) (*DeletePositionResponse, error)
+	GetVacancyReport(context.Context, *VacancyReportRequest) (*VacancyReportResponse, error)
+	mustEmbedUnimplementedPositionServiceServer()
+}
+
+// UnimplementedPositionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPositionServiceServer struct{}
+
+func (UnimplementedPositionServiceServer) CreatePosition(context.Context, *CreatePositionRequest) (*PositionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePosition not implemented")
+}
+func (UnimplementedPositionServiceServer) ListPositions(context.Context, *ListPositionsRequest) (*ListPositionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPositions not implemented")
+}
+func (UnimplementedPositionServiceServer) UpdatePosition(context.Context, *UpdatePositionRequest) (*PositionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePosition not implemented")
+}
+func (UnimplementedPositionServiceServer) DeletePosition(context.Context, *DeletePositionRequest) (*DeletePositionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePosition not implemented")
+}
+func (UnimplementedPositionServiceServer) GetVacancyReport(context.Context, *VacancyReportRequest) (*VacancyReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVacancyReport not implemented")
+}
+func (UnimplementedPositionServiceServer) mustEmbedUnimplementedPositionServiceServer() {}
+func (UnimplementedPositionServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafePositionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PositionServiceServer will
+// result in compilation errors.
+type UnsafePositionServiceServer interface {
+	mustEmbedUnimplementedPositionServiceServer()
+}
+
+func RegisterPositionServiceServer(s grpc.ServiceRegistrar, srv PositionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPositionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PositionService_ServiceDesc, srv)
+}
+
+func _PositionService_CreatePosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePositionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PositionServiceServer).CreatePosition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PositionService_CreatePosition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PositionServiceServer).CreatePosition(ctx, req.(*CreatePositionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PositionService_ListPositions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPositionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PositionServiceServer).ListPositions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PositionService_ListPositions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PositionServiceServer).ListPositions(ctx, req.(*ListPositionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PositionService_UpdatePosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePositionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PositionServiceServer).UpdatePosition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PositionService_UpdatePosition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PositionServiceServer).UpdatePosition(ctx, req.(*UpdatePositionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PositionService_DeletePosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePositionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PositionServiceServer).DeletePosition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PositionService_DeletePosition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PositionServiceServer).DeletePosition(ctx, req.(*DeletePositionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PositionService_GetVacancyReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VacancyReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PositionServiceServer).GetVacancyReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PositionService_GetVacancyReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PositionServiceServer).GetVacancyReport(ctx, req.(*VacancyReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PositionService_ServiceDesc is the grpc.ServiceDesc for PositionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PositionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.PositionService",
+	HandlerType: (*PositionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreatePosition",
+			Handler:    _PositionService_CreatePosition_Handler,
+		},
+		{
+			MethodName: "ListPositions",
+			Handler:    _PositionService_ListPositions_Handler,
+		},
+		{
+			MethodName: "UpdatePosition",
+			Handler:    _PositionService_UpdatePosition_Handler,
+		},
+		{
+			MethodName: "DeletePosition",
+			Handler:    _PositionService_DeletePosition_Handler,
+		},
+		{
+			MethodName: "GetVacancyReport",
+			Handler:    _PositionService_GetVacancyReport_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/employee.proto",
+}
+
+const (
+	LeaveService_CreateLeaveType_FullMethodName    = "/proto.LeaveService/CreateLeaveType"
+	LeaveService_ListLeaveTypes_FullMethodName     = "/proto.LeaveService/ListLeaveTypes"
+	LeaveService_UpdateLeaveType_FullMethodName    = "/proto.LeaveService/UpdateLeaveType"
+	LeaveService_GetLeaveBalances_FullMethodName   = "/proto.LeaveService/GetLeaveBalances"
+	LeaveService_AdjustLeaveBalance_FullMethodName = "/proto.LeaveService/AdjustLeaveBalance"
+	LeaveService_SubmitLeave_FullMethodName        = "/proto.LeaveService/SubmitLeave"
+	LeaveService_DecideLeave_FullMethodName        = "/proto.LeaveService/DecideLeave"
+	LeaveService_CancelLeave_FullMethodName        = "/proto.LeaveService/CancelLeave"
+	LeaveService_ListLeaveRequests_FullMethodName  = "/proto.LeaveService/ListLeaveRequests"
+	LeaveService_ListAbsences_FullMethodName       = "/proto.LeaveService/ListAbsences"
+)
+
+// LeaveServiceClient is the client API for LeaveService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LeaveServiceClient interface {
+	CreateLeaveType(ctx context.Context, in *CreateLeaveTypeRequest, opts ...grpc.CallOption) (*LeaveTypeResponse, error)
+	ListLeaveTypes(ctx context.Context, in *ListLeaveTypesRequest, opts ...grpc.CallOption) (*ListLeaveTypesResponse, error)
+	UpdateLeaveType(ctx context.Context, in *UpdateLeaveTypeRequest, opts ...grpc.CallOption) (*LeaveTypeResponse, error)
+	GetLeaveBalances(ctx context.Context, in *GetLeaveBalancesRequest, opts ...grpc.CallOption) (*LeaveBalancesResponse, error)
+	AdjustLeaveBalance(ctx context.Context, in *AdjustLeaveBalanceRequest, opts ...grpc.CallOption) (*LeaveBalanceResponse, error)
+	SubmitLeave(ctx context.Context, in *SubmitLeaveRequest, opts ...grpc.CallOption) (*LeaveRequestResponse, error)
+	DecideLeave(ctx context.Context, in *DecideLeaveRequest, opts ...grpc.CallOption) (*LeaveRequestResponse, error)
+	CancelLeave(ctx context.Context, in *CancelLeaveRequest, opts ...grpc.CallOption) (*LeaveRequestResponse, error)
+	ListLeaveRequests(ctx context.Context, in *ListLeaveRequestsRequest, opts ...grpc.CallOption) (*ListLeaveRequestsResponse, error)
+	ListAbsences(ctx context.Context, in *ListAbsencesRequest, opts ...grpc.CallOption) (*ListAbsencesResponse, error)
+}
+
+type leaveServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLeaveServiceClient(cc grpc.ClientConnInterface) LeaveServiceClient {
+	return &leaveServiceClient{cc}
+}
+
+func (c *leaveServiceClient) CreateLeaveType(ctx context.Context, in *CreateLeaveTypeRequest, opts ...grpc.CallOption) (*LeaveTypeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaveTypeResponse)
+	err := c.cc.Invoke(ctx, LeaveService_CreateLeaveType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaveServiceClient) ListLeaveTypes(ctx context.Context, in *ListLeaveTypesRequest, opts ...grpc.CallOption) (*ListLeaveTypesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLeaveTypesResponse)
+	err := c.cc.Invoke(ctx, LeaveService_ListLeaveTypes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaveServiceClient) UpdateLeaveType(ctx context.Context, in *UpdateLeaveTypeRequest, opts ...grpc.CallOption) (*LeaveTypeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaveTypeResponse)
+	err := c.cc.Invoke(ctx, LeaveService_UpdateLeaveType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaveServiceClient) GetLeaveBalances(ctx context.Context, in *GetLeaveBalancesRequest, opts ...grpc.CallOption) (*LeaveBalancesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaveBalancesResponse)
+	err := c.cc.Invoke(ctx, LeaveService_GetLeaveBalances_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaveServiceClient) AdjustLeaveBalance(ctx context.Context, in *AdjustLeaveBalanceRequest, opts ...grpc.CallOption) (*LeaveBalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaveBalanceResponse)
+	err := c.cc.Invoke(ctx, LeaveService_AdjustLeaveBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaveServiceClient) SubmitLeave(ctx context.Context, in *SubmitLeaveRequest, opts ...grpc.CallOption) (*LeaveRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaveRequestResponse)
+	err := c.cc.Invoke(ctx, LeaveService_SubmitLeave_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaveServiceClient) DecideLeave(ctx context.Context, in *DecideLeaveRequest, opts ...grpc.CallOption) (*LeaveRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaveRequestResponse)
+	err := c.cc.Invoke(ctx, LeaveService_DecideLeave_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaveServiceClient) CancelLeave(ctx context.Context, in *CancelLeaveRequest, opts ...grpc.CallOption) (*LeaveRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaveRequestResponse)
+	err := c.cc.Invoke(ctx, LeaveService_CancelLeave_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaveServiceClient) ListLeaveRequests(ctx context.Context, in *ListLeaveRequestsRequest, opts ...grpc.CallOption) (*ListLeaveRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLeaveRequestsResponse)
+	err := c.cc.Invoke(ctx, LeaveService_ListLeaveRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaveServiceClient) ListAbsences(ctx context.Context, in *ListAbsencesRequest, opts ...grpc.CallOption) (*ListAbsencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAbsencesResponse)
+	err := c.cc.Invoke(ctx, LeaveService_ListAbsences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LeaveServiceServer is the server API for LeaveService service.
+// All implementations must embed UnimplementedLeaveServiceServer
+// for forward compatibility.
+type LeaveServiceServer interface {
+	CreateLeaveType(context.Context, *CreateLeaveTypeRequest) (*LeaveTypeResponse, error)
+	ListLeaveTypes(context.Context, *ListLeaveTypesRequest) (*ListLeaveTypesResponse, error)
+	UpdateLeaveType(context.Context, *UpdateLeaveTypeRequest) (*LeaveTypeResponse, error)
+	GetLeaveBalances(context.Context, *GetLeaveBalancesRequest) (*LeaveBalancesResponse, error)
+	AdjustLeaveBalance(context.Context, *AdjustLeaveBalanceRequest) (*LeaveBalanceResponse, error)
+	SubmitLeave(context.Context, *SubmitLeaveRequest) (*LeaveRequestResponse, error)
+	DecideLeave(context.Context, *DecideLeaveRequest) (*LeaveRequestResponse, error)
+	CancelLeave(context.Context, *CancelLeaveRequest) (*LeaveRequestResponse, error)
+	ListLeaveRequests(context.Context, *ListLeaveRequestsRequest) (*ListLeaveRequestsResponse, error)
+	ListAbsences(context.Context, *ListAbsencesRequest) (*ListAbsencesResponse, error)
+	mustEmbedUnimplementedLeaveServiceServer()
+}
+
+// UnimplementedLeaveServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLeaveServiceServer struct{}
+
+func (UnimplementedLeaveServiceServer) CreateLeaveType(context.Context, *CreateLeaveTypeRequest) (*LeaveTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLeaveType not implemented")
+}
+func (UnimplementedLeaveServiceServer) ListLeaveTypes(context.Context, *ListLeaveTypesRequest) (*ListLeaveTypesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLeaveTypes not implemented")
+}
+func (UnimplementedLeaveServiceServer) UpdateLeaveType(context.Context, *UpdateLeaveTypeRequest) (*LeaveTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLeaveType not implemented")
+}
+func (UnimplementedLeaveServiceServer) GetLeaveBalances(context.Context, *GetLeaveBalancesRequest) (*LeaveBalancesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaveBalances not implemented")
+}
+func (UnimplementedLeaveServiceServer) AdjustLeaveBalance(context.Context, *AdjustLeaveBalanceRequest) (*LeaveBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdjustLeaveBalance not implemented")
+}
+func (UnimplementedLeaveServiceServer) SubmitLeave(context.Context, *SubmitLeaveRequest) (*LeaveRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitLeave not implemented")
+}
+func (UnimplementedLeaveServiceServer) DecideLeave(context.Context, *DecideLeaveRequest) (*LeaveRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecideLeave not implemented")
+}
+func (UnimplementedLeaveServiceServer) CancelLeave(context.Context, *CancelLeaveRequest) (*LeaveRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelLeave not implemented")
+}
+func (UnimplementedLeaveServiceServer) ListLeaveRequests(context.Context, *ListLeaveRequestsRequest) (*ListLeaveRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLeaveRequests not implemented")
+}
+func (UnimplementedLeaveServiceServer) ListAbsences(context.Context, *ListAbsencesRequest) (*ListAbsencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAbsences not implemented")
+}
+func (UnimplementedLeaveServiceServer) mustEmbedUnimplementedLeaveServiceServer() {}
+func (UnimplementedLeaveServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeLeaveServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LeaveServiceServer will
+// result in compilation errors.
+type UnsafeLeaveServiceServer interface {
+	mustEmbedUnimplementedLeaveServiceServer()
+}
+
+func RegisterLeaveServiceServer(s grpc.ServiceRegistrar, srv LeaveServiceServer) {
+	// If the following call pancis, it indicates UnimplementedLeaveServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LeaveService_ServiceDesc, srv)
+}
+
+func _LeaveService_CreateLeaveType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLeaveTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaveServiceServer).CreateLeaveType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaveService_CreateLeaveType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaveServiceServer).CreateLeaveType(ctx, req.(*CreateLeaveTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaveService_ListLeaveTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLeaveTypesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaveServiceServer).ListLeaveTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaveService_ListLeaveTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaveServiceServer).ListLeaveTypes(ctx, req.(*ListLeaveTypesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaveService_UpdateLeaveType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLeaveTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaveServiceServer).UpdateLeaveType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaveService_UpdateLeaveType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaveServiceServer).UpdateLeaveType(ctx, req.(*UpdateLeaveTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaveService_GetLeaveBalances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaveBalancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaveServiceServer).GetLeaveBalances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaveService_GetLeaveBalances_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaveServiceServer).GetLeaveBalances(ctx, req.(*GetLeaveBalancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaveService_AdjustLeaveBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustLeaveBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaveServiceServer).AdjustLeaveBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaveService_AdjustLeaveBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaveServiceServer).AdjustLeaveBalance(ctx, req.(*AdjustLeaveBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaveService_SubmitLeave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitLeaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaveServiceServer).SubmitLeave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaveService_SubmitLeave_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaveServiceServer).SubmitLeave(ctx, req.(*SubmitLeaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaveService_DecideLeave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideLeaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaveServiceServer).DecideLeave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaveService_DecideLeave_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaveServiceServer).DecideLeave(ctx, req.(*DecideLeaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaveService_CancelLeave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelLeaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaveServiceServer).CancelLeave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaveService_CancelLeave_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaveServiceServer).CancelLeave(ctx, req.(*CancelLeaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaveService_ListLeaveRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLeaveRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaveServiceServer).ListLeaveRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaveService_ListLeaveRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaveServiceServer).ListLeaveRequests(ctx, req.(*ListLeaveRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaveService_ListAbsences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAbsencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaveServiceServer).ListAbsences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaveService_ListAbsences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaveServiceServer).ListAbsences(ctx, req.(*ListAbsencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LeaveService_ServiceDesc is the grpc.ServiceDesc for LeaveService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LeaveService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.LeaveService",
+	HandlerType: (*LeaveServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateLeaveType",
+			Handler:    _LeaveService_CreateLeaveType_Handler,
+		},
+		{
+			MethodName: "ListLeaveTypes",
+			Handler:    _LeaveService_ListLeaveTypes_Handler,
+		},
+		{
+			MethodName: "UpdateLeaveType",
+			Handler:    _LeaveService_UpdateLeaveType_Handler,
+		},
+		{
+			MethodName: "GetLeaveBalances",
+			Handler:    _LeaveService_GetLeaveBalances_Handler,
+		},
+		{
+			MethodName: "AdjustLeaveBalance",
+			Handler:    _LeaveService_AdjustLeaveBalance_Handler,
+		},
+		{
+			MethodName: "SubmitLeave",
+			Handler:    _LeaveService_SubmitLeave_Handler,
+		},
+		{
+			MethodName: "DecideLeave",
+			Handler:    _LeaveService_DecideLeave_Handler,
+		},
+		{
+			MethodName: "CancelLeave",
+			Handler:    _LeaveService_CancelLeave_Handler,
+		},
+		{
+			MethodName: "ListLeaveRequests",
+			Handler:    _LeaveService_ListLeaveRequests_Handler,
+		},
+		{
+			MethodName: "ListAbsences",
+			Handler:    _LeaveService_ListAbsences_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/employee.proto",
+}
+
+const (
+	CalendarService_CreateCalendarFeed_FullMethodName    = "/proto.CalendarService/CreateCalendarFeed"
+	CalendarService_ListCalendarFeeds_FullMethodName     = "/proto.CalendarService/ListCalendarFeeds"
+	CalendarService_RevokeCalendarFeed_FullMethodName    = "/proto.CalendarService/RevokeCalendarFeed"
+	CalendarService_GetCalendarFeedEvents_FullMethodName = "/proto.CalendarService/GetCalendarFeedEvents"
+)
+
+// CalendarServiceClient is the client API for CalendarService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CalendarServiceClient interface {
+	CreateCalendarFeed(ctx context.Context, in *CreateCalendarFeedRequest, opts ...grpc.CallOption) (*CreateCalendarFeedResponse, error)
+	ListCalendarFeeds(ctx context.Context, in *ListCalendarFeedsRequest, opts ...grpc.CallOption) (*ListCalendarFeedsResponse, error)
+	RevokeCalendarFeed(ctx context.Context, in *RevokeCalendarFeedRequest, opts ...grpc.CallOption) (*CalendarFeedResponse, error)
+	GetCalendarFeedEvents(ctx context.Context, in *GetCalendarFeedEventsRequest, opts ...grpc.CallOption) (*CalendarFeedEventsResponse, error)
+}
+
+type calendarServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCalendarServiceClient(cc grpc.ClientConnInterface) CalendarServiceClient {
+	return &calendarServiceClient{cc}
+}
+
+func (c *calendarServiceClient) CreateCalendarFeed(ctx context.Context, in *CreateCalendarFeedRequest, opts ...grpc.CallOption) (*CreateCalendarFeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCalendarFeedResponse)
+	err := c.cc.Invoke(ctx, CalendarService_CreateCalendarFeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ListCalendarFeeds(ctx context.Context, in *ListCalendarFeedsRequest, opts ...grpc.CallOption) (*ListCalendarFeedsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCalendarFeedsResponse)
+	err := c.cc.Invoke(ctx, CalendarService_ListCalendarFeeds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) RevokeCalendarFeed(ctx context.Context, in *RevokeCalendarFeedRequest, opts ...grpc.CallOption) (*CalendarFeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CalendarFeedResponse)
+	err := c.cc.Invoke(ctx, CalendarService_RevokeCalendarFeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) GetCalendarFeedEvents(ctx context.Context, in *GetCalendarFeedEventsRequest, opts ...grpc.CallOption) (*CalendarFeedEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CalendarFeedEventsResponse)
+	err := c.cc.Invoke(ctx, CalendarService_GetCalendarFeedEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CalendarServiceServer is the server API for CalendarService service.
+// All implementations must embed UnimplementedCalendarServiceServer
+// for forward compatibility.
+type CalendarServiceServer interface {
+	CreateCalendarFeed(context.Context, *CreateCalendarFeedRequest) (*CreateCalendarFeedResponse, error)
+	ListCalendarFeeds(context.Context, *ListCalendarFeedsRequest) (*ListCalendarFeedsResponse, error)
+	RevokeCalendarFeed(context.Context, *RevokeCalendarFeedRequest) (*CalendarFeedResponse, error)
+	GetCalendarFeedEvents(context.Context, *GetCalendarFeedEventsRequest) (*CalendarFeedEventsResponse, error)
+	mustEmbedUnimplementedCalendarServiceServer()
+}
+
+// UnimplementedCalendarServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCalendarServiceServer struct{}
+
+func (UnimplementedCalendarServiceServer) CreateCalendarFeed(context.Context, *CreateCalendarFeedRequest) (*CreateCalendarFeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCalendarFeed not implemented")
+}
+func (UnimplementedCalendarServiceServer) ListCalendarFeeds(context.Context, *ListCalendarFeedsRequest) (*ListCalendarFeedsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCalendarFeeds not implemented")
+}
+func (UnimplementedCalendarServiceServer) RevokeCalendarFeed(context.Context, *RevokeCalendarFeedRequest) (*CalendarFeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeCalendarFeed not implemented")
+}
+func (UnimplementedCalendarServiceServer) GetCalendarFeedEvents(context.Context, *GetCalendarFeedEventsRequest) (*CalendarFeedEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCalendarFeedEvents not implemented")
+}
+func (UnimplementedCalendarServiceServer) mustEmbedUnimplementedCalendarServiceServer() {}
+func (UnimplementedCalendarServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeCalendarServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CalendarServiceServer will
+// result in compilation errors.
+type UnsafeCalendarServiceServer interface {
+	mustEmbedUnimplementedCalendarServiceServer()
+}
+
+func RegisterCalendarServiceServer(s grpc.ServiceRegistrar, srv CalendarServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCalendarServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CalendarService_ServiceDesc, srv)
+}
+
+func _CalendarService_CreateCalendarFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCalendarFeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).CreateCalendarFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_CreateCalendarFeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).CreateCalendarFeed(ctx, req.(*CreateCalendarFeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ListCalendarFeeds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCalendarFeedsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).ListCalendarFeeds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_ListCalendarFeeds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).ListCalendarFeeds(ctx, req.(*ListCalendarFeedsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_RevokeCalendarFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCalendarFeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).RevokeCalendarFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_RevokeCalendarFeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).RevokeCalendarFeed(ctx, req.(*RevokeCalendarFeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_GetCalendarFeedEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCalendarFeedEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).GetCalendarFeedEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalendarService_GetCalendarFeedEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).GetCalendarFeedEvents(ctx, req.(*GetCalendarFeedEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CalendarService_ServiceDesc is the grpc.ServiceDesc for CalendarService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CalendarService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.CalendarService",
+	HandlerType: (*CalendarServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateCalendarFeed",
+			Handler:    _CalendarService_CreateCalendarFeed_Handler,
+		},
+		{
+			MethodName: "ListCalendarFeeds",
+			Handler:    _CalendarService_ListCalendarFeeds_Handler,
+		},
+		{
+			MethodName: "RevokeCalendarFeed",
+			Handler:    _CalendarService_RevokeCalendarFeed_Handler,
+		},
+		{
+			MethodName: "GetCalendarFeedEvents",
+			Handler:    _CalendarService_GetCalendarFeedEvents_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/employee.proto",
+}
+
+const (
+	AttributeService_CreateAttribute_FullMethodName = "/proto.AttributeService/CreateAttribute"
+	AttributeService_ListAttributes_FullMethodName  = "/proto.AttributeService/ListAttributes"
+	AttributeService_UpdateAttribute_FullMethodName = "/proto.AttributeService/UpdateAttribute"
+	AttributeService_DeleteAttribute_FullMethodName = "/proto.AttributeService/DeleteAttribute"
+)
+
+// AttributeServiceClient is the client API for AttributeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AttributeServiceClient interface {
+	CreateAttribute(ctx context.Context, in *CreateAttributeRequest, opts ...grpc.CallOption) (*AttributeResponse, error)
+	ListAttributes(ctx context.Context, in *ListAttributesRequest, opts ...grpc.CallOption) (*ListAttributesResponse, error)
+	UpdateAttribute(ctx context.Context, in *UpdateAttributeRequest, opts ...grpc.CallOption) (*AttributeResponse, error)
+	DeleteAttribute(ctx context.Context, in *DeleteAttributeRequest, opts ...grpc.CallOption) (*DeleteAttributeResponse, error)
+}
+
+type attributeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAttributeServiceClient(cc grpc.ClientConnInterface) AttributeServiceClient {
+	return &attributeServiceClient{cc}
+}
+
+func (c *attributeServiceClient) CreateAttribute(ctx context.Context, in *CreateAttributeRequest, opts ...grpc.CallOption) (*AttributeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttributeResponse)
+	err := c.cc.Invoke(ctx, AttributeService_CreateAttribute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attributeServiceClient) ListAttributes(ctx context.Context, in *ListAttributesRequest, opts ...grpc.CallOption) (*ListAttributesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAttributesResponse)
+	err := c.cc.Invoke(ctx, AttributeService_ListAttributes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attributeServiceClient) UpdateAttribute(ctx context.Context, in *UpdateAttributeRequest, opts ...grpc.CallOption) (*AttributeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttributeResponse)
+	err := c.cc.Invoke(ctx, AttributeService_UpdateAttribute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attributeServiceClient) DeleteAttribute(ctx context.Context, in *DeleteAttributeRequest, opts ...grpc.CallOption) (*DeleteAttributeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAttributeResponse)
+	err := c.cc.Invoke(ctx, AttributeService_DeleteAttribute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AttributeServiceServer is the server API for AttributeService service.
+// All implementations must embed UnimplementedAttributeServiceServer
+// for forward compatibility.
+type AttributeServiceServer interface {
+	CreateAttribute(context.Context, *CreateAttributeRequest) (*AttributeResponse, error)
+	ListAttributes(context.Context, *ListAttributesRequest) (*ListAttributesResponse, error)
+	UpdateAttribute(context.Context, *UpdateAttributeRequest) (*AttributeResponse, error)
+	DeleteAttribute(context.Context, *DeleteAttributeRequest) (*DeleteAttributeResponse, error)
+	mustEmbedUnimplementedAttributeServiceServer()
+}
+
+// UnimplementedAttributeServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAttributeServiceServer struct{}
+
+func (UnimplementedAttributeServiceServer) CreateAttribute(context.Context, *CreateAttributeRequest) (*AttributeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAttribute not implemented")
+}
+func (UnimplementedAttributeServiceServer) ListAttributes(context.Context, *ListAttributesRequest) (*ListAttributesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAttributes not implemented")
+}
+func (UnimplementedAttributeServiceServer) UpdateAttribute(context.Context, *UpdateAttributeRequest) (*AttributeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAttribute not implemented")
+}
+func (UnimplementedAttributeServiceServer) DeleteAttribute(context.Context, *DeleteAttributeRequest) (*DeleteAttributeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAttribute not implemented")
+}
+func (UnimplementedAttributeServiceServer) mustEmbedUnimplementedAttributeServiceServer() {}
+func (UnimplementedAttributeServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeAttributeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AttributeServiceServer will
+// result in compilation errors.
+type UnsafeAttributeServiceServer interface {
+	mustEmbedUnimplementedAttributeServiceServer()
+}
+
+func RegisterAttributeServiceServer(s grpc.ServiceRegistrar, srv AttributeServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAttributeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AttributeService_ServiceDesc, srv)
+}
+
+func _AttributeService_CreateAttribute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAttributeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttributeServiceServer).CreateAttribute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttributeService_CreateAttribute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttributeServiceServer).CreateAttribute(ctx, req.(*CreateAttributeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttributeService_ListAttributes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAttributesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttributeServiceServer).ListAttributes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttributeService_ListAttributes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttributeServiceServer).ListAttributes(ctx, req.(*ListAttributesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttributeService_UpdateAttribute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAttributeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttributeServiceServer).UpdateAttribute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttributeService_UpdateAttribute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttributeServiceServer).UpdateAttribute(ctx, req.(*UpdateAttributeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttributeService_DeleteAttribute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAttributeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttributeServiceServer).DeleteAttribute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttributeService_DeleteAttribute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttributeServiceServer).DeleteAttribute(ctx, req.(*DeleteAttributeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AttributeService_ServiceDesc is the grpc.ServiceDesc for AttributeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AttributeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.AttributeService",
+	HandlerType: (*AttributeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAttribute",
+			Handler:    _AttributeService_CreateAttribute_Handler,
+		},
+		{
+			MethodName: "ListAttributes",
+			Handler:    _AttributeService_ListAttributes_Handler,
+		},
+		{
+			MethodName: "UpdateAttribute",
+			Handler:    _AttributeService_UpdateAttribute_Handler,
+		},
+		{
+			MethodName: "DeleteAttribute",
+			Handler:    _AttributeService_DeleteAttribute_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -0,0 +1,264 @@
+package repositories
+
+import (
+	"context"
+	"employee-service/metrics"
+	"employee-service/models"
+	"employee-service/tracing"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"strings"
+	"time"
+)
+
+var (
+	ErrAttributeNotFound = errors.New("custom attribute not found")
+	ErrAttributeExists   = errors.New("custom attribute already exists")
+	// ErrInvalidAttributeDefinition is returned when options are given for
+	// an attribute that is not an enum.
+	ErrInvalidAttributeDefinition = errors.New("invalid custom attribute definition")
+	// ErrAttributeValuesConflict is returned when an attribute would be made
+	// required or lose enum options while employees lack it or use them.
+	ErrAttributeValuesConflict = errors.New("employee values conflict with the custom attribute definition")
+)
+
+// maxAttributeConflicts bounds the employees reported by
+// ErrAttributeValuesConflict.
+const maxAttributeConflicts = 20
+
+type AttributeRepositoryInterface interface {
+	CreateAttribute(ctx context.Context, definition models.AttributeDefinition) (models.AttributeDefinition, error)
+	ListAttributes(ctx context.Context, companyId int32) ([]models.AttributeDefinition, error)
+	UpdateAttribute(ctx context.Context, companyId, id int32, update models.AttributeDefinitionUpdate) (models.AttributeDefinition, error)
+	DeleteAttribute(ctx context.Context, companyId, id int32) error
+}
+
+type AttributeRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAttributeRepository(db *pgxpool.Pool) *AttributeRepository {
+	return &AttributeRepository{db: db}
+}
+
+// attributeColumns are the columns read by scanAttribute, for queries on
+// attribute_definitions AS ad.
+const attributeColumns = `
+		ad.id, ad.company_id, ad.key, ad.name, ad.type, ad.required, ad.options, ad.created_at`
+
+func scanAttribute(row pgx.Row) (models.AttributeDefinition, error) {
+	var definition models.AttributeDefinition
+	err := row.Scan(&definition.Id, &definition.CompanyId, &definition.Key, &definition.Name, &definition.Type,
+		&definition.Required, &definition.Options, &definition.CreatedAt)
+	if err != nil {
+		return models.AttributeDefinition{}, fmt.Errorf("scan attribute: %w", err)
+	}
+	return definition, nil
+}
+
+func (r *AttributeRepository) CreateAttribute(ctx context.Context, definition models.AttributeDefinition) (models.AttributeDefinition, error) {
+	ctx, span := tracing.Start(ctx, "AttributeRepository.CreateAttribute")
+	defer span.End()
+	defer metrics.ObserveQuery("create_attribute", time.Now())
+
+	options := definition.Options
+	if options == nil {
+		options = []string{}
+	}
+
+	created, err := scanAttribute(r.db.QueryRow(ctx, `
+		INSERT INTO attribute_definitions AS ad (company_id, key, name, type, required, options)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING`+attributeColumns,
+		definition.CompanyId, definition.Key, definition.Name, definition.Type, definition.Required, options))
+	if isUniqueViolation(err) {
+		return models.AttributeDefinition{}, fmt.Errorf("attribute_repo: create_attribute: %q: %w",
+			definition.Key, ErrAttributeExists)
+	}
+	if err != nil {
+		return models.AttributeDefinition{}, fmt.Errorf("attribute_repo: create_attribute: %w", err)
+	}
+	return created, nil
+}
+
+func (r *AttributeRepository) ListAttributes(ctx context.Context, companyId int32) ([]models.AttributeDefinition, error) {
+	ctx, span := tracing.Start(ctx, "AttributeRepository.ListAttributes")
+	defer span.End()
+	defer metrics.ObserveQuery("list_attributes", time.Now())
+
+	definitions, err := companyAttributes(ctx, r.db, companyId)
+	if err != nil {
+		return nil, fmt.Errorf("attribute_repo: list_attributes: %w", err)
+	}
+	return definitions, nil
+}
+
+// UpdateAttribute changes the definition. It is refused with
+// ErrAttributeValuesConflict, naming the first employees concerned, when an
+// employee who is not erased lacks an attribute made required or has a value
+// removed from the enum options.
+func (r *AttributeRepository) UpdateAttribute(ctx context.Context, companyId, id int32, update models.AttributeDefinitionUpdate) (models.AttributeDefinition, error) {
+	ctx, span := tracing.Start(ctx, "AttributeRepository.UpdateAttribute")
+	defer span.End()
+	defer metrics.ObserveQuery("update_attribute", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.AttributeDefinition{}, fmt.Errorf("attribute_repo: update_attribute: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	current, err := scanAttribute(tx.QueryRow(ctx, `
+		SELECT`+attributeColumns+`
+		FROM attribute_definitions AS ad
+		WHERE ad.id = $1 AND ad.company_id = $2
+		FOR UPDATE`, id, companyId))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.AttributeDefinition{}, fmt.Errorf("attribute_repo: update_attribute: %w", ErrAttributeNotFound)
+	}
+	if err != nil {
+		return models.AttributeDefinition{}, fmt.Errorf("attribute_repo: update_attribute: %w", err)
+	}
+	if update.Options != nil && current.Type != models.AttributeEnum {
+		return models.AttributeDefinition{}, fmt.Errorf("attribute_repo: update_attribute: options of %s attribute: %w",
+			current.Type, ErrInvalidAttributeDefinition)
+	}
+
+	fields := make([]string, 0)
+	args := []interface{}{}
+	set := func(column string, value interface{}) {
+		args = append(args, value)
+		fields = append(fields, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if update.Name != nil {
+		set("name", *update.Name)
+	}
+	if update.Required != nil {
+		set("required", *update.Required)
+	}
+	if update.Options != nil {
+		set("options", update.Options)
+	}
+	if len(fields) == 0 {
+		return current, nil
+	}
+
+	makesRequired := update.Required != nil && *update.Required && !current.Required
+	if makesRequired || update.Options != nil {
+		conflicts, err := attributeConflicts(ctx, tx, current, makesRequired, update.Options)
+		if err != nil {
+			return models.AttributeDefinition{}, fmt.Errorf("attribute_repo: update_attribute: %w", err)
+		}
+		if len(conflicts) > 0 {
+			return models.AttributeDefinition{}, fmt.Errorf("attribute_repo: update_attribute: %w: %q of employees %v",
+				ErrAttributeValuesConflict, current.Key, conflicts)
+		}
+	}
+
+	args = append(args, id)
+	updated, err := scanAttribute(tx.QueryRow(ctx,
+		fmt.Sprintf("UPDATE attribute_definitions AS ad SET %s WHERE ad.id = $%d RETURNING",
+			strings.Join(fields, ", "), len(args))+attributeColumns, args...))
+	if err != nil {
+		return models.AttributeDefinition{}, fmt.Errorf("attribute_repo: update_attribute: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.AttributeDefinition{}, fmt.Errorf("attribute_repo: update_attribute: commit transaction: %w", err)
+	}
+	return updated, nil
+}
+
+// DeleteAttribute removes the definition and its values from every employee
+// of the company.
+func (r *AttributeRepository) DeleteAttribute(ctx context.Context, companyId, id int32) error {
+	ctx, span := tracing.Start(ctx, "AttributeRepository.DeleteAttribute")
+	defer span.End()
+	defer metrics.ObserveQuery("delete_attribute", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("attribute_repo: delete_attribute: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var key string
+	err = tx.QueryRow(ctx, "DELETE FROM attribute_definitions WHERE id = $1 AND company_id = $2 RETURNING key",
+		id, companyId).Scan(&key)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("attribute_repo: delete_attribute: %w", ErrAttributeNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("attribute_repo: delete_attribute: delete definition: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE employees SET custom_attributes = custom_attributes - $2::TEXT
+		WHERE company_id = $1 AND custom_attributes ? $2::TEXT`, companyId, key)
+	if err != nil {
+		return fmt.Errorf("attribute_repo: delete_attribute: delete values: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("attribute_repo: delete_attribute: commit transaction: %w", err)
+	}
+	return nil
+}
+
+// attributeConflicts returns the ids of the employees of the company of
+// definition, up to maxAttributeConflicts, who lack it while required is set or
+// have a value missing from options. Nil options are not checked.
+func attributeConflicts(ctx context.Context, tx pgx.Tx, definition models.AttributeDefinition, required bool, options []string) ([]int32, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT id
+		FROM employees
+		WHERE company_id = $1 AND erased_at IS NULL
+		  AND (($3 AND NOT custom_attributes ? $2::TEXT) OR custom_attributes ->> $2::TEXT <> ALL($4::TEXT[]))
+		ORDER BY id
+		LIMIT $5`, definition.CompanyId, definition.Key, required, options, maxAttributeConflicts)
+	if err != nil {
+		return nil, fmt.Errorf("attribute conflicts: query: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int32
+	for rows.Next() {
+		var id int32
+		if err = rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("attribute conflicts: scan: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("attribute conflicts: rows: %w", err)
+	}
+	return ids, nil
+}
+
+func companyAttributes(ctx context.Context, q querier, companyId int32) ([]models.AttributeDefinition, error) {
+	rows, err := q.Query(ctx, `
+		SELECT`+attributeColumns+`
+		FROM attribute_definitions AS ad
+		WHERE ad.company_id = $1
+		ORDER BY ad.key`, companyId)
+	if err != nil {
+		return nil, fmt.Errorf("company attributes: query: %w", err)
+	}
+	defer rows.Close()
+
+	var definitions []models.AttributeDefinition
+	for rows.Next() {
+		definition, err := scanAttribute(rows)
+		if err != nil {
+			return nil, fmt.Errorf("company attributes: %w", err)
+		}
+		definitions = append(definitions, definition)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("company attributes: rows: %w", err)
+	}
+	return definitions, nil
+}
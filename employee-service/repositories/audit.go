@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"employee-service/auth"
+	"employee-service/models"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+)
+
+// recordAudit appends an audit entry for the caller in ctx as part of tx, so
+// the entry is committed together with the change it describes.
+func recordAudit(ctx context.Context, tx pgx.Tx, employeeId int32, action string, details map[string]interface{}) error {
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+
+	_, err := tx.Exec(ctx, "INSERT INTO audit_log (employee_id, action, caller_id, details) VALUES ($1, $2, $3, $4)",
+		employeeId, action, auth.CallerFromContext(ctx).Id, details)
+	if err != nil {
+		return fmt.Errorf("record audit: insert %s: %w", action, err)
+	}
+	return nil
+}
+
+func auditHistory(ctx context.Context, tx pgx.Tx, employeeId int32) ([]models.AuditEntry, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT id, employee_id, action, caller_id, details, created_at
+		FROM audit_log
+		WHERE employee_id = $1
+		ORDER BY created_at, id`, employeeId)
+	if err != nil {
+		return nil, fmt.Errorf("audit history: query: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.AuditEntry
+	for rows.Next() {
+		var entry models.AuditEntry
+		err = rows.Scan(&entry.Id, &entry.EmployeeId, &entry.Action, &entry.CallerId, &entry.Details, &entry.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("audit history: scan: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("audit history: rows: %w", err)
+	}
+	return entries, nil
+}
+
+// changedFields lists the attributes an update touches, without their values.
+func changedFields(employee models.Employee) []string {
+	var fields []string
+	if employee.Name != "" {
+		fields = append(fields, "name")
+	}
+	if employee.Surname != "" {
+		fields = append(fields, "surname")
+	}
+	if employee.Phone != "" {
+		fields = append(fields, "phone")
+	}
+	if employee.CompanyId != 0 {
+		fields = append(fields, "company_id")
+	}
+	if employee.Passport.Type != "" {
+		fields = append(fields, "passport.type")
+	}
+	if employee.Passport.Number != "" {
+		fields = append(fields, "passport.number")
+	}
+	if employee.Department.Name != "" {
+		fields = append(fields, "department.name")
+	}
+	if employee.Department.Phone != "" {
+		fields = append(fields, "department.phone")
+	}
+	if employee.ManagerId != nil {
+		fields = append(fields, "manager_id")
+	}
+	if employee.PositionId != nil {
+		fields = append(fields, "position_id")
+	}
+	if employee.Contacts != nil {
+		fields = append(fields, "contacts")
+	}
+	if employee.Addresses != nil {
+		fields = append(fields, "addresses")
+	}
+	if len(employee.CustomAttributes) > 0 {
+		fields = append(fields, "custom_attributes")
+	}
+	return fields
+}
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"employee-service/metrics"
+	"employee-service/models"
+	"employee-service/tracing"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"time"
+)
+
+// ErrBatchItemFailed wraps the error of the item that aborted an all-or-nothing
+// batch.
+var ErrBatchItemFailed = errors.New("batch item failed")
+
+// BatchAddEmployees adds every employee in a single transaction. In
+// all-or-nothing mode the first failing item rolls back the whole batch and
+// is returned as the error. Otherwise every item runs in its own savepoint, a
+// failing item only rolls back itself and its error is reported in its result.
+func (r *EmployeeRepository) BatchAddEmployees(ctx context.Context, items []models.AddItem, allOrNothing bool) ([]models.BatchResult, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.BatchAddEmployees")
+	defer span.End()
+	defer metrics.ObserveQuery("batch_add_employees", time.Now())
+
+	return r.runBatch(ctx, "batch_add_employees", len(items), allOrNothing,
+		func(tx pgx.Tx, i int) (models.BatchResult, error) {
+			id, replayed, err := r.addEmployee(ctx, tx, items[i].Employee, items[i].IdempotencyKey)
+			return models.BatchResult{Id: id, Replayed: replayed}, err
+		})
+}
+
+// BatchUpdateEmployees updates every employee in a single transaction, see
+// BatchAddEmployees for the modes.
+func (r *EmployeeRepository) BatchUpdateEmployees(ctx context.Context, employees []models.Employee, allOrNothing bool) ([]models.BatchResult, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.BatchUpdateEmployees")
+	defer span.End()
+	defer metrics.ObserveQuery("batch_update_employees", time.Now())
+
+	return r.runBatch(ctx, "batch_update_employees", len(employees), allOrNothing,
+		func(tx pgx.Tx, i int) (models.BatchResult, error) {
+			return models.BatchResult{Id: employees[i].Id}, r.updateEmployee(ctx, tx, employees[i], nil)
+		})
+}
+
+// BatchDeleteEmployees deletes every employee in a single transaction, see
+// BatchAddEmployees for the modes.
+func (r *EmployeeRepository) BatchDeleteEmployees(ctx context.Context, ids []int32, allOrNothing bool) ([]models.BatchResult, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.BatchDeleteEmployees")
+	defer span.End()
+	defer metrics.ObserveQuery("batch_delete_employees", time.Now())
+
+	return r.runBatch(ctx, "batch_delete_employees", len(ids), allOrNothing,
+		func(tx pgx.Tx, i int) (models.BatchResult, error) {
+			return models.BatchResult{Id: ids[i]}, deleteEmployee(ctx, tx, ids[i])
+		})
+}
+
+func (r *EmployeeRepository) runBatch(ctx context.Context, op string, count int, allOrNothing bool,
+	apply func(tx pgx.Tx, i int) (models.BatchResult, error)) ([]models.BatchResult, error) {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("employee_repo: %s: acquire connection: %w", op, err)
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("employee_repo: %s: begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	results := make([]models.BatchResult, count)
+	for i := range results {
+		if allOrNothing {
+			results[i], err = apply(tx, i)
+			if err != nil {
+				return nil, fmt.Errorf("employee_repo: %s: item %d: %w: %w", op, i, ErrBatchItemFailed, err)
+			}
+			continue
+		}
+
+		savepoint, err := tx.Begin(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("employee_repo: %s: item %d: savepoint: %w", op, i, err)
+		}
+		results[i], err = apply(savepoint, i)
+		if err != nil {
+			results[i].Err = err
+			if err = savepoint.Rollback(ctx); err != nil {
+				return nil, fmt.Errorf("employee_repo: %s: item %d: rollback to savepoint: %w", op, i, err)
+			}
+			continue
+		}
+		if err = savepoint.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("employee_repo: %s: item %d: release savepoint: %w", op, i, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("employee_repo: %s: commit transaction: %w", op, err)
+	}
+	return results, nil
+}
@@ -0,0 +1,195 @@
+package repositories
+
+import (
+	"context"
+	"crypto/sha256"
+	"employee-service/metrics"
+	"employee-service/models"
+	"employee-service/tracing"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+// ErrCalendarFeedNotFound is also returned for the token of a revoked feed.
+var ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+
+type CalendarRepositoryInterface interface {
+	CreateCalendarFeed(ctx context.Context, feed models.CalendarFeed, token string) (models.CalendarFeed, error)
+	ListCalendarFeeds(ctx context.Context, companyId int32) ([]models.CalendarFeed, error)
+	RevokeCalendarFeed(ctx context.Context, companyId, id int32) (models.CalendarFeed, error)
+	CalendarFeedEvents(ctx context.Context, token string, from, to time.Time) (models.CalendarFeed, []models.CalendarEvent, error)
+}
+
+type CalendarRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCalendarRepository(db *pgxpool.Pool) *CalendarRepository {
+	return &CalendarRepository{db: db}
+}
+
+const calendarFeedColumns = `
+		id, company_id, COALESCE(department_name, ''), COALESCE(department_phone, ''), name, created_by, created_at,
+		revoked_at`
+
+func scanCalendarFeed(row pgx.Row) (models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	err := row.Scan(&feed.Id, &feed.CompanyId, &feed.Department.Name, &feed.Department.Phone, &feed.Name,
+		&feed.CreatedBy, &feed.CreatedAt, &feed.RevokedAt)
+	if err != nil {
+		return models.CalendarFeed{}, fmt.Errorf("scan calendar feed: %w", err)
+	}
+	return feed, nil
+}
+
+// CreateCalendarFeed stores the feed with the hash of its token, so a leaked
+// database does not expose the feeds.
+func (r *CalendarRepository) CreateCalendarFeed(ctx context.Context, feed models.CalendarFeed, token string) (models.CalendarFeed, error) {
+	ctx, span := tracing.Start(ctx, "CalendarRepository.CreateCalendarFeed")
+	defer span.End()
+	defer metrics.ObserveQuery("create_calendar_feed", time.Now())
+
+	row := r.db.QueryRow(ctx, `
+		INSERT INTO calendar_feeds (company_id, department_name, department_phone, name, token_hash, created_by)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), $4, $5, $6)
+		RETURNING `+calendarFeedColumns,
+		feed.CompanyId, feed.Department.Name, feed.Department.Phone, feed.Name, hashFeedToken(token), feed.CreatedBy)
+	created, err := scanCalendarFeed(row)
+	if err != nil {
+		return models.CalendarFeed{}, fmt.Errorf("calendar_repo: create_calendar_feed: %w", err)
+	}
+	return created, nil
+}
+
+func (r *CalendarRepository) ListCalendarFeeds(ctx context.Context, companyId int32) ([]models.CalendarFeed, error) {
+	ctx, span := tracing.Start(ctx, "CalendarRepository.ListCalendarFeeds")
+	defer span.End()
+	defer metrics.ObserveQuery("list_calendar_feeds", time.Now())
+
+	rows, err := r.db.Query(ctx, "SELECT "+calendarFeedColumns+" FROM calendar_feeds WHERE company_id = $1 ORDER BY id",
+		companyId)
+	if err != nil {
+		return nil, fmt.Errorf("calendar_repo: list_calendar_feeds: query: %w", err)
+	}
+	defer rows.Close()
+
+	var feeds []models.CalendarFeed
+	for rows.Next() {
+		feed, err := scanCalendarFeed(rows)
+		if err != nil {
+			return nil, fmt.Errorf("calendar_repo: list_calendar_feeds: %w", err)
+		}
+		feeds = append(feeds, feed)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("calendar_repo: list_calendar_feeds: rows: %w", err)
+	}
+	return feeds, nil
+}
+
+// RevokeCalendarFeed stops serving the feed. Revoking a revoked feed keeps its
+// original revocation time.
+func (r *CalendarRepository) RevokeCalendarFeed(ctx context.Context, companyId, id int32) (models.CalendarFeed, error) {
+	ctx, span := tracing.Start(ctx, "CalendarRepository.RevokeCalendarFeed")
+	defer span.End()
+	defer metrics.ObserveQuery("revoke_calendar_feed", time.Now())
+
+	row := r.db.QueryRow(ctx, `
+		UPDATE calendar_feeds
+		SET revoked_at = COALESCE(revoked_at, now())
+		WHERE id = $1 AND company_id = $2
+		RETURNING `+calendarFeedColumns, id, companyId)
+	feed, err := scanCalendarFeed(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.CalendarFeed{}, fmt.Errorf("calendar_repo: revoke_calendar_feed: %w", ErrCalendarFeedNotFound)
+	}
+	if err != nil {
+		return models.CalendarFeed{}, fmt.Errorf("calendar_repo: revoke_calendar_feed: %w", err)
+	}
+	return feed, nil
+}
+
+// CalendarFeedEvents returns the active feed of token and its events between
+// from and to: the start and termination dates of its employees, scheduled
+// terminations included, the pending transfers of employees moving in or out
+// of it, and approved leave.
+func (r *CalendarRepository) CalendarFeedEvents(ctx context.Context, token string, from, to time.Time) (models.CalendarFeed, []models.CalendarEvent, error) {
+	ctx, span := tracing.Start(ctx, "CalendarRepository.CalendarFeedEvents")
+	defer span.End()
+	defer metrics.ObserveQuery("calendar_feed_events", time.Now())
+
+	row := r.db.QueryRow(ctx, "SELECT "+calendarFeedColumns+" FROM calendar_feeds WHERE token_hash = $1 AND revoked_at IS NULL",
+		hashFeedToken(token))
+	feed, err := scanCalendarFeed(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.CalendarFeed{}, nil, fmt.Errorf("calendar_repo: calendar_feed_events: %w", ErrCalendarFeedNotFound)
+	}
+	if err != nil {
+		return models.CalendarFeed{}, nil, fmt.Errorf("calendar_repo: calendar_feed_events: %w", err)
+	}
+
+	// $1-$3 select the employees of the feed, whose department is d. The
+	// target of a transfer is matched the same way, keeping the current
+	// company and department where the transfer does not change them.
+	rows, err := r.db.Query(ctx, `
+		SELECT 'start', 0, e.id, COALESCE(e.name, ''), COALESCE(e.surname, ''), e.hire_date, e.hire_date, '', '', 0
+		FROM employees AS e
+		JOIN departments AS d ON e.department_id = d.id
+		WHERE e.company_id = $1 AND ($2 = '' OR d.name = $2) AND ($3 = '' OR d.phone = $3)
+		  AND e.status <> 'candidate' AND e.hire_date BETWEEN $4 AND $5
+		UNION ALL
+		SELECT 'departure', 0, e.id, COALESCE(e.name, ''), COALESCE(e.surname, ''), e.termination_date,
+		       e.termination_date, '', '', 0
+		FROM employees AS e
+		JOIN departments AS d ON e.department_id = d.id
+		WHERE e.company_id = $1 AND ($2 = '' OR d.name = $2) AND ($3 = '' OR d.phone = $3)
+		  AND e.status <> 'candidate' AND e.termination_date BETWEEN $4 AND $5
+		UNION ALL
+		SELECT 'transfer', t.id, e.id, COALESCE(e.name, ''), COALESCE(e.surname, ''), t.effective_date,
+		       t.effective_date, COALESCE(t.department_name, ''), COALESCE(t.department_phone, ''),
+		       COALESCE(t.company_id, 0)
+		FROM transfers AS t
+		JOIN employees AS e ON t.employee_id = e.id
+		JOIN departments AS d ON e.department_id = d.id
+		WHERE t.status = 'pending' AND t.effective_date BETWEEN $4 AND $5
+		  AND ((e.company_id = $1 AND ($2 = '' OR d.name = $2) AND ($3 = '' OR d.phone = $3))
+		    OR (COALESCE(t.company_id, e.company_id) = $1
+		        AND ($2 = '' OR COALESCE(t.department_name, d.name) = $2)
+		        AND ($3 = '' OR COALESCE(t.department_phone, d.phone) = $3)))
+		UNION ALL
+		SELECT 'absence', lr.id, e.id, COALESCE(e.name, ''), COALESCE(e.surname, ''), lr.start_date, lr.end_date,
+		       '', '', 0
+		FROM leave_requests AS lr
+		JOIN employees AS e ON lr.employee_id = e.id
+		JOIN departments AS d ON e.department_id = d.id
+		WHERE e.company_id = $1 AND ($2 = '' OR d.name = $2) AND ($3 = '' OR d.phone = $3)
+		  AND lr.status = 'approved' AND lr.start_date <= $5 AND lr.end_date >= $4
+		ORDER BY 6, 3`, feed.CompanyId, feed.Department.Name, feed.Department.Phone, from, to)
+	if err != nil {
+		return models.CalendarFeed{}, nil, fmt.Errorf("calendar_repo: calendar_feed_events: query events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []models.CalendarEvent
+	for rows.Next() {
+		var event models.CalendarEvent
+		err = rows.Scan(&event.Kind, &event.SourceId, &event.EmployeeId, &event.Name, &event.Surname, &event.StartDate,
+			&event.EndDate, &event.Department.Name, &event.Department.Phone, &event.CompanyId)
+		if err != nil {
+			return models.CalendarFeed{}, nil, fmt.Errorf("calendar_repo: calendar_feed_events: scan event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err = rows.Err(); err != nil {
+		return models.CalendarFeed{}, nil, fmt.Errorf("calendar_repo: calendar_feed_events: rows: %w", err)
+	}
+	return feed, events, nil
+}
+
+func hashFeedToken(token string) []byte {
+	hash := sha256.Sum256([]byte(token))
+	return hash[:]
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"employee-service/models"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+)
+
+// ErrWorkEmailTaken is returned when a work email is already used by another
+// employee of the company.
+var ErrWorkEmailTaken = errors.New("work email is already used in the company")
+
+// replaceContacts replaces every contact of the employee.
+func replaceContacts(ctx context.Context, tx pgx.Tx, employeeId int32, contacts []models.Contact) error {
+	if _, err := tx.Exec(ctx, "DELETE FROM employee_contacts WHERE employee_id = $1", employeeId); err != nil {
+		return fmt.Errorf("replace contacts: delete: %w", err)
+	}
+
+	for _, contact := range contacts {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO employee_contacts (employee_id, company_id, kind, value, is_primary)
+			SELECT id, company_id, $2, $3, $4 FROM employees WHERE id = $1`,
+			employeeId, contact.Kind, contact.Value, contact.Primary)
+		if isUniqueViolation(err) {
+			return fmt.Errorf("replace contacts: %q: %w", contact.Value, ErrWorkEmailTaken)
+		}
+		if err != nil {
+			return fmt.Errorf("replace contacts: insert: %w", err)
+		}
+	}
+	return nil
+}
+
+// replaceAddresses replaces every address of the employee.
+func replaceAddresses(ctx context.Context, tx pgx.Tx, employeeId int32, addresses []models.Address) error {
+	if _, err := tx.Exec(ctx, "DELETE FROM employee_addresses WHERE employee_id = $1", employeeId); err != nil {
+		return fmt.Errorf("replace addresses: delete: %w", err)
+	}
+
+	for _, address := range addresses {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO employee_addresses
+			    (employee_id, kind, line1, line2, city, region, postal_code, country_code, is_primary)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			employeeId, address.Kind, address.Line1, address.Line2, address.City, address.Region,
+			address.PostalCode, address.CountryCode, address.Primary)
+		if err != nil {
+			return fmt.Errorf("replace addresses: insert: %w", err)
+		}
+	}
+	return nil
+}
+
+// moveContacts follows the employee to another company. The work emails of
+// the employee must not be used in the new company.
+func moveContacts(ctx context.Context, tx pgx.Tx, employeeId, companyId int32) error {
+	_, err := tx.Exec(ctx, "UPDATE employee_contacts SET company_id = $2 WHERE employee_id = $1", employeeId, companyId)
+	if isUniqueViolation(err) {
+		return fmt.Errorf("move contacts: %w", ErrWorkEmailTaken)
+	}
+	if err != nil {
+		return fmt.Errorf("move contacts: %w", err)
+	}
+	return nil
+}
+
+// attachContacts loads the contacts and addresses of the employees.
+func attachContacts(ctx context.Context, q querier, employees ...*models.Employee) error {
+	if len(employees) == 0 {
+		return nil
+	}
+	byId := make(map[int32]*models.Employee, len(employees))
+	ids := make([]int32, 0, len(employees))
+	for _, employee := range employees {
+		byId[employee.Id] = employee
+		ids = append(ids, employee.Id)
+	}
+
+	rows, err := q.Query(ctx, `
+		SELECT employee_id, kind, value, is_primary
+		FROM employee_contacts
+		WHERE employee_id = ANY($1)
+		ORDER BY employee_id, kind, NOT is_primary, id`, ids)
+	if err != nil {
+		return fmt.Errorf("attach contacts: query contacts: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var employeeId int32
+		var contact models.Contact
+		if err = rows.Scan(&employeeId, &contact.Kind, &contact.Value, &contact.Primary); err != nil {
+			return fmt.Errorf("attach contacts: scan contact: %w", err)
+		}
+		byId[employeeId].Contacts = append(byId[employeeId].Contacts, contact)
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("attach contacts: contact rows: %w", err)
+	}
+
+	rows, err = q.Query(ctx, `
+		SELECT employee_id, kind, line1, line2, city, region, postal_code, country_code, is_primary
+		FROM employee_addresses
+		WHERE employee_id = ANY($1)
+		ORDER BY employee_id, kind, NOT is_primary, id`, ids)
+	if err != nil {
+		return fmt.Errorf("attach contacts: query addresses: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var employeeId int32
+		var address models.Address
+		err = rows.Scan(&employeeId, &address.Kind, &address.Line1, &address.Line2, &address.City, &address.Region,
+			&address.PostalCode, &address.CountryCode, &address.Primary)
+		if err != nil {
+			return fmt.Errorf("attach contacts: scan address: %w", err)
+		}
+		byId[employeeId].Addresses = append(byId[employeeId].Addresses, address)
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("attach contacts: address rows: %w", err)
+	}
+	return nil
+}
+
+func employeePointers(employees []models.Employee) []*models.Employee {
+	pointers := make([]*models.Employee, len(employees))
+	for i := range employees {
+		pointers[i] = &employees[i]
+	}
+	return pointers
+}
@@ -0,0 +1,173 @@
+package repositories
+
+import (
+	"context"
+	"employee-service/models"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"math"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidCustomAttribute is returned when a custom attribute value is not
+// defined by the company of the employee, does not match its type, or leaves
+// a required attribute empty.
+var ErrInvalidCustomAttribute = errors.New("invalid custom attribute")
+
+const maxAttributeValueLength = 255
+
+// setCustomAttributes validates the values against the attributes of the
+// company of the employee and merges them into the current ones. An empty
+// value removes the attribute. When creating, every required attribute must
+// be given.
+func setCustomAttributes(ctx context.Context, tx pgx.Tx, employeeId int32, values map[string]string, creating bool) error {
+	var companyId int32
+	err := tx.QueryRow(ctx, "SELECT company_id FROM employees WHERE id = $1", employeeId).Scan(&companyId)
+	if err != nil {
+		return fmt.Errorf("set custom attributes: query row company id: %w", err)
+	}
+
+	definitions, err := companyAttributes(ctx, tx, companyId)
+	if err != nil {
+		return fmt.Errorf("set custom attributes: %w", err)
+	}
+
+	set, removed := map[string]interface{}{}, []string{}
+	for key, value := range values {
+		definition, ok := findAttribute(definitions, key)
+		if !ok {
+			return fmt.Errorf("set custom attributes: %w: %q is not defined by company %d",
+				ErrInvalidCustomAttribute, key, companyId)
+		}
+		if value == "" {
+			if definition.Required {
+				return fmt.Errorf("set custom attributes: %w: %q is required", ErrInvalidCustomAttribute, key)
+			}
+			removed = append(removed, key)
+			continue
+		}
+		if set[key], err = attributeValue(definition, value); err != nil {
+			return fmt.Errorf("set custom attributes: %w", err)
+		}
+	}
+	if creating {
+		for _, definition := range definitions {
+			if _, ok := set[definition.Key]; definition.Required && !ok {
+				return fmt.Errorf("set custom attributes: %w: %q is required",
+					ErrInvalidCustomAttribute, definition.Key)
+			}
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE employees SET custom_attributes = (custom_attributes || $2::JSONB) - $3::TEXT[]
+		WHERE id = $1`, employeeId, set, removed)
+	if err != nil {
+		return fmt.Errorf("set custom attributes: update employee: %w", err)
+	}
+	return nil
+}
+
+// dropForeignAttributes removes the custom attributes that the current
+// company of the employee does not define, after a move to another company.
+func dropForeignAttributes(ctx context.Context, tx pgx.Tx, employeeId int32) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE employees AS e SET custom_attributes = COALESCE((
+			SELECT jsonb_object_agg(a.key, a.value)
+			FROM jsonb_each(e.custom_attributes) AS a
+			JOIN attribute_definitions AS ad ON ad.company_id = e.company_id AND ad.key = a.key), '{}')
+		WHERE e.id = $1`, employeeId)
+	if err != nil {
+		return fmt.Errorf("drop foreign attributes: %w", err)
+	}
+	return nil
+}
+
+// attributeFilter converts the values to the JSON stored for the custom
+// attributes of the company, to select employees with all of them.
+func attributeFilter(ctx context.Context, q querier, companyId int32, values map[string]string) (map[string]interface{}, error) {
+	filter := map[string]interface{}{}
+	if len(values) == 0 {
+		return filter, nil
+	}
+
+	definitions, err := companyAttributes(ctx, q, companyId)
+	if err != nil {
+		return nil, fmt.Errorf("attribute filter: %w", err)
+	}
+	for key, value := range values {
+		definition, ok := findAttribute(definitions, key)
+		if !ok {
+			return nil, fmt.Errorf("attribute filter: %w: %q is not defined by company %d",
+				ErrInvalidCustomAttribute, key, companyId)
+		}
+		if filter[key], err = attributeValue(definition, value); err != nil {
+			return nil, fmt.Errorf("attribute filter: %w", err)
+		}
+	}
+	return filter, nil
+}
+
+func findAttribute(definitions []models.AttributeDefinition, key string) (models.AttributeDefinition, bool) {
+	for _, definition := range definitions {
+		if definition.Key == key {
+			return definition, true
+		}
+	}
+	return models.AttributeDefinition{}, false
+}
+
+// attributeValue parses the value of the attribute. Numbers are stored as
+// JSON numbers and the other types as strings, dates as YYYY-MM-DD.
+func attributeValue(definition models.AttributeDefinition, value string) (interface{}, error) {
+	switch definition.Type {
+	case models.AttributeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsInf(number, 0) || math.IsNaN(number) {
+			return nil, fmt.Errorf("%w: %q must be a number, got %q", ErrInvalidCustomAttribute, definition.Key, value)
+		}
+		return number, nil
+	case models.AttributeDate:
+		date, err := time.Parse(models.DateLayout, value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q must be a YYYY-MM-DD date, got %q", ErrInvalidCustomAttribute,
+				definition.Key, value)
+		}
+		return date.Format(models.DateLayout), nil
+	case models.AttributeEnum:
+		for _, option := range definition.Options {
+			if value == option {
+				return value, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: %q must be one of %q, got %q", ErrInvalidCustomAttribute, definition.Key,
+			definition.Options, value)
+	default:
+		if value == "" || len(value) > maxAttributeValueLength {
+			return nil, fmt.Errorf("%w: %q must be between 1 and %d bytes", ErrInvalidCustomAttribute,
+				definition.Key, maxAttributeValueLength)
+		}
+		return value, nil
+	}
+}
+
+// formatAttributes converts stored custom attributes to strings.
+func formatAttributes(stored map[string]interface{}) map[string]string {
+	if len(stored) == 0 {
+		return nil
+	}
+	values := make(map[string]string, len(stored))
+	for key, value := range stored {
+		switch value := value.(type) {
+		case string:
+			values[key] = value
+		case float64:
+			values[key] = strconv.FormatFloat(value, 'f', -1, 64)
+		default:
+			values[key] = fmt.Sprint(value)
+		}
+	}
+	return values
+}
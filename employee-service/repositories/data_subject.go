@@ -0,0 +1,195 @@
+package repositories
+
+import (
+	"context"
+	"employee-service/metrics"
+	"employee-service/models"
+	"employee-service/tracing"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"time"
+)
+
+var ErrEmployeeNotFound = errors.New("employee not found")
+
+// erasedValue replaces names of erased employees. The row itself is kept so
+// that foreign keys and headcount aggregates stay intact.
+const erasedValue = "[erased]"
+
+// ExportEmployeeData returns every stored attribute of the employee together
+// with the audit history, and records the export itself in the audit log.
+func (r *EmployeeRepository) ExportEmployeeData(ctx context.Context, id int32) (models.EmployeeDossier, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.ExportEmployeeData")
+	defer span.End()
+	defer metrics.ObserveQuery("export_employee_data", time.Now())
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return models.EmployeeDossier{}, fmt.Errorf("employee_repo: export_employee_data: acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return models.EmployeeDossier{}, fmt.Errorf("employee_repo: export_employee_data: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var dossier models.EmployeeDossier
+
+	row := tx.QueryRow(ctx, `
+		SELECT`+employeeColumns+`, e.erased_at
+		FROM employees AS e
+		JOIN departments AS d ON e.department_id = d.id
+		JOIN passports AS p ON e.passport_id = p.id
+		WHERE e.id = $1`, id)
+	dossier.Employee, err = r.scanEmployee(row, &dossier.ErasedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.EmployeeDossier{}, fmt.Errorf("employee_repo: export_employee_data: %w", ErrEmployeeNotFound)
+	}
+	if err != nil {
+		return models.EmployeeDossier{}, fmt.Errorf("employee_repo: export_employee_data: %w", err)
+	}
+
+	if err = attachContacts(ctx, tx, &dossier.Employee); err != nil {
+		return models.EmployeeDossier{}, fmt.Errorf("employee_repo: export_employee_data: %w", err)
+	}
+
+	dossier.EmergencyContacts, err = emergencyContacts(ctx, tx, id)
+	if err != nil {
+		return models.EmployeeDossier{}, fmt.Errorf("employee_repo: export_employee_data: %w", err)
+	}
+
+	dossier.LeaveBalances, err = employeeLeaveBalances(ctx, tx, dossier.Employee.CompanyId, id)
+	if err != nil {
+		return models.EmployeeDossier{}, fmt.Errorf("employee_repo: export_employee_data: %w", err)
+	}
+	dossier.LeaveRequests, err = employeeLeaveRequests(ctx, tx, id, nil)
+	if err != nil {
+		return models.EmployeeDossier{}, fmt.Errorf("employee_repo: export_employee_data: %w", err)
+	}
+	dossier.LeaveLedger, err = leaveLedger(ctx, tx, id)
+	if err != nil {
+		return models.EmployeeDossier{}, fmt.Errorf("employee_repo: export_employee_data: %w", err)
+	}
+	dossier.Transfers, err = employeeTransfers(ctx, tx, id)
+	if err != nil {
+		return models.EmployeeDossier{}, fmt.Errorf("employee_repo: export_employee_data: %w", err)
+	}
+	dossier.Assignments, err = employeeAssignments(ctx, tx, id)
+	if err != nil {
+		return models.EmployeeDossier{}, fmt.Errorf("employee_repo: export_employee_data: %w", err)
+	}
+
+	if err = recordAudit(ctx, tx, id, models.AuditEmployeeExported, nil); err != nil {
+		return models.EmployeeDossier{}, fmt.Errorf("employee_repo: export_employee_data: %w", err)
+	}
+
+	dossier.AuditHistory, err = auditHistory(ctx, tx, id)
+	if err != nil {
+		return models.EmployeeDossier{}, fmt.Errorf("employee_repo: export_employee_data: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.EmployeeDossier{}, fmt.Errorf("employee_repo: export_employee_data: commit transaction: %w", err)
+	}
+
+	dossier.ExportedAt = time.Now().UTC()
+	return dossier, nil
+}
+
+// EraseEmployee irreversibly anonymizes the personal data of the employee.
+// Passport ciphertexts and blind indexes are dropped, so the number cannot be
+// recovered even with the keyring.
+func (r *EmployeeRepository) EraseEmployee(ctx context.Context, id int32) error {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.EraseEmployee")
+	defer span.End()
+	defer metrics.ObserveQuery("erase_employee", time.Now())
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("employee_repo: erase_employee: acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("employee_repo: erase_employee: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var passportId int32
+	err = tx.QueryRow(ctx, `
+		UPDATE employees
+		SET name = $1, surname = $1, phone = '', custom_attributes = '{}', termination_reason = '', erased_at = now()
+		WHERE id = $2
+		RETURNING passport_id`, erasedValue, id).Scan(&passportId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("employee_repo: erase_employee: %w", ErrEmployeeNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("employee_repo: erase_employee: anonymize employee: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE passports
+		SET type = '', number = NULL, number_ciphertext = NULL, number_dek = NULL,
+		    number_key_id = NULL, number_index = NULL
+		WHERE id = $1`, passportId)
+	if err != nil {
+		return fmt.Errorf("employee_repo: erase_employee: anonymize passport: %w", err)
+	}
+
+	if err = replaceContacts(ctx, tx, id, nil); err != nil {
+		return fmt.Errorf("employee_repo: erase_employee: %w", err)
+	}
+	if err = replaceAddresses(ctx, tx, id, nil); err != nil {
+		return fmt.Errorf("employee_repo: erase_employee: %w", err)
+	}
+	if _, err = tx.Exec(ctx, "DELETE FROM emergency_contacts WHERE employee_id = $1", id); err != nil {
+		return fmt.Errorf("employee_repo: erase_employee: delete emergency contacts: %w", err)
+	}
+
+	// Dates and days of leave stay for balances and headcount reports.
+	_, err = tx.Exec(ctx, "UPDATE leave_requests SET reason = '', decision_comment = '' WHERE employee_id = $1", id)
+	if err != nil {
+		return fmt.Errorf("employee_repo: erase_employee: clear leave requests: %w", err)
+	}
+	if _, err = tx.Exec(ctx, "UPDATE leave_ledger SET reason = '' WHERE employee_id = $1", id); err != nil {
+		return fmt.Errorf("employee_repo: erase_employee: clear leave ledger: %w", err)
+	}
+	if _, err = tx.Exec(ctx, "UPDATE transfers SET reason = '' WHERE employee_id = $1", id); err != nil {
+		return fmt.Errorf("employee_repo: erase_employee: clear transfer reasons: %w", err)
+	}
+
+	if err = recordAudit(ctx, tx, id, models.AuditEmployeeErased, nil); err != nil {
+		return fmt.Errorf("employee_repo: erase_employee: %w", err)
+	}
+
+	// Earlier events and their webhook deliveries carry the personal data of
+	// the employee, whether they are sent yet or not. The outbox lock keeps
+	// the relay from publishing them while they are deleted.
+	if _, err = tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", outboxLockKey); err != nil {
+		return fmt.Errorf("employee_repo: erase_employee: lock outbox: %w", err)
+	}
+	_, err = tx.Exec(ctx, "DELETE FROM webhook_deliveries WHERE (payload ->> 'employee_id')::INT = $1", id)
+	if err != nil {
+		return fmt.Errorf("employee_repo: erase_employee: delete webhook deliveries: %w", err)
+	}
+	if _, err = tx.Exec(ctx, "DELETE FROM outbox WHERE employee_id = $1", id); err != nil {
+		return fmt.Errorf("employee_repo: erase_employee: delete events: %w", err)
+	}
+
+	err = recordEvent(ctx, tx, models.EventEmployeeUpdated, id, []string{"name", "surname", "phone", "passport.type",
+		"contacts", "addresses", "custom_attributes", "termination_reason"})
+	if err != nil {
+		return fmt.Errorf("employee_repo: erase_employee: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("employee_repo: erase_employee: commit transaction: %w", err)
+	}
+
+	return nil
+}
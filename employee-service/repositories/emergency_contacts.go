@@ -0,0 +1,199 @@
+package repositories
+
+import (
+	"context"
+	"employee-service/metrics"
+	"employee-service/models"
+	"employee-service/tracing"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"time"
+)
+
+// maxEmergencyContacts bounds the emergency contacts of an employee.
+const maxEmergencyContacts = 5
+
+var (
+	ErrEmergencyContactNotFound = errors.New("emergency contact not found")
+	// ErrTooManyEmergencyContacts is returned when an employee already has
+	// maxEmergencyContacts emergency contacts.
+	ErrTooManyEmergencyContacts = errors.New("too many emergency contacts")
+)
+
+const emergencyContactColumns = "id, employee_id, name, relation, phones, created_at, updated_at"
+
+func scanEmergencyContact(row pgx.Row) (models.EmergencyContact, error) {
+	var contact models.EmergencyContact
+	err := row.Scan(&contact.Id, &contact.EmployeeId, &contact.Name, &contact.Relation, &contact.Phones,
+		&contact.CreatedAt, &contact.UpdatedAt)
+	if err != nil {
+		return models.EmergencyContact{}, fmt.Errorf("scan emergency contact: %w", err)
+	}
+	return contact, nil
+}
+
+func (r *EmployeeRepository) AddEmergencyContact(ctx context.Context, contact models.EmergencyContact) (models.EmergencyContact, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.AddEmergencyContact")
+	defer span.End()
+	defer metrics.ObserveQuery("add_emergency_contact", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.EmergencyContact{}, fmt.Errorf("employee_repo: add_emergency_contact: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Locking the employee serializes the count check of concurrent adds.
+	var count int
+	err = tx.QueryRow(ctx, `
+		SELECT (SELECT COUNT(*) FROM emergency_contacts WHERE employee_id = e.id)
+		FROM employees AS e
+		WHERE e.id = $1
+		FOR UPDATE`, contact.EmployeeId).Scan(&count)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.EmergencyContact{}, fmt.Errorf("employee_repo: add_emergency_contact: %w", ErrEmployeeNotFound)
+	}
+	if err != nil {
+		return models.EmergencyContact{}, fmt.Errorf("employee_repo: add_emergency_contact: lock employee: %w", err)
+	}
+	if count >= maxEmergencyContacts {
+		return models.EmergencyContact{}, fmt.Errorf("employee_repo: add_emergency_contact: at most %d: %w",
+			maxEmergencyContacts, ErrTooManyEmergencyContacts)
+	}
+
+	row := tx.QueryRow(ctx, `
+		INSERT INTO emergency_contacts (employee_id, name, relation, phones)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+emergencyContactColumns,
+		contact.EmployeeId, contact.Name, contact.Relation, contact.Phones)
+	created, err := scanEmergencyContact(row)
+	if err != nil {
+		return models.EmergencyContact{}, fmt.Errorf("employee_repo: add_emergency_contact: insert: %w", err)
+	}
+
+	err = recordAudit(ctx, tx, contact.EmployeeId, models.AuditEmergencyContactsChanged,
+		map[string]interface{}{"operation": "added", "emergency_contact_id": created.Id})
+	if err != nil {
+		return models.EmergencyContact{}, fmt.Errorf("employee_repo: add_emergency_contact: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.EmergencyContact{}, fmt.Errorf("employee_repo: add_emergency_contact: commit transaction: %w", err)
+	}
+	return created, nil
+}
+
+func (r *EmployeeRepository) ListEmergencyContacts(ctx context.Context, employeeId int32) ([]models.EmergencyContact, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.ListEmergencyContacts")
+	defer span.End()
+	defer metrics.ObserveQuery("list_emergency_contacts", time.Now())
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("employee_repo: list_emergency_contacts: acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if err = employeeExists(ctx, conn, employeeId); err != nil {
+		return nil, fmt.Errorf("employee_repo: list_emergency_contacts: %w", err)
+	}
+
+	contacts, err := emergencyContacts(ctx, conn, employeeId)
+	if err != nil {
+		return nil, fmt.Errorf("employee_repo: list_emergency_contacts: %w", err)
+	}
+	return contacts, nil
+}
+
+// UpdateEmergencyContact replaces the name, relation and phones of an
+// emergency contact of the employee.
+func (r *EmployeeRepository) UpdateEmergencyContact(ctx context.Context, contact models.EmergencyContact) (models.EmergencyContact, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.UpdateEmergencyContact")
+	defer span.End()
+	defer metrics.ObserveQuery("update_emergency_contact", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.EmergencyContact{}, fmt.Errorf("employee_repo: update_emergency_contact: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	row := tx.QueryRow(ctx, `
+		UPDATE emergency_contacts
+		SET name = $3, relation = $4, phones = $5, updated_at = now()
+		WHERE id = $1 AND employee_id = $2
+		RETURNING `+emergencyContactColumns,
+		contact.Id, contact.EmployeeId, contact.Name, contact.Relation, contact.Phones)
+	updated, err := scanEmergencyContact(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.EmergencyContact{}, fmt.Errorf("employee_repo: update_emergency_contact: %w", ErrEmergencyContactNotFound)
+	}
+	if err != nil {
+		return models.EmergencyContact{}, fmt.Errorf("employee_repo: update_emergency_contact: %w", err)
+	}
+
+	err = recordAudit(ctx, tx, contact.EmployeeId, models.AuditEmergencyContactsChanged,
+		map[string]interface{}{"operation": "updated", "emergency_contact_id": contact.Id})
+	if err != nil {
+		return models.EmergencyContact{}, fmt.Errorf("employee_repo: update_emergency_contact: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.EmergencyContact{}, fmt.Errorf("employee_repo: update_emergency_contact: commit transaction: %w", err)
+	}
+	return updated, nil
+}
+
+func (r *EmployeeRepository) DeleteEmergencyContact(ctx context.Context, employeeId, id int32) error {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.DeleteEmergencyContact")
+	defer span.End()
+	defer metrics.ObserveQuery("delete_emergency_contact", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("employee_repo: delete_emergency_contact: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, "DELETE FROM emergency_contacts WHERE id = $1 AND employee_id = $2", id, employeeId)
+	if err != nil {
+		return fmt.Errorf("employee_repo: delete_emergency_contact: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("employee_repo: delete_emergency_contact: %w", ErrEmergencyContactNotFound)
+	}
+
+	err = recordAudit(ctx, tx, employeeId, models.AuditEmergencyContactsChanged,
+		map[string]interface{}{"operation": "deleted", "emergency_contact_id": id})
+	if err != nil {
+		return fmt.Errorf("employee_repo: delete_emergency_contact: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("employee_repo: delete_emergency_contact: commit transaction: %w", err)
+	}
+	return nil
+}
+
+func emergencyContacts(ctx context.Context, q querier, employeeId int32) ([]models.EmergencyContact, error) {
+	rows, err := q.Query(ctx, "SELECT "+emergencyContactColumns+" FROM emergency_contacts WHERE employee_id = $1 ORDER BY id",
+		employeeId)
+	if err != nil {
+		return nil, fmt.Errorf("emergency contacts: query: %w", err)
+	}
+	defer rows.Close()
+
+	var contacts []models.EmergencyContact
+	for rows.Next() {
+		contact, err := scanEmergencyContact(rows)
+		if err != nil {
+			return nil, fmt.Errorf("emergency contacts: %w", err)
+		}
+		contacts = append(contacts, contact)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("emergency contacts: rows: %w", err)
+	}
+	return contacts, nil
+}
@@ -2,26 +2,54 @@ package repositories
 
 import (
 	"context"
+	"employee-service/encryption"
+	"employee-service/metrics"
 	"employee-service/models"
+	"employee-service/tracing"
+	"errors"
 	"fmt"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"strings"
+	"time"
 )
 
 type EmployeeRepositoryInterface interface {
-	AddEmployee(ctx context.Context, employee models.Employee) (int32, error)
+	AddEmployee(ctx context.Context, employee models.Employee, key *models.IdempotencyKey) (int32, bool, error)
 	DeleteEmployee(ctx context.Context, id int32) error
-	ShowCompanyEmployees(ctx context.Context, companyId int32, department models.Department) ([]models.Employee, error)
+	ShowCompanyEmployees(ctx context.Context, companyId int32, department models.Department, statuses []string, attributes map[string]string) ([]models.Employee, error)
 	UpdateEmployee(ctx context.Context, employee models.Employee) error
+	DirectReports(ctx context.Context, id int32) ([]models.Employee, error)
+	ReportingChain(ctx context.Context, id int32) ([]models.Employee, error)
+	OrgSubtree(ctx context.Context, id int32, maxDepth int32) ([]models.OrgNode, error)
+	Hire(ctx context.Context, id int32, hireDate *time.Time, onboarding bool) (models.Employment, error)
+	Rehire(ctx context.Context, id int32, hireDate *time.Time, onboarding bool) (models.Employment, error)
+	Terminate(ctx context.Context, id int32, terminationDate *time.Time, reason string) (models.Employment, error)
+	SetOnLeave(ctx context.Context, id int32, onLeave bool) (models.Employment, error)
+	TransferEmployee(ctx context.Context, transfer models.Transfer) (models.Transfer, error)
+	CancelTransfer(ctx context.Context, employeeId, id int32) (models.Transfer, error)
+	ListTransfers(ctx context.Context, employeeId int32) ([]models.Transfer, error)
+	AssignmentHistory(ctx context.Context, employeeId int32) ([]models.Assignment, error)
+	AddEmergencyContact(ctx context.Context, contact models.EmergencyContact) (models.EmergencyContact, error)
+	ListEmergencyContacts(ctx context.Context, employeeId int32) ([]models.EmergencyContact, error)
+	UpdateEmergencyContact(ctx context.Context, contact models.EmergencyContact) (models.EmergencyContact, error)
+	DeleteEmergencyContact(ctx context.Context, employeeId, id int32) error
+	ApplyDueTransfers(ctx context.Context, limit int) ([]models.Transfer, error)
+	EncryptPassports(ctx context.Context) (int, error)
+	ExportEmployeeData(ctx context.Context, id int32) (models.EmployeeDossier, error)
+	EraseEmployee(ctx context.Context, id int32) error
+	BatchAddEmployees(ctx context.Context, items []models.AddItem, allOrNothing bool) ([]models.BatchResult, error)
+	BatchUpdateEmployees(ctx context.Context, employees []models.Employee, allOrNothing bool) ([]models.BatchResult, error)
+	BatchDeleteEmployees(ctx context.Context, ids []int32, allOrNothing bool) ([]models.BatchResult, error)
 }
 
 type EmployeeRepository struct {
-	db *pgxpool.Pool
+	db      *pgxpool.Pool
+	keyring *encryption.Keyring
 }
 
-func NewEmployeeRepository(db *pgxpool.Pool) *EmployeeRepository {
-	return &EmployeeRepository{db: db}
+func NewEmployeeRepository(db *pgxpool.Pool, keyring *encryption.Keyring) *EmployeeRepository {
+	return &EmployeeRepository{db: db, keyring: keyring}
 }
 
 func departmentExists(ctx context.Context, tx pgx.Tx, department models.Department) (bool, error) {
@@ -56,61 +84,161 @@ func createOrGetDepartmentId(ctx context.Context, tx pgx.Tx, departExists bool,
 	return departId, nil
 }
 
-func (r *EmployeeRepository) AddEmployee(ctx context.Context, employee models.Employee) (int32, error) {
+// AddEmployee creates the employee. With an idempotency key, a retry of a
+// request that already succeeded returns the id created the first time and
+// reports it as replayed instead of creating another employee.
+func (r *EmployeeRepository) AddEmployee(ctx context.Context, employee models.Employee, key *models.IdempotencyKey) (int32, bool, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.AddEmployee")
+	defer span.End()
+	defer metrics.ObserveQuery("add_employee", time.Now())
+
 	conn, err := r.db.Acquire(ctx)
 	if err != nil {
 		err = fmt.Errorf("employee_repo: add_employee: acquire connection: %w", err)
-		return 0, err
+		return 0, false, err
 	}
 	defer conn.Release()
 
 	tx, err := conn.Begin(ctx)
 	if err != nil {
 		err = fmt.Errorf("employee_repo: add_employee: begin transaction: %w", err)
-		return 0, err
+		return 0, false, err
 	}
 	defer tx.Rollback(ctx)
 
+	employeeId, replayed, err := r.addEmployee(ctx, tx, employee, key)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, false, fmt.Errorf("employee_repo: add_employee: commit transaction: %w", err)
+	}
+
+	return employeeId, replayed, nil
+}
+
+func (r *EmployeeRepository) addEmployee(ctx context.Context, tx pgx.Tx, employee models.Employee, key *models.IdempotencyKey) (int32, bool, error) {
+	if key != nil {
+		existingId, claimed, err := claimIdempotencyKey(ctx, tx, *key)
+		if err != nil {
+			return 0, false, fmt.Errorf("employee_repo: add_employee: %w", err)
+		}
+		if !claimed {
+			return existingId, true, nil
+		}
+	}
+
 	var departmentId int32
 	departExists, err := departmentExists(ctx, tx, employee.Department)
 	if err != nil {
-		return 0, fmt.Errorf("employee_repo: add_employee: %w", err)
+		return 0, false, fmt.Errorf("employee_repo: add_employee: %w", err)
 	}
 
 	departmentId, err = createOrGetDepartmentId(ctx, tx, departExists, employee.Department)
 	if err != nil {
-		return 0, fmt.Errorf("employee_repo: add_employee: %w", err)
+		return 0, false, fmt.Errorf("employee_repo: add_employee: %w", err)
 	}
 
 	var passportId int32
 
-	err = tx.QueryRow(ctx, "INSERT INTO passports (type, number) VALUES ($1, $2) RETURNING id",
-		employee.Passport.Type, employee.Passport.Number).Scan(&passportId)
+	number, err := r.encryptPassportNumber(employee.Passport.Number)
+	if err != nil {
+		return 0, false, fmt.Errorf("employee_repo: add_employee: %w", err)
+	}
+
+	if number.index != nil {
+		registered, err := passportRegistered(ctx, tx, *number.index, 0)
+		if err != nil {
+			return 0, false, fmt.Errorf("employee_repo: add_employee: %w", err)
+		}
+		if registered {
+			return 0, false, fmt.Errorf("employee_repo: add_employee: passport number already registered")
+		}
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO passports (type, number_ciphertext, number_dek, number_key_id, number_index)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`,
+		employee.Passport.Type, number.ciphertext, number.dek, number.keyId, number.index).Scan(&passportId)
 	if err != nil {
 		err = fmt.Errorf("employee_repo: add_employee: insert passport: %w", err)
-		return 0, err
+		return 0, false, err
 	}
 
 	insertQuery := `
-		INSERT INTO employees (name, surname, phone, company_id, passport_id, department_id) 
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO employees (name, surname, phone, company_id, passport_id, department_id, status, hire_date) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, CASE WHEN $7 = 'candidate' THEN NULL ELSE COALESCE($8, CURRENT_DATE) END)
 		RETURNING id`
 
+	status := employee.Employment.Status
+	if status == "" {
+		status = models.StatusActive
+	}
+
+	var employeeId int32
 	err = tx.QueryRow(ctx, insertQuery, employee.Name, employee.Surname, employee.Phone, employee.CompanyId,
-		passportId, departmentId).Scan(&passportId)
+		passportId, departmentId, status, employee.Employment.HireDate).Scan(&employeeId)
 	if err != nil {
 		err = fmt.Errorf("employee_repo: add_employee: insert employee: %w", err)
-		return 0, err
+		return 0, false, err
 	}
 
-	if err = tx.Commit(ctx); err != nil {
-		return 0, fmt.Errorf("employee_repo: add_employee: commit transaction: %w", err)
+	if err = replaceContacts(ctx, tx, employeeId, employee.Contacts); err != nil {
+		return 0, false, fmt.Errorf("employee_repo: add_employee: %w", err)
+	}
+	if err = replaceAddresses(ctx, tx, employeeId, employee.Addresses); err != nil {
+		return 0, false, fmt.Errorf("employee_repo: add_employee: %w", err)
+	}
+	if err = setCustomAttributes(ctx, tx, employeeId, employee.CustomAttributes, true); err != nil {
+		return 0, false, fmt.Errorf("employee_repo: add_employee: %w", err)
 	}
 
-	return passportId, nil
+	if employee.ManagerId != nil && *employee.ManagerId != 0 {
+		if err = setManager(ctx, tx, employeeId, *employee.ManagerId); err != nil {
+			return 0, false, fmt.Errorf("employee_repo: add_employee: %w", err)
+		}
+	}
+
+	if employee.PositionId != nil && *employee.PositionId != 0 {
+		if err = setPosition(ctx, tx, employeeId, *employee.PositionId); err != nil {
+			return 0, false, fmt.Errorf("employee_repo: add_employee: %w", err)
+		}
+	}
+
+	startedOn := today()
+	if employee.Employment.HireDate != nil {
+		startedOn = *employee.Employment.HireDate
+	}
+	if err = recordAssignment(ctx, tx, employeeId, startedOn, nil); err != nil {
+		return 0, false, fmt.Errorf("employee_repo: add_employee: %w", err)
+	}
+
+	err = recordAudit(ctx, tx, employeeId, models.AuditEmployeeCreated,
+		map[string]interface{}{"company_id": employee.CompanyId})
+	if err != nil {
+		return 0, false, fmt.Errorf("employee_repo: add_employee: %w", err)
+	}
+
+	if err = recordEvent(ctx, tx, models.EventEmployeeCreated, employeeId, nil); err != nil {
+		return 0, false, fmt.Errorf("employee_repo: add_employee: %w", err)
+	}
+
+	if key != nil {
+		if err = completeIdempotencyKey(ctx, tx, *key, employeeId); err != nil {
+			return 0, false, fmt.Errorf("employee_repo: add_employee: %w", err)
+		}
+	}
+
+	return employeeId, false, nil
 }
 
 func (r *EmployeeRepository) DeleteEmployee(ctx context.Context, id int32) error {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.DeleteEmployee")
+	defer span.End()
+	defer metrics.ObserveQuery("delete_employee", time.Now())
+
 	conn, err := r.db.Acquire(ctx)
 	if err != nil {
 		err = fmt.Errorf("employee_repo: delete_employee: acquire connection: %w", err)
@@ -125,15 +253,34 @@ func (r *EmployeeRepository) DeleteEmployee(ctx context.Context, id int32) error
 	}
 	defer tx.Rollback(ctx)
 
+	if err = deleteEmployee(ctx, tx, id); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("employee_repo: delete_employee: commit error: %w", err)
+	}
+
+	return nil
+}
+
+func deleteEmployee(ctx context.Context, tx pgx.Tx, id int32) error {
 	var passportId, departmentId int32
 
-	err = tx.QueryRow(ctx, "SELECT passport_id, department_id FROM employees WHERE id = $1", id).
+	err := tx.QueryRow(ctx, "SELECT passport_id, department_id FROM employees WHERE id = $1", id).
 		Scan(&passportId, &departmentId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("employee_repo: delete_employee: %w", ErrEmployeeNotFound)
+	}
 	if err != nil {
 		err = fmt.Errorf("employee_repo: delete_employee: pass and department id: %w", err)
 		return err
 	}
 
+	if err = recordEvent(ctx, tx, models.EventEmployeeDeleted, id, nil); err != nil {
+		return fmt.Errorf("employee_repo: delete_employee: %w", err)
+	}
+
 	_, err = tx.Exec(ctx, "DELETE FROM employees WHERE id = $1", id)
 	if err != nil {
 		err = fmt.Errorf("employee_repo: delete_employee: delete employee: %w", err)
@@ -146,30 +293,25 @@ func (r *EmployeeRepository) DeleteEmployee(ctx context.Context, id int32) error
 		return err
 	}
 
-	var leftDepartment bool
-	err = tx.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM employees WHERE department_id = $1)", departmentId).
-		Scan(&leftDepartment)
-	if err != nil {
-		err = fmt.Errorf("employee_repo: delete_employee: query row department: %w", err)
-		return err
+	if err = cleanUnusedDepartments(ctx, tx, departmentId); err != nil {
+		return fmt.Errorf("employee_repo: delete_employee: %w", err)
 	}
 
-	if !leftDepartment {
-		_, err = tx.Exec(ctx, "DELETE FROM departments WHERE id = $1", departmentId)
-		if err != nil {
-			err = fmt.Errorf("employee_repo: delete_employee: delete department: %w", err)
-			return err
-		}
-	}
-
-	if err = tx.Commit(ctx); err != nil {
-		return fmt.Errorf("employee_repo: delete_employee: commit error: %w", err)
+	if err = recordAudit(ctx, tx, id, models.AuditEmployeeDeleted, nil); err != nil {
+		return fmt.Errorf("employee_repo: delete_employee: %w", err)
 	}
 
 	return nil
 }
 
-func (r *EmployeeRepository) ShowCompanyEmployees(ctx context.Context, companyId int32, department models.Department) ([]models.Employee, error) {
+// ShowCompanyEmployees lists the employees of the company, optionally only
+// those of a department or with one of statuses. No statuses means all.
+// Employees must have every custom attribute value in attributes.
+func (r *EmployeeRepository) ShowCompanyEmployees(ctx context.Context, companyId int32, department models.Department, statuses []string, attributes map[string]string) ([]models.Employee, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.ShowCompanyEmployees")
+	defer span.End()
+	defer metrics.ObserveQuery("show_company_employees", time.Now())
+
 	conn, err := r.db.Acquire(ctx)
 	if err != nil {
 		err = fmt.Errorf("employee_repo: show_department_employee: acquire connection: %w", err)
@@ -177,28 +319,34 @@ func (r *EmployeeRepository) ShowCompanyEmployees(ctx context.Context, companyId
 	}
 	defer conn.Release()
 
+	filter, err := attributeFilter(ctx, conn, companyId, attributes)
+	if err != nil {
+		return nil, fmt.Errorf("employee_repo: show_department_employee: %w", err)
+	}
+
 	query := `
-		SELECT e.id, e.name, e.surname, e.phone, e.company_id,
-		       p.type, p.number,
-		       d.name, d.phone
+		SELECT` + employeeColumns + `
 		FROM employees AS e
 		JOIN departments AS d ON e.department_id = d.id AND e.company_id = $1 %s
-		JOIN passports AS p ON e.passport_id = p.id`
+		JOIN passports AS p ON e.passport_id = p.id
+		WHERE (COALESCE(cardinality($2::TEXT[]), 0) = 0 OR e.status = ANY($2))
+		  AND e.custom_attributes @> $3::JSONB
+		ORDER BY e.id`
 
 	args := []interface{}{}
-	args = append(args, companyId)
+	args = append(args, companyId, statuses, filter)
 
 	switch {
 	case department.Name == "" && department.Phone == "":
 		query = fmt.Sprintf(query, "")
 	case department.Name != "" && department.Phone == "":
-		query = fmt.Sprintf(query, "AND d.name = $2")
+		query = fmt.Sprintf(query, "AND d.name = $4")
 		args = append(args, department.Name)
 	case department.Name == "" && department.Phone != "":
-		query = fmt.Sprintf(query, "AND d.phone = $2")
+		query = fmt.Sprintf(query, "AND d.phone = $4")
 		args = append(args, department.Phone)
 	default:
-		query = fmt.Sprintf(query, "AND d.name = $2 AND d.phone = $3")
+		query = fmt.Sprintf(query, "AND d.name = $4 AND d.phone = $5")
 		args = append(args, department.Name)
 		args = append(args, department.Phone)
 	}
@@ -213,35 +361,68 @@ func (r *EmployeeRepository) ShowCompanyEmployees(ctx context.Context, companyId
 	var employees []models.Employee
 
 	for rows.Next() {
-		var employee models.Employee
-		var passport models.Passport
-		var department2 models.Department
-		err = rows.Scan(&employee.Id, &employee.Name, &employee.Surname, &employee.Phone, &employee.CompanyId,
-			&passport.Type, &passport.Number, &department2.Name, &department2.Phone)
-
+		employee, err := r.scanEmployee(rows)
 		if err != nil {
-			err = fmt.Errorf("employee_repo: show_department_employee: scan: %w", err)
-			return nil, err
+			return nil, fmt.Errorf("employee_repo: show_department_employee: %w", err)
 		}
 
-		employee.Passport = passport
-		employee.Department = department2
-
 		employees = append(employees, employee)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("employee_repo: show_department_employee: read rows: %w", err)
+	}
+
+	if err = attachContacts(ctx, conn, employeePointers(employees)...); err != nil {
+		return nil, fmt.Errorf("employee_repo: show_department_employee: %w", err)
+	}
 
 	return employees, nil
 }
 
+// employeeColumns are the columns read by scanEmployee, for queries joining
+// employees AS e, passports AS p and departments AS d. Columns selected after
+// them are scanned into extra.
+const employeeColumns = `
+		e.id, e.name, e.surname, e.phone, e.company_id,
+		p.type, p.number, p.number_ciphertext, p.number_dek, p.number_key_id,
+		d.name, d.phone, e.manager_id, e.position_id,
+		e.status, e.hire_date, e.termination_date, e.termination_reason, e.custom_attributes`
+
+func (r *EmployeeRepository) scanEmployee(row pgx.Row, extra ...interface{}) (models.Employee, error) {
+	var employee models.Employee
+	var number storedPassportNumber
+	var attributes map[string]interface{}
+	dest := []interface{}{&employee.Id, &employee.Name, &employee.Surname, &employee.Phone, &employee.CompanyId,
+		&employee.Passport.Type, &number.plaintext, &number.ciphertext, &number.dek, &number.keyId,
+		&employee.Department.Name, &employee.Department.Phone, &employee.ManagerId,
+		&employee.PositionId, &employee.Employment.Status, &employee.Employment.HireDate,
+		&employee.Employment.TerminationDate, &employee.Employment.TerminationReason, &attributes}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return models.Employee{}, fmt.Errorf("scan employee: %w", err)
+	}
+
+	employee.Passport.Number, err = r.decryptPassportNumber(number)
+	if err != nil {
+		return models.Employee{}, fmt.Errorf("scan employee: %w", err)
+	}
+	employee.CustomAttributes = formatAttributes(attributes)
+	return employee, nil
+}
+
+// cleanUnusedDepartments deletes the department once neither employees nor
+// positions refer to it.
 func cleanUnusedDepartments(ctx context.Context, tx pgx.Tx, departmentId int32) error {
-	var countOldDepartment int
-	err := tx.QueryRow(ctx, "SELECT COUNT(*) FROM employees WHERE department_id = $1", departmentId).
-		Scan(&countOldDepartment)
+	var inUse bool
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS(SELECT 1 FROM employees WHERE department_id = $1)
+		    OR EXISTS(SELECT 1 FROM positions WHERE department_id = $1)`, departmentId).
+		Scan(&inUse)
 	if err != nil {
 		err = fmt.Errorf("clean unused depart: select department: %w", err)
 		return err
 	}
-	if countOldDepartment == 0 {
+	if !inUse {
 		_, err = tx.Exec(ctx, "DELETE FROM departments WHERE id = $1", departmentId)
 		if err != nil {
 			err = fmt.Errorf("clean unused depart: delete department: %w", err)
@@ -252,6 +433,10 @@ func cleanUnusedDepartments(ctx context.Context, tx pgx.Tx, departmentId int32)
 }
 
 func (r *EmployeeRepository) UpdateEmployee(ctx context.Context, employee models.Employee) error {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.UpdateEmployee")
+	defer span.End()
+	defer metrics.ObserveQuery("update_employee", time.Now())
+
 	conn, err := r.db.Acquire(ctx)
 	if err != nil {
 		err = fmt.Errorf("employee_repo: update_employee: acquire connection: %w", err)
@@ -266,10 +451,27 @@ func (r *EmployeeRepository) UpdateEmployee(ctx context.Context, employee models
 	}
 	defer tx.Rollback(ctx)
 
+	if err = r.updateEmployee(ctx, tx, employee, nil); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("employee_repo: update_employee: commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// updateEmployee applies the update, which is the application of transfer
+// unless it is nil.
+func (r *EmployeeRepository) updateEmployee(ctx context.Context, tx pgx.Tx, employee models.Employee, transfer *models.Transfer) error {
 	var departmentId int32
 
-	err = tx.QueryRow(ctx, "SELECT department_id FROM employees WHERE id = $1", employee.Id).
+	err := tx.QueryRow(ctx, "SELECT department_id FROM employees WHERE id = $1", employee.Id).
 		Scan(&departmentId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("employee_repo: update_employee: %w", ErrEmployeeNotFound)
+	}
 	if err != nil {
 		err = fmt.Errorf("employee_repo: update_employee: pass and depart ids query: %w", err)
 		return err
@@ -282,8 +484,44 @@ func (r *EmployeeRepository) UpdateEmployee(ctx context.Context, employee models
 		}
 	}
 
+	if employee.CompanyId != 0 {
+		if err = detachOtherCompanies(ctx, tx, employee.Id); err != nil {
+			return fmt.Errorf("employee_repo: update_employee: %w", err)
+		}
+		if err = moveContacts(ctx, tx, employee.Id, employee.CompanyId); err != nil {
+			return fmt.Errorf("employee_repo: update_employee: %w", err)
+		}
+		if err = dropForeignAttributes(ctx, tx, employee.Id); err != nil {
+			return fmt.Errorf("employee_repo: update_employee: %w", err)
+		}
+	}
+
+	if employee.Contacts != nil {
+		if err = replaceContacts(ctx, tx, employee.Id, employee.Contacts); err != nil {
+			return fmt.Errorf("employee_repo: update_employee: %w", err)
+		}
+	}
+
+	if employee.Addresses != nil {
+		if err = replaceAddresses(ctx, tx, employee.Id, employee.Addresses); err != nil {
+			return fmt.Errorf("employee_repo: update_employee: %w", err)
+		}
+	}
+
+	if len(employee.CustomAttributes) > 0 {
+		if err = setCustomAttributes(ctx, tx, employee.Id, employee.CustomAttributes, false); err != nil {
+			return fmt.Errorf("employee_repo: update_employee: %w", err)
+		}
+	}
+
+	if employee.ManagerId != nil {
+		if err = setManager(ctx, tx, employee.Id, *employee.ManagerId); err != nil {
+			return fmt.Errorf("employee_repo: update_employee: %w", err)
+		}
+	}
+
 	if employee.Passport.Type != "" || employee.Passport.Number != "" {
-		err = updatePassport(ctx, tx, employee)
+		err = r.updatePassport(ctx, tx, employee)
 		if err != nil {
 			return fmt.Errorf("employee repo: update employee: update pass data: %w", err)
 		}
@@ -309,8 +547,41 @@ func (r *EmployeeRepository) UpdateEmployee(ctx context.Context, employee models
 
 	}
 
-	if err = tx.Commit(ctx); err != nil {
-		return fmt.Errorf("employee_repo: update_employee: commit transaction: %w", err)
+	if employee.CompanyId != 0 || employee.Department.Name != "" || employee.Department.Phone != "" {
+		if err = detachMismatchedPosition(ctx, tx, employee.Id); err != nil {
+			return fmt.Errorf("employee_repo: update_employee: %w", err)
+		}
+	}
+
+	if employee.PositionId != nil {
+		if err = setPosition(ctx, tx, employee.Id, *employee.PositionId); err != nil {
+			return fmt.Errorf("employee_repo: update_employee: %w", err)
+		}
+	}
+
+	if employee.CompanyId != 0 || employee.Department.Name != "" || employee.Department.Phone != "" ||
+		employee.PositionId != nil {
+		startedOn, transferId := today(), (*int32)(nil)
+		if transfer != nil {
+			startedOn, transferId = transfer.EffectiveDate, &transfer.Id
+		}
+		if err = recordAssignment(ctx, tx, employee.Id, startedOn, transferId); err != nil {
+			return fmt.Errorf("employee_repo: update_employee: %w", err)
+		}
+	}
+
+	fields := changedFields(employee)
+	action, details := models.AuditEmployeeUpdated, map[string]interface{}{"fields": fields}
+	if transfer != nil {
+		action = models.AuditEmployeeTransferred
+		details["transfer_id"] = transfer.Id
+	}
+	if err = recordAudit(ctx, tx, employee.Id, action, details); err != nil {
+		return fmt.Errorf("employee_repo: update_employee: %w", err)
+	}
+
+	if err = recordEvent(ctx, tx, models.EventEmployeeUpdated, employee.Id, fields); err != nil {
+		return fmt.Errorf("employee_repo: update_employee: %w", err)
 	}
 
 	return nil
@@ -352,7 +623,7 @@ func updateEmployeeData(ctx context.Context, tx pgx.Tx, employee models.Employee
 	return nil
 }
 
-func updatePassport(ctx context.Context, tx pgx.Tx, employee models.Employee) error {
+func (r *EmployeeRepository) updatePassport(ctx context.Context, tx pgx.Tx, employee models.Employee) error {
 	updatePassportQuery := "UPDATE passports SET"
 	passportArgs := []interface{}{}
 	fields := make([]string, 0)
@@ -364,11 +635,29 @@ func updatePassport(ctx context.Context, tx pgx.Tx, employee models.Employee) er
 		index++
 	}
 	if employee.Passport.Number != "" {
-		fields = append(fields, fmt.Sprintf("number = $%v", index))
-		passportArgs = append(passportArgs, employee.Passport.Number)
-		index++
+		number, err := r.encryptPassportNumber(employee.Passport.Number)
+		if err != nil {
+			return fmt.Errorf("employee_repo: update_employee: %w", err)
+		}
+
+		registered, err := passportRegistered(ctx, tx, *number.index, employee.Id)
+		if err != nil {
+			return fmt.Errorf("employee_repo: update_employee: %w", err)
+		}
+		if registered {
+			return fmt.Errorf("employee_repo: update_employee: passport number already registered")
+		}
+
+		fields = append(fields, "number = NULL",
+			fmt.Sprintf("number_ciphertext = $%v", index),
+			fmt.Sprintf("number_dek = $%v", index+1),
+			fmt.Sprintf("number_key_id = $%v", index+2),
+			fmt.Sprintf("number_index = $%v", index+3))
+		passportArgs = append(passportArgs, number.ciphertext, number.dek, number.keyId, number.index)
+		index += 4
 	}
-	updatePassportQuery += " " + strings.Join(fields, ", ") + fmt.Sprintf(" WHERE id = $%v", index)
+	updatePassportQuery += " " + strings.Join(fields, ", ") +
+		fmt.Sprintf(" WHERE id = (SELECT passport_id FROM employees WHERE id = $%v)", index)
 	passportArgs = append(passportArgs, employee.Id)
 
 	_, err := tx.Exec(ctx, updatePassportQuery, passportArgs...)
@@ -0,0 +1,367 @@
+package repositories
+
+import (
+	"context"
+	"employee-service/metrics"
+	"employee-service/models"
+	"employee-service/tracing"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"time"
+)
+
+var (
+	// ErrInvalidTransition is returned when a lifecycle operation does not
+	// apply to the employee's current status.
+	ErrInvalidTransition = errors.New("invalid employment status transition")
+	// ErrInvalidEmploymentDates is returned when an employee would be
+	// terminated before being hired.
+	ErrInvalidEmploymentDates = errors.New("invalid termination date")
+)
+
+// transition changes the employment of an employee in the from statuses. It
+// returns the columns it set, which are reported as changed fields.
+type transition func(ctx context.Context, tx pgx.Tx, id int32, current models.Employment) ([]string, error)
+
+// Hire makes a candidate active, or onboarding when onboarding is set, and
+// completes the onboarding of an employee. hireDate defaults to the current
+// hire date, or today.
+func (r *EmployeeRepository) Hire(ctx context.Context, id int32, hireDate *time.Time, onboarding bool) (models.Employment, error) {
+	from := []string{models.StatusCandidate, models.StatusOnboarding}
+	if onboarding {
+		from = []string{models.StatusCandidate}
+	}
+
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.Hire")
+	defer span.End()
+
+	return r.changeEmployment(ctx, "hire", id, from,
+		func(ctx context.Context, tx pgx.Tx, id int32, current models.Employment) ([]string, error) {
+			_, err := tx.Exec(ctx, `
+				UPDATE employees
+				SET status = $2, hire_date = COALESCE($3, hire_date, CURRENT_DATE)
+				WHERE id = $1`, id, startStatus(onboarding), hireDate)
+			if err != nil {
+				return nil, fmt.Errorf("update employment: %w", err)
+			}
+			return []string{"status", "hire_date"}, nil
+		})
+}
+
+// Rehire brings a terminated employee back, active or onboarding, hired on
+// hireDate or today.
+func (r *EmployeeRepository) Rehire(ctx context.Context, id int32, hireDate *time.Time, onboarding bool) (models.Employment, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.Rehire")
+	defer span.End()
+
+	return r.changeEmployment(ctx, "rehire", id, []string{models.StatusTerminated},
+		func(ctx context.Context, tx pgx.Tx, id int32, current models.Employment) ([]string, error) {
+			date := today()
+			if hireDate != nil {
+				date = *hireDate
+			}
+
+			_, err := tx.Exec(ctx, `
+				UPDATE employees
+				SET status = $2, hire_date = $3, termination_date = NULL, termination_reason = ''
+				WHERE id = $1`, id, startStatus(onboarding), date)
+			if err != nil {
+				return nil, fmt.Errorf("update employment: %w", err)
+			}
+			if err = recordAssignment(ctx, tx, id, date, nil); err != nil {
+				return nil, err
+			}
+			return []string{"status", "hire_date", "termination_date", "termination_reason"}, nil
+		})
+}
+
+// Terminate ends the employment on terminationDate, or today. The employee
+// leaves their position and the reporting lines: their reports move to their
+// manager. Pending transfers and leave requests are cancelled. A future date
+// only schedules the termination: the employee stays current until
+// ApplyDueTerminations terminates them, and terminating them again
+// reschedules it.
+func (r *EmployeeRepository) Terminate(ctx context.Context, id int32, terminationDate *time.Time, reason string) (models.Employment, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.Terminate")
+	defer span.End()
+
+	from := []string{models.StatusOnboarding, models.StatusActive, models.StatusOnLeave}
+
+	return r.changeEmployment(ctx, "terminate", id, from,
+		func(ctx context.Context, tx pgx.Tx, id int32, current models.Employment) ([]string, error) {
+			date := today()
+			if terminationDate != nil {
+				date = *terminationDate
+			}
+			if current.HireDate != nil && date.Before(*current.HireDate) {
+				return nil, fmt.Errorf("terminate on %s, hired on %s: %w",
+					date.Format(models.DateLayout), current.HireDate.Format(models.DateLayout), ErrInvalidEmploymentDates)
+			}
+
+			if date.After(today()) {
+				_, err := tx.Exec(ctx, `
+					UPDATE employees
+					SET termination_date = $2, termination_reason = $3
+					WHERE id = $1`, id, date, reason)
+				if err != nil {
+					return nil, fmt.Errorf("schedule termination: %w", err)
+				}
+				return []string{"termination_date", "termination_reason"}, nil
+			}
+			return terminate(ctx, tx, id, date, reason)
+		})
+}
+
+// ApplyDueTerminations terminates up to limit employees whose scheduled
+// termination date has come, each in its own transaction. It returns the ids
+// of the employees it terminated.
+func (r *EmployeeRepository) ApplyDueTerminations(ctx context.Context, limit int) ([]int32, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.ApplyDueTerminations")
+	defer span.End()
+	defer metrics.ObserveQuery("apply_due_terminations", time.Now())
+
+	var terminated []int32
+	for len(terminated) < limit {
+		id, found, err := r.applyNextDueTermination(ctx)
+		if err != nil {
+			return terminated, fmt.Errorf("employee_repo: apply_due_terminations: %w", err)
+		}
+		if !found {
+			break
+		}
+		terminated = append(terminated, id)
+	}
+	return terminated, nil
+}
+
+func (r *EmployeeRepository) applyNextDueTermination(ctx context.Context) (int32, bool, error) {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return 0, false, fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return 0, false, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Only a scheduled termination leaves a date on an employee who is not
+	// terminated. SKIP LOCKED lets several instances of the service share the
+	// work.
+	var id int32
+	err = tx.QueryRow(ctx, `
+		SELECT id
+		FROM employees
+		WHERE status <> 'terminated' AND termination_date <= $1
+		ORDER BY termination_date, id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`, today()).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("query row due termination: %w", err)
+	}
+
+	_, err = applyEmploymentChange(ctx, tx, id, models.CurrentStatuses,
+		func(ctx context.Context, tx pgx.Tx, id int32, current models.Employment) ([]string, error) {
+			return terminate(ctx, tx, id, *current.TerminationDate, current.TerminationReason)
+		})
+	if err != nil {
+		return 0, false, fmt.Errorf("terminate employee %d: %w", id, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, false, fmt.Errorf("commit transaction: %w", err)
+	}
+	return id, true, nil
+}
+
+// terminate ends the employment of id on date. Their reports move to their
+// manager, their assignment ends and their pending transfers and leave
+// requests are cancelled.
+func terminate(ctx context.Context, tx pgx.Tx, id int32, date time.Time, reason string) ([]string, error) {
+	rows, err := tx.Query(ctx, `
+		UPDATE employees
+		SET manager_id = (SELECT manager_id FROM employees WHERE id = $1)
+		WHERE manager_id = $1
+		RETURNING id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("reassign reports: %w", err)
+	}
+	var reports []int32
+	for rows.Next() {
+		var report int32
+		if err = rows.Scan(&report); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("reassign reports: scan: %w", err)
+		}
+		reports = append(reports, report)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("reassign reports: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE employees
+		SET status = 'terminated', termination_date = $2, termination_reason = $3, manager_id = NULL,
+		    position_id = NULL
+		WHERE id = $1`, id, date, reason)
+	if err != nil {
+		return nil, fmt.Errorf("update employment: %w", err)
+	}
+
+	for _, report := range reports {
+		if err = recordManagerChange(ctx, tx, report); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = endAssignment(ctx, tx, id, date); err != nil {
+		return nil, err
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE transfers
+		SET status = 'cancelled'
+		WHERE employee_id = $1 AND status = 'pending'`, id)
+	if err != nil {
+		return nil, fmt.Errorf("cancel pending transfers: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE leave_requests
+		SET status = 'cancelled'
+		WHERE employee_id = $1 AND status = 'pending'`, id)
+	if err != nil {
+		return nil, fmt.Errorf("cancel pending leave: %w", err)
+	}
+	return []string{"status", "termination_date", "termination_reason", "manager_id", "position_id"}, nil
+}
+
+// SetOnLeave moves an active employee on leave, or back when onLeave is false.
+func (r *EmployeeRepository) SetOnLeave(ctx context.Context, id int32, onLeave bool) (models.Employment, error) {
+	from, to := models.StatusOnLeave, models.StatusActive
+	if onLeave {
+		from, to = models.StatusActive, models.StatusOnLeave
+	}
+
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.SetOnLeave")
+	defer span.End()
+
+	return r.changeEmployment(ctx, "set_on_leave", id, []string{from},
+		func(ctx context.Context, tx pgx.Tx, id int32, current models.Employment) ([]string, error) {
+			if _, err := tx.Exec(ctx, "UPDATE employees SET status = $2 WHERE id = $1", id, to); err != nil {
+				return nil, fmt.Errorf("update employment: %w", err)
+			}
+			return []string{"status"}, nil
+		})
+}
+
+// today is the current date in UTC, the time zone of the dates in requests.
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+func startStatus(onboarding bool) string {
+	if onboarding {
+		return models.StatusOnboarding
+	}
+	return models.StatusActive
+}
+
+// changeEmployment applies change to an employee in one of the from statuses
+// in a transaction of its own.
+func (r *EmployeeRepository) changeEmployment(ctx context.Context, op string, id int32, from []string, change transition) (models.Employment, error) {
+	defer metrics.ObserveQuery(op+"_employee", time.Now())
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return models.Employment{}, fmt.Errorf("employee_repo: %s: acquire connection: %w", op, err)
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return models.Employment{}, fmt.Errorf("employee_repo: %s: begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	updated, err := applyEmploymentChange(ctx, tx, id, from, change)
+	if err != nil {
+		return models.Employment{}, fmt.Errorf("employee_repo: %s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.Employment{}, fmt.Errorf("employee_repo: %s: commit transaction: %w", op, err)
+	}
+	return updated, nil
+}
+
+// applyEmploymentChange applies change to an employee in one of the from
+// statuses and records the transition in the audit log and the outbox.
+func applyEmploymentChange(ctx context.Context, tx pgx.Tx, id int32, from []string, change transition) (models.Employment, error) {
+	current, err := loadEmployment(ctx, tx, id, true)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Employment{}, ErrEmployeeNotFound
+	}
+	if err != nil {
+		return models.Employment{}, err
+	}
+
+	allowed := false
+	for _, status := range from {
+		allowed = allowed || current.Status == status
+	}
+	if !allowed {
+		return models.Employment{}, fmt.Errorf("employee is %s: %w", current.Status, ErrInvalidTransition)
+	}
+
+	fields, err := change(ctx, tx, id, current)
+	if err != nil {
+		return models.Employment{}, err
+	}
+
+	updated, err := loadEmployment(ctx, tx, id, false)
+	if err != nil {
+		return models.Employment{}, err
+	}
+
+	err = recordAudit(ctx, tx, id, models.AuditEmploymentChanged,
+		map[string]interface{}{"from": current.Status, "to": updated.Status})
+	if err != nil {
+		return models.Employment{}, err
+	}
+	if err = recordEvent(ctx, tx, models.EventEmployeeUpdated, id, fields); err != nil {
+		return models.Employment{}, err
+	}
+	return updated, nil
+}
+
+func loadEmployment(ctx context.Context, tx pgx.Tx, id int32, forUpdate bool) (models.Employment, error) {
+	query := "SELECT status, hire_date, termination_date, termination_reason FROM employees WHERE id = $1"
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+
+	var employment models.Employment
+	err := tx.QueryRow(ctx, query, id).Scan(&employment.Status, &employment.HireDate,
+		&employment.TerminationDate, &employment.TerminationReason)
+	if err != nil {
+		return models.Employment{}, fmt.Errorf("query row employment: %w", err)
+	}
+	return employment, nil
+}
+
+// recordManagerChange records that the manager of employeeId was changed as a
+// side effect of another operation.
+func recordManagerChange(ctx context.Context, tx pgx.Tx, employeeId int32) error {
+	fields := []string{"manager_id"}
+	err := recordAudit(ctx, tx, employeeId, models.AuditEmployeeUpdated, map[string]interface{}{"fields": fields})
+	if err != nil {
+		return err
+	}
+	return recordEvent(ctx, tx, models.EventEmployeeUpdated, employeeId, fields)
+}
@@ -0,0 +1,287 @@
+package repositories
+
+import (
+	"context"
+	"employee-service/metrics"
+	"employee-service/models"
+	"employee-service/tracing"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+// ErrInvalidManager is returned when a manager does not exist, belongs to
+// another company, is terminated or would make the reporting lines circular.
+var ErrInvalidManager = errors.New("invalid manager")
+
+// hierarchyLockKey namespaces the per-company advisory lock taken while a
+// manager is assigned, so that two concurrent assignments cannot form a cycle
+// that neither of them sees.
+const hierarchyLockKey = 0x6f7267
+
+// maxHierarchyDepth bounds the recursive queries. Cycles are prevented on
+// write, the bound only protects the queries from rows written around it.
+const maxHierarchyDepth = 100
+
+// setManager makes managerId the manager of employeeId, or removes the manager
+// when managerId is 0.
+func setManager(ctx context.Context, tx pgx.Tx, employeeId, managerId int32) error {
+	if managerId == 0 {
+		if _, err := tx.Exec(ctx, "UPDATE employees SET manager_id = NULL WHERE id = $1", employeeId); err != nil {
+			return fmt.Errorf("set manager: clear manager: %w", err)
+		}
+		return nil
+	}
+	if managerId == employeeId {
+		return fmt.Errorf("set manager: employee %d cannot manage itself: %w", employeeId, ErrInvalidManager)
+	}
+
+	var companyId int32
+	err := tx.QueryRow(ctx, "SELECT company_id FROM employees WHERE id = $1", employeeId).Scan(&companyId)
+	if err != nil {
+		return fmt.Errorf("set manager: query row employee: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1, $2)", hierarchyLockKey, companyId); err != nil {
+		return fmt.Errorf("set manager: lock company hierarchy: %w", err)
+	}
+
+	var managerCompanyId int32
+	var managerStatus string
+	err = tx.QueryRow(ctx, "SELECT company_id, status FROM employees WHERE id = $1", managerId).
+		Scan(&managerCompanyId, &managerStatus)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("set manager: manager %d not found: %w", managerId, ErrInvalidManager)
+	}
+	if err != nil {
+		return fmt.Errorf("set manager: query row manager: %w", err)
+	}
+	if managerCompanyId != companyId {
+		return fmt.Errorf("set manager: manager %d works for another company: %w", managerId, ErrInvalidManager)
+	}
+	if managerStatus == models.StatusTerminated {
+		return fmt.Errorf("set manager: manager %d is terminated: %w", managerId, ErrInvalidManager)
+	}
+
+	// The employee must not appear in the new manager's reporting chain.
+	var cycle bool
+	err = tx.QueryRow(ctx, `
+		WITH RECURSIVE chain (id, manager_id, depth) AS (
+			SELECT id, manager_id, 1 FROM employees WHERE id = $1
+			UNION ALL
+			SELECT e.id, e.manager_id, c.depth + 1
+			FROM employees AS e
+			JOIN chain AS c ON e.id = c.manager_id
+			WHERE c.depth < $3
+		)
+		SELECT EXISTS(SELECT 1 FROM chain WHERE id = $2)`,
+		managerId, employeeId, maxHierarchyDepth).Scan(&cycle)
+	if err != nil {
+		return fmt.Errorf("set manager: query reporting chain: %w", err)
+	}
+	if cycle {
+		return fmt.Errorf("set manager: employee %d already manages %d: %w", employeeId, managerId, ErrInvalidManager)
+	}
+
+	if _, err = tx.Exec(ctx, "UPDATE employees SET manager_id = $1 WHERE id = $2", managerId, employeeId); err != nil {
+		return fmt.Errorf("set manager: update manager: %w", err)
+	}
+	return nil
+}
+
+// detachOtherCompanies removes the reporting lines between employeeId and
+// employees of other companies, after the employee has moved to a new one.
+func detachOtherCompanies(ctx context.Context, tx pgx.Tx, employeeId int32) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE employees AS e
+		SET manager_id = NULL
+		FROM employees AS m
+		WHERE e.manager_id = m.id AND e.company_id <> m.company_id AND (e.id = $1 OR m.id = $1)`,
+		employeeId)
+	if err != nil {
+		return fmt.Errorf("detach other companies: %w", err)
+	}
+	return nil
+}
+
+// DirectReports returns the employees whose manager is id.
+func (r *EmployeeRepository) DirectReports(ctx context.Context, id int32) ([]models.Employee, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.DirectReports")
+	defer span.End()
+	defer metrics.ObserveQuery("direct_reports", time.Now())
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("employee_repo: direct_reports: acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if err = employeeExists(ctx, conn, id); err != nil {
+		return nil, fmt.Errorf("employee_repo: direct_reports: %w", err)
+	}
+
+	rows, err := conn.Query(ctx, `
+		SELECT`+employeeColumns+`
+		FROM employees AS e
+		JOIN passports AS p ON e.passport_id = p.id
+		JOIN departments AS d ON e.department_id = d.id
+		WHERE e.manager_id = $1
+		ORDER BY e.id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("employee_repo: direct_reports: query: %w", err)
+	}
+	defer rows.Close()
+
+	var employees []models.Employee
+	for rows.Next() {
+		employee, err := r.scanEmployee(rows)
+		if err != nil {
+			return nil, fmt.Errorf("employee_repo: direct_reports: %w", err)
+		}
+		employees = append(employees, employee)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("employee_repo: direct_reports: read rows: %w", err)
+	}
+
+	if err = attachContacts(ctx, conn, employeePointers(employees)...); err != nil {
+		return nil, fmt.Errorf("employee_repo: direct_reports: %w", err)
+	}
+
+	return employees, nil
+}
+
+// ReportingChain returns the managers of id, from its direct manager up to the
+// top of the hierarchy.
+func (r *EmployeeRepository) ReportingChain(ctx context.Context, id int32) ([]models.Employee, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.ReportingChain")
+	defer span.End()
+	defer metrics.ObserveQuery("reporting_chain", time.Now())
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("employee_repo: reporting_chain: acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if err = employeeExists(ctx, conn, id); err != nil {
+		return nil, fmt.Errorf("employee_repo: reporting_chain: %w", err)
+	}
+
+	rows, err := conn.Query(ctx, `
+		WITH RECURSIVE chain (id, depth) AS (
+			SELECT manager_id, 1 FROM employees WHERE id = $1 AND manager_id IS NOT NULL
+			UNION ALL
+			SELECT m.manager_id, c.depth + 1
+			FROM employees AS m
+			JOIN chain AS c ON m.id = c.id
+			WHERE m.manager_id IS NOT NULL AND c.depth < $2
+		)
+		SELECT`+employeeColumns+`
+		FROM chain AS c
+		JOIN employees AS e ON e.id = c.id
+		JOIN passports AS p ON e.passport_id = p.id
+		JOIN departments AS d ON e.department_id = d.id
+		ORDER BY c.depth`, id, maxHierarchyDepth)
+	if err != nil {
+		return nil, fmt.Errorf("employee_repo: reporting_chain: query: %w", err)
+	}
+	defer rows.Close()
+
+	var employees []models.Employee
+	for rows.Next() {
+		employee, err := r.scanEmployee(rows)
+		if err != nil {
+			return nil, fmt.Errorf("employee_repo: reporting_chain: %w", err)
+		}
+		employees = append(employees, employee)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("employee_repo: reporting_chain: read rows: %w", err)
+	}
+
+	if err = attachContacts(ctx, conn, employeePointers(employees)...); err != nil {
+		return nil, fmt.Errorf("employee_repo: reporting_chain: %w", err)
+	}
+
+	return employees, nil
+}
+
+// OrgSubtree returns id and everyone reporting to it directly or indirectly,
+// down to maxDepth levels below id (0 means no limit). Nodes are ordered by
+// depth, so every manager comes before its reports.
+func (r *EmployeeRepository) OrgSubtree(ctx context.Context, id int32, maxDepth int32) ([]models.OrgNode, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.OrgSubtree")
+	defer span.End()
+	defer metrics.ObserveQuery("org_subtree", time.Now())
+
+	if maxDepth <= 0 || maxDepth > maxHierarchyDepth {
+		maxDepth = maxHierarchyDepth
+	}
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("employee_repo: org_subtree: acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, `
+		WITH RECURSIVE subtree (id, depth) AS (
+			SELECT id, 0 FROM employees WHERE id = $1
+			UNION ALL
+			SELECT e.id, s.depth + 1
+			FROM employees AS e
+			JOIN subtree AS s ON e.manager_id = s.id
+			WHERE s.depth < $2
+		)
+		SELECT`+employeeColumns+`, s.depth
+		FROM subtree AS s
+		JOIN employees AS e ON e.id = s.id
+		JOIN passports AS p ON e.passport_id = p.id
+		JOIN departments AS d ON e.department_id = d.id
+		ORDER BY s.depth, e.id`, id, maxDepth)
+	if err != nil {
+		return nil, fmt.Errorf("employee_repo: org_subtree: query: %w", err)
+	}
+	defer rows.Close()
+
+	var nodes []models.OrgNode
+	for rows.Next() {
+		var node models.OrgNode
+		node.Employee, err = r.scanEmployee(rows, &node.Depth)
+		if err != nil {
+			return nil, fmt.Errorf("employee_repo: org_subtree: %w", err)
+		}
+		nodes = append(nodes, node)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("employee_repo: org_subtree: read rows: %w", err)
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("employee_repo: org_subtree: %w", ErrEmployeeNotFound)
+	}
+
+	employees := make([]*models.Employee, len(nodes))
+	for i := range nodes {
+		employees[i] = &nodes[i].Employee
+	}
+	if err = attachContacts(ctx, conn, employees...); err != nil {
+		return nil, fmt.Errorf("employee_repo: org_subtree: %w", err)
+	}
+
+	return nodes, nil
+}
+
+func employeeExists(ctx context.Context, conn *pgxpool.Conn, id int32) error {
+	var exists bool
+	err := conn.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM employees WHERE id = $1)", id).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("employee exists: query row: %w", err)
+	}
+	if !exists {
+		return ErrEmployeeNotFound
+	}
+	return nil
+}
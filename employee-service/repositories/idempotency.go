@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"employee-service/metrics"
+	"employee-service/models"
+	"employee-service/tracing"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"time"
+)
+
+var ErrIdempotencyKeyReused = errors.New("idempotency key already used with a different request")
+
+// claimIdempotencyKey records the key as part of tx. If the key was recorded
+// before, it returns the employee created by the original request and false.
+// A concurrent request with the same key blocks until the first transaction
+// ends, so only one of them creates the employee; if the first one rolls back
+// the key is claimed by the second.
+func claimIdempotencyKey(ctx context.Context, tx pgx.Tx, key models.IdempotencyKey) (int32, bool, error) {
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO idempotency_keys (caller_id, key, request_hash)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (caller_id, key) DO NOTHING`,
+		key.CallerId, key.Key, key.RequestHash)
+	if err != nil {
+		return 0, false, fmt.Errorf("claim idempotency key: insert: %w", err)
+	}
+	if tag.RowsAffected() == 1 {
+		return 0, true, nil
+	}
+
+	var requestHash string
+	var employeeId int32
+	err = tx.QueryRow(ctx, "SELECT request_hash, employee_id FROM idempotency_keys WHERE caller_id = $1 AND key = $2",
+		key.CallerId, key.Key).Scan(&requestHash, &employeeId)
+	if err != nil {
+		return 0, false, fmt.Errorf("claim idempotency key: query row: %w", err)
+	}
+	if requestHash != key.RequestHash {
+		return 0, false, fmt.Errorf("claim idempotency key: %w", ErrIdempotencyKeyReused)
+	}
+	return employeeId, false, nil
+}
+
+// completeIdempotencyKey stores the response of the request that claimed key.
+func completeIdempotencyKey(ctx context.Context, tx pgx.Tx, key models.IdempotencyKey, employeeId int32) error {
+	_, err := tx.Exec(ctx, "UPDATE idempotency_keys SET employee_id = $1 WHERE caller_id = $2 AND key = $3",
+		employeeId, key.CallerId, key.Key)
+	if err != nil {
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpiredIdempotencyKeys forgets keys recorded before the given time, so
+// they can no longer be replayed.
+func (r *EmployeeRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.DeleteExpiredIdempotencyKeys")
+	defer span.End()
+	defer metrics.ObserveQuery("delete_expired_idempotency_keys", time.Now())
+
+	tag, err := r.db.Exec(ctx, "DELETE FROM idempotency_keys WHERE created_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("employee_repo: delete_expired_idempotency_keys: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
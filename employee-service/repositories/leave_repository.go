@@ -0,0 +1,854 @@
+package repositories
+
+import (
+	"context"
+	"employee-service/auth"
+	"employee-service/metrics"
+	"employee-service/models"
+	"employee-service/tracing"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"math"
+	"strings"
+	"time"
+)
+
+var (
+	ErrLeaveTypeNotFound    = errors.New("leave type not found")
+	ErrLeaveTypeExists      = errors.New("leave type already exists")
+	ErrLeaveRequestNotFound = errors.New("leave request not found")
+	// ErrLeaveOverlap is returned when a leave overlaps a pending or approved
+	// leave of the same employee.
+	ErrLeaveOverlap = errors.New("leave overlaps another leave request")
+	// ErrNoWorkingDays is returned for a leave that only covers weekends.
+	ErrNoWorkingDays       = errors.New("leave covers no working days")
+	ErrInsufficientBalance = errors.New("insufficient leave balance")
+	// ErrUnlimitedLeaveType is returned when the balance of a leave type
+	// without balances is adjusted.
+	ErrUnlimitedLeaveType = errors.New("leave type has no balance")
+	// ErrLeaveNotPending is returned when a decided leave request is approved
+	// or rejected again.
+	ErrLeaveNotPending = errors.New("leave request is not pending")
+	// ErrLeaveNotCancellable is returned when a rejected, cancelled or already
+	// started leave is cancelled.
+	ErrLeaveNotCancellable = errors.New("leave request cannot be cancelled")
+	// ErrEmployeeNotCurrent is returned when a candidate or a terminated
+	// employee requests leave.
+	ErrEmployeeNotCurrent = errors.New("employee does not currently work for the company")
+	// ErrLeaveForbidden is returned when the caller is neither allowed to act
+	// on the leave as the employee, nor as their manager or a leave admin.
+	ErrLeaveForbidden = errors.New("caller may not act on this leave request")
+)
+
+type LeaveRepositoryInterface interface {
+	CreateLeaveType(ctx context.Context, leaveType models.LeaveType) (models.LeaveType, error)
+	ListLeaveTypes(ctx context.Context, companyId int32) ([]models.LeaveType, error)
+	UpdateLeaveType(ctx context.Context, companyId, id int32, update models.LeaveTypeUpdate) (models.LeaveType, error)
+	LeaveBalances(ctx context.Context, employeeId int32, actor models.LeaveActor) ([]models.LeaveBalance, error)
+	AdjustLeaveBalance(ctx context.Context, employeeId, leaveTypeId int32, days float64, reason string) (models.LeaveBalance, error)
+	SubmitLeave(ctx context.Context, request models.LeaveRequest) (models.LeaveRequest, error)
+	DecideLeave(ctx context.Context, id int32, approve bool, comment string, actor models.LeaveActor) (models.LeaveRequest, error)
+	CancelLeave(ctx context.Context, id int32, actor models.LeaveActor) (models.LeaveRequest, error)
+	ListLeaveRequests(ctx context.Context, employeeId int32, statuses []string, actor models.LeaveActor) ([]models.LeaveRequest, error)
+	ListAbsences(ctx context.Context, companyId int32, from, to time.Time, department models.Department, includePending bool, actor models.LeaveActor) ([]models.Absence, error)
+}
+
+type LeaveRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewLeaveRepository(db *pgxpool.Pool) *LeaveRepository {
+	return &LeaveRepository{db: db}
+}
+
+// leaveTypeColumns are the columns read by scanLeaveType, for queries on
+// leave_types AS lt.
+const leaveTypeColumns = `
+		lt.id, lt.company_id, lt.name, lt.accrual_days_per_month, lt.max_balance, lt.unlimited, lt.created_at`
+
+func scanLeaveType(row pgx.Row) (models.LeaveType, error) {
+	var leaveType models.LeaveType
+	err := row.Scan(&leaveType.Id, &leaveType.CompanyId, &leaveType.Name, &leaveType.AccrualDaysPerMonth,
+		&leaveType.MaxBalance, &leaveType.Unlimited, &leaveType.CreatedAt)
+	if err != nil {
+		return models.LeaveType{}, fmt.Errorf("scan leave type: %w", err)
+	}
+	return leaveType, nil
+}
+
+// leaveRequestColumns are the columns read by scanLeaveRequest, for queries on
+// leave_requests AS lr joining leave_types AS lt.
+const leaveRequestColumns = `
+		lr.id, lr.employee_id, lr.leave_type_id, lt.name, lr.start_date, lr.end_date, lr.days, lr.reason,
+		lr.status, lr.decided_by, lr.decision_comment, lr.decided_at, lr.created_at`
+
+func scanLeaveRequest(row pgx.Row) (models.LeaveRequest, error) {
+	var request models.LeaveRequest
+	err := row.Scan(&request.Id, &request.EmployeeId, &request.LeaveTypeId, &request.LeaveTypeName,
+		&request.StartDate, &request.EndDate, &request.Days, &request.Reason, &request.Status, &request.DecidedBy,
+		&request.DecisionComment, &request.DecidedAt, &request.CreatedAt)
+	if err != nil {
+		return models.LeaveRequest{}, fmt.Errorf("scan leave request: %w", err)
+	}
+	return request, nil
+}
+
+func (r *LeaveRepository) CreateLeaveType(ctx context.Context, leaveType models.LeaveType) (models.LeaveType, error) {
+	ctx, span := tracing.Start(ctx, "LeaveRepository.CreateLeaveType")
+	defer span.End()
+	defer metrics.ObserveQuery("create_leave_type", time.Now())
+
+	row := r.db.QueryRow(ctx, `
+		INSERT INTO leave_types AS lt (company_id, name, accrual_days_per_month, max_balance, unlimited)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING`+leaveTypeColumns,
+		leaveType.CompanyId, leaveType.Name, leaveType.AccrualDaysPerMonth, leaveType.MaxBalance, leaveType.Unlimited)
+	created, err := scanLeaveType(row)
+	if isUniqueViolation(err) {
+		return models.LeaveType{}, fmt.Errorf("leave_repo: create_leave_type: %w", ErrLeaveTypeExists)
+	}
+	if err != nil {
+		return models.LeaveType{}, fmt.Errorf("leave_repo: create_leave_type: %w", err)
+	}
+	return created, nil
+}
+
+func (r *LeaveRepository) ListLeaveTypes(ctx context.Context, companyId int32) ([]models.LeaveType, error) {
+	ctx, span := tracing.Start(ctx, "LeaveRepository.ListLeaveTypes")
+	defer span.End()
+	defer metrics.ObserveQuery("list_leave_types", time.Now())
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("leave_repo: list_leave_types: acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	leaveTypes, err := companyLeaveTypes(ctx, conn, companyId)
+	if err != nil {
+		return nil, fmt.Errorf("leave_repo: list_leave_types: %w", err)
+	}
+	return leaveTypes, nil
+}
+
+func (r *LeaveRepository) UpdateLeaveType(ctx context.Context, companyId, id int32, update models.LeaveTypeUpdate) (models.LeaveType, error) {
+	ctx, span := tracing.Start(ctx, "LeaveRepository.UpdateLeaveType")
+	defer span.End()
+	defer metrics.ObserveQuery("update_leave_type", time.Now())
+
+	fields := make([]string, 0)
+	args := []interface{}{}
+	set := func(column string, value interface{}) {
+		args = append(args, value)
+		fields = append(fields, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if update.Name != nil {
+		set("name", *update.Name)
+	}
+	if update.AccrualDaysPerMonth != nil {
+		set("accrual_days_per_month", *update.AccrualDaysPerMonth)
+	}
+	if update.MaxBalance != nil {
+		if *update.MaxBalance == 0 {
+			set("max_balance", nil)
+		} else {
+			set("max_balance", *update.MaxBalance)
+		}
+	}
+
+	args = append(args, id, companyId)
+	query := fmt.Sprintf(`
+		UPDATE leave_types AS lt
+		SET %s
+		WHERE id = $%d AND company_id = $%d
+		RETURNING`+leaveTypeColumns, strings.Join(fields, ", "), len(args)-1, len(args))
+	// Without changes the update only checks that the leave type exists.
+	if len(fields) == 0 {
+		query = `
+		SELECT` + leaveTypeColumns + `
+		FROM leave_types AS lt
+		WHERE id = $1 AND company_id = $2`
+	}
+
+	updated, err := scanLeaveType(r.db.QueryRow(ctx, query, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.LeaveType{}, fmt.Errorf("leave_repo: update_leave_type: %w", ErrLeaveTypeNotFound)
+	}
+	if isUniqueViolation(err) {
+		return models.LeaveType{}, fmt.Errorf("leave_repo: update_leave_type: %w", ErrLeaveTypeExists)
+	}
+	if err != nil {
+		return models.LeaveType{}, fmt.Errorf("leave_repo: update_leave_type: %w", err)
+	}
+	return updated, nil
+}
+
+// LeaveBalances returns the balance of the employee for every leave type of
+// their company, accruing the months passed since the last access.
+func (r *LeaveRepository) LeaveBalances(ctx context.Context, employeeId int32, actor models.LeaveActor) ([]models.LeaveBalance, error) {
+	ctx, span := tracing.Start(ctx, "LeaveRepository.LeaveBalances")
+	defer span.End()
+	defer metrics.ObserveQuery("leave_balances", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("leave_repo: leave_balances: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var companyId int32
+	err = tx.QueryRow(ctx, "SELECT company_id FROM employees WHERE id = $1", employeeId).Scan(&companyId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("leave_repo: leave_balances: %w", ErrEmployeeNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("leave_repo: leave_balances: query row employee: %w", err)
+	}
+	if err = checkLeaveAccess(ctx, tx, employeeId, actor); err != nil {
+		return nil, fmt.Errorf("leave_repo: leave_balances: %w", err)
+	}
+
+	balances, err := employeeLeaveBalances(ctx, tx, companyId, employeeId)
+	if err != nil {
+		return nil, fmt.Errorf("leave_repo: leave_balances: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("leave_repo: leave_balances: commit transaction: %w", err)
+	}
+	return balances, nil
+}
+
+// AdjustLeaveBalance adds days, which may be negative, to the balance of the
+// employee, for example to carry over the balance of a previous system.
+func (r *LeaveRepository) AdjustLeaveBalance(ctx context.Context, employeeId, leaveTypeId int32, days float64, reason string) (models.LeaveBalance, error) {
+	ctx, span := tracing.Start(ctx, "LeaveRepository.AdjustLeaveBalance")
+	defer span.End()
+	defer metrics.ObserveQuery("adjust_leave_balance", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.LeaveBalance{}, fmt.Errorf("leave_repo: adjust_leave_balance: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	leaveType, err := employeeLeaveType(ctx, tx, employeeId, leaveTypeId)
+	if err != nil {
+		return models.LeaveBalance{}, fmt.Errorf("leave_repo: adjust_leave_balance: %w", err)
+	}
+	if leaveType.Unlimited {
+		return models.LeaveBalance{}, fmt.Errorf("leave_repo: adjust_leave_balance: %w", ErrUnlimitedLeaveType)
+	}
+
+	if _, err = accrueBalance(ctx, tx, employeeId, leaveType); err != nil {
+		return models.LeaveBalance{}, fmt.Errorf("leave_repo: adjust_leave_balance: %w", err)
+	}
+	if err = changeBalance(ctx, tx, employeeId, leaveTypeId, days, models.LedgerAdjustment, nil, reason); err != nil {
+		return models.LeaveBalance{}, fmt.Errorf("leave_repo: adjust_leave_balance: %w", err)
+	}
+
+	balance, err := leaveBalance(ctx, tx, employeeId, leaveType)
+	if err != nil {
+		return models.LeaveBalance{}, fmt.Errorf("leave_repo: adjust_leave_balance: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.LeaveBalance{}, fmt.Errorf("leave_repo: adjust_leave_balance: commit transaction: %w", err)
+	}
+	return balance, nil
+}
+
+// SubmitLeave records a pending leave request. The days of pending requests
+// are reserved, so the request must fit in the available balance.
+func (r *LeaveRepository) SubmitLeave(ctx context.Context, request models.LeaveRequest) (models.LeaveRequest, error) {
+	ctx, span := tracing.Start(ctx, "LeaveRepository.SubmitLeave")
+	defer span.End()
+	defer metrics.ObserveQuery("submit_leave", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: submit_leave: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The employee row lock serializes the overlap and balance checks of
+	// concurrent requests of the same employee.
+	var employeeStatus string
+	err = tx.QueryRow(ctx, "SELECT status FROM employees WHERE id = $1 FOR UPDATE", request.EmployeeId).
+		Scan(&employeeStatus)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: submit_leave: %w", ErrEmployeeNotFound)
+	}
+	if err != nil {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: submit_leave: query row employee: %w", err)
+	}
+	if employeeStatus == models.StatusCandidate || employeeStatus == models.StatusTerminated {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: submit_leave: employee is %s: %w",
+			employeeStatus, ErrEmployeeNotCurrent)
+	}
+
+	leaveType, err := employeeLeaveType(ctx, tx, request.EmployeeId, request.LeaveTypeId)
+	if err != nil {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: submit_leave: %w", err)
+	}
+
+	request.Days = workingDays(request.StartDate, request.EndDate)
+	if request.Days == 0 {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: submit_leave: %w", ErrNoWorkingDays)
+	}
+
+	var overlapping int32
+	err = tx.QueryRow(ctx, `
+		SELECT id
+		FROM leave_requests
+		WHERE employee_id = $1 AND status IN ('pending', 'approved') AND start_date <= $3 AND end_date >= $2
+		ORDER BY start_date
+		LIMIT 1`, request.EmployeeId, request.StartDate, request.EndDate).Scan(&overlapping)
+	if err == nil {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: submit_leave: leave request %d: %w",
+			overlapping, ErrLeaveOverlap)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: submit_leave: query row overlapping leave: %w", err)
+	}
+
+	if !leaveType.Unlimited {
+		balance, err := leaveBalance(ctx, tx, request.EmployeeId, leaveType)
+		if err != nil {
+			return models.LeaveRequest{}, fmt.Errorf("leave_repo: submit_leave: %w", err)
+		}
+		if balance.Available() < request.Days {
+			return models.LeaveRequest{}, fmt.Errorf("leave_repo: submit_leave: %.2f days requested, %.2f available: %w",
+				request.Days, balance.Available(), ErrInsufficientBalance)
+		}
+	}
+
+	var id int32
+	err = tx.QueryRow(ctx, `
+		INSERT INTO leave_requests (employee_id, leave_type_id, start_date, end_date, days, reason)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`,
+		request.EmployeeId, request.LeaveTypeId, request.StartDate, request.EndDate, request.Days, request.Reason).
+		Scan(&id)
+	if err != nil {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: submit_leave: insert leave request: %w", err)
+	}
+
+	created, err := getLeaveRequest(ctx, tx, id, false)
+	if err != nil {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: submit_leave: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: submit_leave: commit transaction: %w", err)
+	}
+	return created, nil
+}
+
+// DecideLeave approves or rejects a pending leave request. Only a leave admin
+// or the manager of the employee may decide. Approval takes the days from the
+// balance.
+func (r *LeaveRepository) DecideLeave(ctx context.Context, id int32, approve bool, comment string, actor models.LeaveActor) (models.LeaveRequest, error) {
+	ctx, span := tracing.Start(ctx, "LeaveRepository.DecideLeave")
+	defer span.End()
+	defer metrics.ObserveQuery("decide_leave", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: decide_leave: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	request, err := getLeaveRequest(ctx, tx, id, true)
+	if err != nil {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: decide_leave: %w", err)
+	}
+
+	if !actor.Admin {
+		var managerId *int32
+		err = tx.QueryRow(ctx, "SELECT manager_id FROM employees WHERE id = $1", request.EmployeeId).Scan(&managerId)
+		if err != nil {
+			return models.LeaveRequest{}, fmt.Errorf("leave_repo: decide_leave: query row manager: %w", err)
+		}
+		if actor.EmployeeId == 0 || managerId == nil || *managerId != actor.EmployeeId {
+			return models.LeaveRequest{}, fmt.Errorf("leave_repo: decide_leave: not the manager of employee %d: %w",
+				request.EmployeeId, ErrLeaveForbidden)
+		}
+	}
+	if request.Status != models.LeavePending {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: decide_leave: request is %s: %w",
+			request.Status, ErrLeaveNotPending)
+	}
+
+	decision := models.LeaveRejected
+	if approve {
+		decision = models.LeaveApproved
+
+		leaveType, err := employeeLeaveType(ctx, tx, request.EmployeeId, request.LeaveTypeId)
+		if err != nil {
+			return models.LeaveRequest{}, fmt.Errorf("leave_repo: decide_leave: %w", err)
+		}
+		if !leaveType.Unlimited {
+			balance, err := accrueBalance(ctx, tx, request.EmployeeId, leaveType)
+			if err != nil {
+				return models.LeaveRequest{}, fmt.Errorf("leave_repo: decide_leave: %w", err)
+			}
+			// An adjustment may have lowered the balance since submission.
+			if balance < request.Days {
+				return models.LeaveRequest{}, fmt.Errorf("leave_repo: decide_leave: %.2f days requested, balance is %.2f: %w",
+					request.Days, balance, ErrInsufficientBalance)
+			}
+			err = changeBalance(ctx, tx, request.EmployeeId, request.LeaveTypeId, -request.Days, models.LedgerTaken,
+				&request.Id, "")
+			if err != nil {
+				return models.LeaveRequest{}, fmt.Errorf("leave_repo: decide_leave: %w", err)
+			}
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE leave_requests
+		SET status = $2, decided_by = $3, decision_comment = $4, decided_at = now()
+		WHERE id = $1`, id, decision, auth.CallerFromContext(ctx).Id, comment)
+	if err != nil {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: decide_leave: update leave request: %w", err)
+	}
+
+	decided, err := getLeaveRequest(ctx, tx, id, false)
+	if err != nil {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: decide_leave: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: decide_leave: commit transaction: %w", err)
+	}
+	return decided, nil
+}
+
+// CancelLeave cancels a pending leave, or an approved one that has not started
+// yet, whose days are returned to the balance. Only the employee or a leave
+// admin may cancel.
+func (r *LeaveRepository) CancelLeave(ctx context.Context, id int32, actor models.LeaveActor) (models.LeaveRequest, error) {
+	ctx, span := tracing.Start(ctx, "LeaveRepository.CancelLeave")
+	defer span.End()
+	defer metrics.ObserveQuery("cancel_leave", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: cancel_leave: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	request, err := getLeaveRequest(ctx, tx, id, true)
+	if err != nil {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: cancel_leave: %w", err)
+	}
+	if !actor.Admin && (actor.EmployeeId == 0 || actor.EmployeeId != request.EmployeeId) {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: cancel_leave: not the leave of the caller: %w",
+			ErrLeaveForbidden)
+	}
+
+	switch {
+	case request.Status == models.LeavePending:
+	case request.Status == models.LeaveApproved && request.StartDate.After(today()):
+		leaveType, err := employeeLeaveType(ctx, tx, request.EmployeeId, request.LeaveTypeId)
+		if err != nil {
+			return models.LeaveRequest{}, fmt.Errorf("leave_repo: cancel_leave: %w", err)
+		}
+		if !leaveType.Unlimited {
+			err = changeBalance(ctx, tx, request.EmployeeId, request.LeaveTypeId, request.Days, models.LedgerRefund,
+				&request.Id, "")
+			if err != nil {
+				return models.LeaveRequest{}, fmt.Errorf("leave_repo: cancel_leave: %w", err)
+			}
+		}
+	default:
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: cancel_leave: request is %s from %s: %w",
+			request.Status, request.StartDate.Format(models.DateLayout), ErrLeaveNotCancellable)
+	}
+
+	if _, err = tx.Exec(ctx, "UPDATE leave_requests SET status = 'cancelled' WHERE id = $1", id); err != nil {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: cancel_leave: update leave request: %w", err)
+	}
+
+	cancelled, err := getLeaveRequest(ctx, tx, id, false)
+	if err != nil {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: cancel_leave: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.LeaveRequest{}, fmt.Errorf("leave_repo: cancel_leave: commit transaction: %w", err)
+	}
+	return cancelled, nil
+}
+
+// ListLeaveRequests returns the leave requests of the employee with one of
+// statuses, latest first. No statuses means all.
+func (r *LeaveRepository) ListLeaveRequests(ctx context.Context, employeeId int32, statuses []string, actor models.LeaveActor) ([]models.LeaveRequest, error) {
+	ctx, span := tracing.Start(ctx, "LeaveRepository.ListLeaveRequests")
+	defer span.End()
+	defer metrics.ObserveQuery("list_leave_requests", time.Now())
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("leave_repo: list_leave_requests: acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if err = employeeExists(ctx, conn, employeeId); err != nil {
+		return nil, fmt.Errorf("leave_repo: list_leave_requests: %w", err)
+	}
+	if err = checkLeaveAccess(ctx, conn, employeeId, actor); err != nil {
+		return nil, fmt.Errorf("leave_repo: list_leave_requests: %w", err)
+	}
+
+	requests, err := employeeLeaveRequests(ctx, conn, employeeId, statuses)
+	if err != nil {
+		return nil, fmt.Errorf("leave_repo: list_leave_requests: %w", err)
+	}
+	return requests, nil
+}
+
+// ListAbsences returns the approved leaves, and the pending ones when
+// includePending is set, of the employees of the company that overlap the
+// from-to range, optionally only those of a department. A caller without leave
+// admin rights sees their own absences and those of their direct reports, and
+// only the approved absences of the rest of their department, without the
+// leave type.
+func (r *LeaveRepository) ListAbsences(ctx context.Context, companyId int32, from, to time.Time, department models.Department, includePending bool, actor models.LeaveActor) ([]models.Absence, error) {
+	ctx, span := tracing.Start(ctx, "LeaveRepository.ListAbsences")
+	defer span.End()
+	defer metrics.ObserveQuery("list_absences", time.Now())
+
+	if !actor.Admin && actor.EmployeeId == 0 {
+		return nil, fmt.Errorf("leave_repo: list_absences: caller is not an employee: %w", ErrLeaveForbidden)
+	}
+
+	statuses := []string{models.LeaveApproved}
+	if includePending {
+		statuses = append(statuses, models.LeavePending)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT lr.id, e.id, e.name, e.surname, d.name, d.phone,
+		       CASE WHEN $7 OR e.id = $8 OR e.manager_id = $8 THEN lt.name ELSE '' END,
+		       lr.start_date, lr.end_date, lr.status
+		FROM leave_requests AS lr
+		JOIN leave_types AS lt ON lr.leave_type_id = lt.id
+		JOIN employees AS e ON lr.employee_id = e.id
+		JOIN departments AS d ON e.department_id = d.id
+		WHERE e.company_id = $1 AND lr.start_date <= $3 AND lr.end_date >= $2 AND lr.status = ANY($4)
+		  AND ($5 = '' OR d.name = $5) AND ($6 = '' OR d.phone = $6)
+		  AND ($7 OR e.id = $8 OR e.manager_id = $8
+		    OR (lr.status = 'approved' AND e.department_id = (SELECT department_id FROM employees WHERE id = $8)))
+		ORDER BY lr.start_date, e.id`, companyId, from, to, statuses, department.Name, department.Phone, actor.Admin,
+		actor.EmployeeId)
+	if err != nil {
+		return nil, fmt.Errorf("leave_repo: list_absences: query: %w", err)
+	}
+	defer rows.Close()
+
+	var absences []models.Absence
+	for rows.Next() {
+		var absence models.Absence
+		err = rows.Scan(&absence.LeaveRequestId, &absence.EmployeeId, &absence.Name, &absence.Surname,
+			&absence.Department.Name, &absence.Department.Phone, &absence.LeaveTypeName, &absence.StartDate,
+			&absence.EndDate, &absence.Status)
+		if err != nil {
+			return nil, fmt.Errorf("leave_repo: list_absences: scan: %w", err)
+		}
+		absences = append(absences, absence)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("leave_repo: list_absences: rows: %w", err)
+	}
+	return absences, nil
+}
+
+// querier is implemented by connections and transactions.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// checkLeaveAccess fails with ErrLeaveForbidden unless the actor is a leave
+// admin, the employee or their manager.
+func checkLeaveAccess(ctx context.Context, q querier, employeeId int32, actor models.LeaveActor) error {
+	if actor.Admin || (actor.EmployeeId != 0 && actor.EmployeeId == employeeId) {
+		return nil
+	}
+
+	var managerId *int32
+	err := q.QueryRow(ctx, "SELECT manager_id FROM employees WHERE id = $1", employeeId).Scan(&managerId)
+	if err != nil {
+		return fmt.Errorf("check leave access: query row manager: %w", err)
+	}
+	if actor.EmployeeId == 0 || managerId == nil || *managerId != actor.EmployeeId {
+		return fmt.Errorf("check leave access: not employee %d or their manager: %w", employeeId, ErrLeaveForbidden)
+	}
+	return nil
+}
+
+func companyLeaveTypes(ctx context.Context, q querier, companyId int32) ([]models.LeaveType, error) {
+	rows, err := q.Query(ctx, `
+		SELECT`+leaveTypeColumns+`
+		FROM leave_types AS lt
+		WHERE lt.company_id = $1
+		ORDER BY lt.name`, companyId)
+	if err != nil {
+		return nil, fmt.Errorf("company leave types: query: %w", err)
+	}
+	defer rows.Close()
+
+	var leaveTypes []models.LeaveType
+	for rows.Next() {
+		leaveType, err := scanLeaveType(rows)
+		if err != nil {
+			return nil, fmt.Errorf("company leave types: %w", err)
+		}
+		leaveTypes = append(leaveTypes, leaveType)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("company leave types: rows: %w", err)
+	}
+	return leaveTypes, nil
+}
+
+// employeeLeaveType returns the leave type, which must belong to the company
+// of the employee.
+func employeeLeaveType(ctx context.Context, tx pgx.Tx, employeeId, leaveTypeId int32) (models.LeaveType, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT`+leaveTypeColumns+`
+		FROM leave_types AS lt
+		JOIN employees AS e ON e.company_id = lt.company_id
+		WHERE lt.id = $1 AND e.id = $2`, leaveTypeId, employeeId)
+	leaveType, err := scanLeaveType(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.LeaveType{}, fmt.Errorf("employee leave type: leave type %d of employee %d: %w",
+			leaveTypeId, employeeId, ErrLeaveTypeNotFound)
+	}
+	if err != nil {
+		return models.LeaveType{}, fmt.Errorf("employee leave type: %w", err)
+	}
+	return leaveType, nil
+}
+
+func getLeaveRequest(ctx context.Context, tx pgx.Tx, id int32, forUpdate bool) (models.LeaveRequest, error) {
+	query := `
+		SELECT` + leaveRequestColumns + `
+		FROM leave_requests AS lr
+		JOIN leave_types AS lt ON lr.leave_type_id = lt.id
+		WHERE lr.id = $1`
+	if forUpdate {
+		query += " FOR UPDATE OF lr"
+	}
+
+	request, err := scanLeaveRequest(tx.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.LeaveRequest{}, fmt.Errorf("get leave request: %w", ErrLeaveRequestNotFound)
+	}
+	if err != nil {
+		return models.LeaveRequest{}, fmt.Errorf("get leave request: %w", err)
+	}
+	return request, nil
+}
+
+// leaveBalance accrues and returns the balance of the employee for leaveType,
+// with the days of pending requests.
+// employeeLeaveBalances returns the balances of the employee for every leave
+// type of the company, accruing them first.
+func employeeLeaveBalances(ctx context.Context, tx pgx.Tx, companyId, employeeId int32) ([]models.LeaveBalance, error) {
+	leaveTypes, err := companyLeaveTypes(ctx, tx, companyId)
+	if err != nil {
+		return nil, fmt.Errorf("employee leave balances: %w", err)
+	}
+
+	balances := make([]models.LeaveBalance, 0, len(leaveTypes))
+	for _, leaveType := range leaveTypes {
+		balance, err := leaveBalance(ctx, tx, employeeId, leaveType)
+		if err != nil {
+			return nil, fmt.Errorf("employee leave balances: %w", err)
+		}
+		balances = append(balances, balance)
+	}
+	return balances, nil
+}
+
+// employeeLeaveRequests returns the leave requests of the employee with one of
+// statuses, latest first. No statuses means all.
+func employeeLeaveRequests(ctx context.Context, q querier, employeeId int32, statuses []string) ([]models.LeaveRequest, error) {
+	rows, err := q.Query(ctx, `
+		SELECT`+leaveRequestColumns+`
+		FROM leave_requests AS lr
+		JOIN leave_types AS lt ON lr.leave_type_id = lt.id
+		WHERE lr.employee_id = $1 AND (COALESCE(cardinality($2::TEXT[]), 0) = 0 OR lr.status = ANY($2))
+		ORDER BY lr.start_date DESC, lr.id DESC`, employeeId, statuses)
+	if err != nil {
+		return nil, fmt.Errorf("employee leave requests: query: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []models.LeaveRequest
+	for rows.Next() {
+		request, err := scanLeaveRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("employee leave requests: %w", err)
+		}
+		requests = append(requests, request)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("employee leave requests: rows: %w", err)
+	}
+	return requests, nil
+}
+
+// leaveLedger returns every change of the balances of the employee, oldest
+// first.
+func leaveLedger(ctx context.Context, q querier, employeeId int32) ([]models.LeaveLedgerEntry, error) {
+	rows, err := q.Query(ctx, `
+		SELECT ll.id, ll.leave_type_id, lt.name, ll.days, ll.kind, ll.leave_request_id, ll.reason, ll.caller_id,
+		       ll.created_at
+		FROM leave_ledger AS ll
+		JOIN leave_types AS lt ON ll.leave_type_id = lt.id
+		WHERE ll.employee_id = $1
+		ORDER BY ll.id`, employeeId)
+	if err != nil {
+		return nil, fmt.Errorf("leave ledger: query: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.LeaveLedgerEntry
+	for rows.Next() {
+		var entry models.LeaveLedgerEntry
+		err = rows.Scan(&entry.Id, &entry.LeaveTypeId, &entry.LeaveTypeName, &entry.Days, &entry.Kind,
+			&entry.LeaveRequestId, &entry.Reason, &entry.CallerId, &entry.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("leave ledger: scan: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("leave ledger: rows: %w", err)
+	}
+	return entries, nil
+}
+
+func leaveBalance(ctx context.Context, tx pgx.Tx, employeeId int32, leaveType models.LeaveType) (models.LeaveBalance, error) {
+	balance := models.LeaveBalance{LeaveType: leaveType}
+
+	if !leaveType.Unlimited {
+		var err error
+		if balance.Balance, err = accrueBalance(ctx, tx, employeeId, leaveType); err != nil {
+			return models.LeaveBalance{}, err
+		}
+	}
+
+	err := tx.QueryRow(ctx, `
+		SELECT COALESCE(SUM(days), 0)
+		FROM leave_requests
+		WHERE employee_id = $1 AND leave_type_id = $2 AND status = 'pending'`, employeeId, leaveType.Id).
+		Scan(&balance.Pending)
+	if err != nil {
+		return models.LeaveBalance{}, fmt.Errorf("leave balance: query row pending days: %w", err)
+	}
+	return balance, nil
+}
+
+// accrueBalance credits the balance of the employee with the accrual of every
+// month started since it was last accrued, and returns it locked. Balances
+// start accruing the month after the hire, and only while the employee works
+// for the company. After a rehire, accrual restarts from the new hire date, so
+// the months between termination and rehire are not credited.
+func accrueBalance(ctx context.Context, tx pgx.Tx, employeeId int32, leaveType models.LeaveType) (float64, error) {
+	thisMonth := monthStart(today())
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO leave_balances (employee_id, leave_type_id, accrued_through)
+		SELECT id, $2, date_trunc('month', GREATEST(COALESCE(hire_date, $3), $4::DATE))::DATE
+		FROM employees
+		WHERE id = $1
+		ON CONFLICT DO NOTHING`, employeeId, leaveType.Id, thisMonth, leaveType.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("accrue balance: insert balance: %w", err)
+	}
+
+	var balance float64
+	var accruedThrough time.Time
+	var hireDate *time.Time
+	var employeeStatus string
+	err = tx.QueryRow(ctx, `
+		SELECT b.balance, b.accrued_through, e.hire_date, e.status
+		FROM leave_balances AS b
+		JOIN employees AS e ON b.employee_id = e.id
+		WHERE b.employee_id = $1 AND b.leave_type_id = $2
+		FOR UPDATE OF b`, employeeId, leaveType.Id).Scan(&balance, &accruedThrough, &hireDate, &employeeStatus)
+	if err != nil {
+		return 0, fmt.Errorf("accrue balance: query row balance: %w", err)
+	}
+	if hireDate != nil && monthStart(*hireDate).After(accruedThrough) {
+		accruedThrough = monthStart(*hireDate)
+	}
+
+	months := (thisMonth.Year()-accruedThrough.Year())*12 + int(thisMonth.Month()-accruedThrough.Month())
+	if months <= 0 {
+		return balance, nil
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE leave_balances SET accrued_through = $3 WHERE employee_id = $1 AND leave_type_id = $2",
+		employeeId, leaveType.Id, thisMonth)
+	if err != nil {
+		return 0, fmt.Errorf("accrue balance: update accrued through: %w", err)
+	}
+
+	accrual := float64(months) * leaveType.AccrualDaysPerMonth
+	if leaveType.MaxBalance != nil {
+		accrual = math.Max(0, math.Min(accrual, *leaveType.MaxBalance-balance))
+	}
+	if employeeStatus == models.StatusCandidate || employeeStatus == models.StatusTerminated || accrual == 0 {
+		return balance, nil
+	}
+
+	reason := fmt.Sprintf("%d month(s) through %s", months, thisMonth.Format(models.DateLayout))
+	if err = changeBalance(ctx, tx, employeeId, leaveType.Id, accrual, models.LedgerAccrual, nil, reason); err != nil {
+		return 0, fmt.Errorf("accrue balance: %w", err)
+	}
+	return balance + accrual, nil
+}
+
+// changeBalance adds days to a balance and records the change in the ledger.
+func changeBalance(ctx context.Context, tx pgx.Tx, employeeId, leaveTypeId int32, days float64, kind string,
+	leaveRequestId *int32, reason string) error {
+	_, err := tx.Exec(ctx, "UPDATE leave_balances SET balance = balance + $3 WHERE employee_id = $1 AND leave_type_id = $2",
+		employeeId, leaveTypeId, days)
+	if err != nil {
+		return fmt.Errorf("change balance: update balance: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO leave_ledger (employee_id, leave_type_id, days, kind, leave_request_id, reason, caller_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		employeeId, leaveTypeId, days, kind, leaveRequestId, reason, auth.CallerFromContext(ctx).Id)
+	if err != nil {
+		return fmt.Errorf("change balance: insert %s: %w", kind, err)
+	}
+	return nil
+}
+
+func monthStart(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// workingDays counts the days from start to end inclusive that fall on a
+// weekday. Public holidays are not known and count as working days.
+func workingDays(start, end time.Time) float64 {
+	var days float64
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		if weekday := date.Weekday(); weekday != time.Saturday && weekday != time.Sunday {
+			days++
+		}
+	}
+	return days
+}
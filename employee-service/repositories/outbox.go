@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"employee-service/models"
+	"encoding/json"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"strconv"
+)
+
+// ChangesChannel is the LISTEN/NOTIFY channel announcing new outbox events. The
+// payload is the company id.
+const ChangesChannel = "employee_changes"
+
+// recordEvent appends an employee change event to the outbox and queues it for
+// the company's webhooks as part of tx.
+// The payload is read from the employee row in tx, so EmployeeDeleted has to be
+// recorded before the row is deleted and the other events after the change.
+func recordEvent(ctx context.Context, tx pgx.Tx, eventType string, employeeId int32, changedFields []string) error {
+	data := models.EmployeeEventData{EmployeeId: employeeId, ChangedFields: changedFields}
+
+	view := models.EmployeeEventView{}
+	err := tx.QueryRow(ctx, `
+		SELECT e.company_id, e.name, e.surname, p.type, d.name, d.phone, e.manager_id, e.position_id, e.status
+		FROM employees AS e
+		JOIN passports AS p ON e.passport_id = p.id
+		JOIN departments AS d ON e.department_id = d.id
+		WHERE e.id = $1`, employeeId).Scan(&data.CompanyId, &view.Name, &view.Surname, &view.PassportType,
+		&view.DepartmentName, &view.DepartmentPhone, &view.ManagerId, &view.PositionId, &view.Status)
+	if err != nil {
+		return fmt.Errorf("record event: query row employee: %w", err)
+	}
+	if eventType != models.EventEmployeeDeleted {
+		data.Employee = &view
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("record event: marshal %s: %w", eventType, err)
+	}
+
+	var eventId int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO outbox (employee_id, company_id, event_type, payload)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`,
+		employeeId, data.CompanyId, eventType, payload).Scan(&eventId)
+	if err != nil {
+		return fmt.Errorf("record event: insert %s: %w", eventType, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload)
+		SELECT id, $1, $2, $3
+		FROM webhooks
+		WHERE company_id = $4 AND $2 = ANY(event_types)`,
+		eventId, eventType, payload, data.CompanyId)
+	if err != nil {
+		return fmt.Errorf("record event: queue webhook deliveries: %w", err)
+	}
+
+	// Delivered on commit; watchers of the company then read the change feed.
+	_, err = tx.Exec(ctx, "SELECT pg_notify($1, $2)", ChangesChannel, strconv.Itoa(int(data.CompanyId)))
+	if err != nil {
+		return fmt.Errorf("record event: notify %s: %w", eventType, err)
+	}
+	return nil
+}
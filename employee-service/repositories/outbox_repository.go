@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"context"
+	"employee-service/metrics"
+	"employee-service/models"
+	"employee-service/tracing"
+	"fmt"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"strconv"
+	"time"
+)
+
+// outboxLockKey is the advisory lock held while a batch is published, so that
+// concurrent relays cannot reorder the events of an employee.
+const outboxLockKey = 0x6f7574626f78
+
+type OutboxRepositoryInterface interface {
+	PublishPending(ctx context.Context, limit int, publish func(context.Context, []models.Event) error) (int, error)
+	DeletePublished(ctx context.Context, before time.Time) (int64, error)
+	ChangesSince(ctx context.Context, companyId int32, after models.ChangePosition, limit int) ([]models.Event, error)
+	CurrentPosition(ctx context.Context) (models.ChangePosition, error)
+}
+
+type OutboxRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOutboxRepository(db *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// PublishPending passes up to limit unpublished events to publish in the order
+// they were written and marks them published once publish returns nil. If
+// publish fails, or the events cannot be marked, the whole batch is offered
+// again on the next call. It returns 0 without calling publish while another
+// relay holds the outbox.
+func (r *OutboxRepository) PublishPending(ctx context.Context, limit int, publish func(context.Context, []models.Event) error) (int, error) {
+	ctx, span := tracing.Start(ctx, "OutboxRepository.PublishPending")
+	defer span.End()
+	defer metrics.ObserveQuery("publish_pending_events", time.Now())
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("outbox_repo: publish_pending: acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("outbox_repo: publish_pending: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var locked bool
+	if err = tx.QueryRow(ctx, "SELECT pg_try_advisory_xact_lock($1)", outboxLockKey).Scan(&locked); err != nil {
+		return 0, fmt.Errorf("outbox_repo: publish_pending: lock outbox: %w", err)
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT id, event_type, employee_id, payload, created_at
+		FROM outbox
+		WHERE published_at IS NULL
+		ORDER BY id
+		LIMIT $1`, limit)
+	if err != nil {
+		return 0, fmt.Errorf("outbox_repo: publish_pending: query: %w", err)
+	}
+
+	var events []models.Event
+	var ids []int64
+	for rows.Next() {
+		var event models.Event
+		err = rows.Scan(&event.Id, &event.Type, &event.EmployeeId, &event.Payload, &event.CreatedAt)
+		if err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("outbox_repo: publish_pending: scan: %w", err)
+		}
+		events = append(events, event)
+		ids = append(ids, event.Id)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("outbox_repo: publish_pending: rows: %w", err)
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	if err = publish(ctx, events); err != nil {
+		return 0, fmt.Errorf("outbox_repo: publish_pending: publish: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE outbox SET published_at = now() WHERE id = ANY($1)", ids)
+	if err != nil {
+		return 0, fmt.Errorf("outbox_repo: publish_pending: mark published: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("outbox_repo: publish_pending: commit transaction: %w", err)
+	}
+
+	return len(events), nil
+}
+
+// DeletePublished removes events published before the given time.
+func (r *OutboxRepository) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := tracing.Start(ctx, "OutboxRepository.DeletePublished")
+	defer span.End()
+	defer metrics.ObserveQuery("delete_published_events", time.Now())
+
+	tag, err := r.db.Exec(ctx, "DELETE FROM outbox WHERE published_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("outbox_repo: delete_published: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// ChangesSince returns up to limit events of the company after the given
+// position. Only events of transactions older than every running transaction
+// are returned, so an event never shows up behind a position already read.
+func (r *OutboxRepository) ChangesSince(ctx context.Context, companyId int32, after models.ChangePosition, limit int) ([]models.Event, error) {
+	ctx, span := tracing.Start(ctx, "OutboxRepository.ChangesSince")
+	defer span.End()
+	defer metrics.ObserveQuery("changes_since", time.Now())
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, event_type, employee_id, payload, created_at, tx_id::TEXT
+		FROM outbox
+		WHERE company_id = $1
+		  AND (tx_id, id) > ($2::TEXT::XID8, $3)
+		  AND tx_id < pg_snapshot_xmin(pg_current_snapshot())
+		ORDER BY tx_id, id
+		LIMIT $4`, companyId, strconv.FormatUint(after.TxId, 10), after.Id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("outbox_repo: changes_since: query: %w", err)
+	}
+	defer rows.Close()
+
+	var events []models.Event
+	for rows.Next() {
+		var event models.Event
+		var txId string
+		err = rows.Scan(&event.Id, &event.Type, &event.EmployeeId, &event.Payload, &event.CreatedAt, &txId)
+		if err != nil {
+			return nil, fmt.Errorf("outbox_repo: changes_since: scan: %w", err)
+		}
+		event.Position.Id = event.Id
+		if event.Position.TxId, err = strconv.ParseUint(txId, 10, 64); err != nil {
+			return nil, fmt.Errorf("outbox_repo: changes_since: parse tx id: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("outbox_repo: changes_since: rows: %w", err)
+	}
+	return events, nil
+}
+
+// CurrentPosition returns a position before every event not yet visible to
+// ChangesSince, for watchers that only want future changes.
+func (r *OutboxRepository) CurrentPosition(ctx context.Context) (models.ChangePosition, error) {
+	ctx, span := tracing.Start(ctx, "OutboxRepository.CurrentPosition")
+	defer span.End()
+	defer metrics.ObserveQuery("current_change_position", time.Now())
+
+	var xmin string
+	err := r.db.QueryRow(ctx, "SELECT pg_snapshot_xmin(pg_current_snapshot())::TEXT").Scan(&xmin)
+	if err != nil {
+		return models.ChangePosition{}, fmt.Errorf("outbox_repo: current_position: query row: %w", err)
+	}
+
+	txId, err := strconv.ParseUint(xmin, 10, 64)
+	if err != nil {
+		return models.ChangePosition{}, fmt.Errorf("outbox_repo: current_position: parse tx id: %w", err)
+	}
+	// Event ids start at 1, so every event of transaction xmin is still ahead.
+	return models.ChangePosition{TxId: txId}, nil
+}
@@ -0,0 +1,177 @@
+package repositories
+
+import (
+	"context"
+	"employee-service/encryption"
+	"employee-service/metrics"
+	"employee-service/tracing"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"time"
+)
+
+// encryptedPassportNumber holds the column values written for a passport
+// number. All fields are nil for an empty number so that the unique blind
+// index does not collide between employees without a passport.
+type encryptedPassportNumber struct {
+	ciphertext []byte
+	dek        []byte
+	keyId      *string
+	index      *string
+}
+
+// storedPassportNumber holds the column values read for a passport number.
+// plaintext is only set for rows written before encryption was enabled and
+// not yet processed by EncryptPassports.
+type storedPassportNumber struct {
+	plaintext  *string
+	ciphertext []byte
+	dek        []byte
+	keyId      *string
+}
+
+func (r *EmployeeRepository) encryptPassportNumber(number string) (encryptedPassportNumber, error) {
+	if number == "" {
+		return encryptedPassportNumber{}, nil
+	}
+
+	envelope, err := r.keyring.Encrypt(number)
+	if err != nil {
+		return encryptedPassportNumber{}, fmt.Errorf("encrypt passport number: %w", err)
+	}
+
+	index := r.keyring.BlindIndex(number)
+	return encryptedPassportNumber{
+		ciphertext: envelope.Ciphertext,
+		dek:        envelope.WrappedKey,
+		keyId:      &envelope.KeyID,
+		index:      &index,
+	}, nil
+}
+
+func (r *EmployeeRepository) decryptPassportNumber(number storedPassportNumber) (string, error) {
+	if number.keyId == nil {
+		if number.plaintext == nil {
+			return "", nil
+		}
+		return *number.plaintext, nil
+	}
+
+	plaintext, err := r.keyring.Decrypt(encryption.Envelope{
+		KeyID:      *number.keyId,
+		WrappedKey: number.dek,
+		Ciphertext: number.ciphertext,
+	})
+	if err != nil {
+		return "", fmt.Errorf("decrypt passport number: %w", err)
+	}
+	return plaintext, nil
+}
+
+// passportRegistered reports whether a passport with the given blind index
+// belongs to an employee other than exceptEmployeeId. Pass 0 to check against
+// all employees.
+func passportRegistered(ctx context.Context, tx pgx.Tx, index string, exceptEmployeeId int32) (bool, error) {
+	var registered bool
+
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM passports AS p
+			JOIN employees AS e ON e.passport_id = p.id
+			WHERE p.number_index = $1 AND e.id <> $2)`,
+		index, exceptEmployeeId).Scan(&registered)
+	if err != nil {
+		return false, fmt.Errorf("passport registered: query row passport: %w", err)
+	}
+	return registered, nil
+}
+
+// EncryptPassports encrypts passport numbers still stored in plaintext and
+// re-wraps the data keys of numbers encrypted with a retired keyring key. It
+// returns the number of rows changed and is safe to run repeatedly.
+func (r *EmployeeRepository) EncryptPassports(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.EncryptPassports")
+	defer span.End()
+	defer metrics.ObserveQuery("encrypt_passports", time.Now())
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("employee_repo: encrypt_passports: acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("employee_repo: encrypt_passports: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT id, number, number_ciphertext, number_dek, number_key_id
+		FROM passports
+		WHERE number IS NOT NULL OR number_key_id <> $1
+		FOR UPDATE`, r.keyring.ActiveKeyID())
+	if err != nil {
+		return 0, fmt.Errorf("employee_repo: encrypt_passports: query: %w", err)
+	}
+
+	type pending struct {
+		id     int32
+		number storedPassportNumber
+	}
+	var passports []pending
+	for rows.Next() {
+		var passport pending
+		err = rows.Scan(&passport.id, &passport.number.plaintext, &passport.number.ciphertext,
+			&passport.number.dek, &passport.number.keyId)
+		if err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("employee_repo: encrypt_passports: scan: %w", err)
+		}
+		passports = append(passports, passport)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("employee_repo: encrypt_passports: rows: %w", err)
+	}
+
+	for _, passport := range passports {
+		if passport.number.keyId != nil {
+			envelope, err := r.keyring.Rewrap(encryption.Envelope{
+				KeyID:      *passport.number.keyId,
+				WrappedKey: passport.number.dek,
+				Ciphertext: passport.number.ciphertext,
+			})
+			if err != nil {
+				return 0, fmt.Errorf("employee_repo: encrypt_passports: passport %d: %w", passport.id, err)
+			}
+
+			_, err = tx.Exec(ctx, "UPDATE passports SET number = NULL, number_dek = $1, number_key_id = $2 WHERE id = $3",
+				envelope.WrappedKey, envelope.KeyID, passport.id)
+			if err != nil {
+				return 0, fmt.Errorf("employee_repo: encrypt_passports: rewrap passport %d: %w", passport.id, err)
+			}
+			continue
+		}
+
+		number, err := r.encryptPassportNumber(*passport.number.plaintext)
+		if err != nil {
+			return 0, fmt.Errorf("employee_repo: encrypt_passports: passport %d: %w", passport.id, err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE passports
+			SET number = NULL, number_ciphertext = $1, number_dek = $2, number_key_id = $3, number_index = $4
+			WHERE id = $5`,
+			number.ciphertext, number.dek, number.keyId, number.index, passport.id)
+		if err != nil {
+			return 0, fmt.Errorf("employee_repo: encrypt_passports: encrypt passport %d: %w", passport.id, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("employee_repo: encrypt_passports: commit transaction: %w", err)
+	}
+
+	return len(passports), nil
+}
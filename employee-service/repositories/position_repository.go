@@ -0,0 +1,362 @@
+package repositories
+
+import (
+	"context"
+	"employee-service/metrics"
+	"employee-service/models"
+	"employee-service/tracing"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"strings"
+	"time"
+)
+
+var (
+	ErrPositionNotFound = errors.New("position not found")
+	ErrPositionExists   = errors.New("position already exists")
+	// ErrPositionFilled is returned when a position with assigned employees
+	// is deleted or moved to another department.
+	ErrPositionFilled = errors.New("position has assigned employees")
+	// ErrInvalidPosition is returned when an employee is assigned a position
+	// of another company or department, or a terminated employee any position.
+	ErrInvalidPosition = errors.New("invalid position")
+)
+
+type PositionRepositoryInterface interface {
+	CreatePosition(ctx context.Context, position models.Position) (models.Position, error)
+	ListPositions(ctx context.Context, companyId int32) ([]models.Position, error)
+	UpdatePosition(ctx context.Context, companyId, id int32, update models.PositionUpdate) (models.Position, error)
+	DeletePosition(ctx context.Context, companyId, id int32) error
+	VacancyReport(ctx context.Context, companyId int32) ([]models.DepartmentHeadcount, error)
+}
+
+type PositionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPositionRepository(db *pgxpool.Pool) *PositionRepository {
+	return &PositionRepository{db: db}
+}
+
+// positionColumns are the columns read by scanPosition, for queries on
+// positions AS p joining departments AS d.
+const positionColumns = `
+		p.id, p.company_id, p.title, p.grade, d.name, d.phone, p.budgeted_headcount,
+		(SELECT COUNT(*) FROM employees WHERE position_id = p.id)::INT, p.created_at`
+
+func scanPosition(row pgx.Row) (models.Position, error) {
+	var position models.Position
+	err := row.Scan(&position.Id, &position.CompanyId, &position.Title, &position.Grade,
+		&position.Department.Name, &position.Department.Phone, &position.BudgetedHeadcount,
+		&position.FilledHeadcount, &position.CreatedAt)
+	if err != nil {
+		return models.Position{}, fmt.Errorf("scan position: %w", err)
+	}
+	return position, nil
+}
+
+func (r *PositionRepository) CreatePosition(ctx context.Context, position models.Position) (models.Position, error) {
+	ctx, span := tracing.Start(ctx, "PositionRepository.CreatePosition")
+	defer span.End()
+	defer metrics.ObserveQuery("create_position", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.Position{}, fmt.Errorf("position_repo: create_position: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	departmentId, err := ensureDepartment(ctx, tx, position.Department)
+	if err != nil {
+		return models.Position{}, fmt.Errorf("position_repo: create_position: %w", err)
+	}
+
+	var id int32
+	err = tx.QueryRow(ctx, `
+		INSERT INTO positions (company_id, title, grade, department_id, budgeted_headcount)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`,
+		position.CompanyId, position.Title, position.Grade, departmentId, position.BudgetedHeadcount).Scan(&id)
+	if isUniqueViolation(err) {
+		return models.Position{}, fmt.Errorf("position_repo: create_position: %w", ErrPositionExists)
+	}
+	if err != nil {
+		return models.Position{}, fmt.Errorf("position_repo: create_position: insert position: %w", err)
+	}
+
+	created, err := getPosition(ctx, tx, position.CompanyId, id)
+	if err != nil {
+		return models.Position{}, fmt.Errorf("position_repo: create_position: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.Position{}, fmt.Errorf("position_repo: create_position: commit transaction: %w", err)
+	}
+	return created, nil
+}
+
+func (r *PositionRepository) ListPositions(ctx context.Context, companyId int32) ([]models.Position, error) {
+	ctx, span := tracing.Start(ctx, "PositionRepository.ListPositions")
+	defer span.End()
+	defer metrics.ObserveQuery("list_positions", time.Now())
+
+	rows, err := r.db.Query(ctx, `
+		SELECT`+positionColumns+`
+		FROM positions AS p
+		JOIN departments AS d ON p.department_id = d.id
+		WHERE p.company_id = $1
+		ORDER BY d.name, d.phone, p.title, p.grade`, companyId)
+	if err != nil {
+		return nil, fmt.Errorf("position_repo: list_positions: query: %w", err)
+	}
+	defer rows.Close()
+
+	var positions []models.Position
+	for rows.Next() {
+		position, err := scanPosition(rows)
+		if err != nil {
+			return nil, fmt.Errorf("position_repo: list_positions: %w", err)
+		}
+		positions = append(positions, position)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("position_repo: list_positions: rows: %w", err)
+	}
+	return positions, nil
+}
+
+func (r *PositionRepository) UpdatePosition(ctx context.Context, companyId, id int32, update models.PositionUpdate) (models.Position, error) {
+	ctx, span := tracing.Start(ctx, "PositionRepository.UpdatePosition")
+	defer span.End()
+	defer metrics.ObserveQuery("update_position", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.Position{}, fmt.Errorf("position_repo: update_position: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	current, err := getPosition(ctx, tx, companyId, id)
+	if err != nil {
+		return models.Position{}, fmt.Errorf("position_repo: update_position: %w", err)
+	}
+
+	fields := make([]string, 0)
+	args := []interface{}{}
+	set := func(column string, value interface{}) {
+		args = append(args, value)
+		fields = append(fields, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if update.Title != "" {
+		set("title", update.Title)
+	}
+	if update.Grade != nil {
+		set("grade", *update.Grade)
+	}
+	if update.BudgetedHeadcount != nil {
+		set("budgeted_headcount", *update.BudgetedHeadcount)
+	}
+
+	var oldDepartmentId int32
+	if update.Department.Name != "" && update.Department != current.Department {
+		if current.FilledHeadcount > 0 {
+			return models.Position{}, fmt.Errorf("position_repo: update_position: move to another department: %w", ErrPositionFilled)
+		}
+		err = tx.QueryRow(ctx, "SELECT department_id FROM positions WHERE id = $1", id).Scan(&oldDepartmentId)
+		if err != nil {
+			return models.Position{}, fmt.Errorf("position_repo: update_position: query row department id: %w", err)
+		}
+		newDepartmentId, err := ensureDepartment(ctx, tx, update.Department)
+		if err != nil {
+			return models.Position{}, fmt.Errorf("position_repo: update_position: %w", err)
+		}
+		set("department_id", newDepartmentId)
+	}
+
+	if len(fields) > 0 {
+		args = append(args, id)
+		_, err = tx.Exec(ctx, fmt.Sprintf("UPDATE positions SET %s WHERE id = $%d", strings.Join(fields, ", "), len(args)), args...)
+		if isUniqueViolation(err) {
+			return models.Position{}, fmt.Errorf("position_repo: update_position: %w", ErrPositionExists)
+		}
+		if err != nil {
+			return models.Position{}, fmt.Errorf("position_repo: update_position: update position: %w", err)
+		}
+	}
+
+	if oldDepartmentId != 0 {
+		if err = cleanUnusedDepartments(ctx, tx, oldDepartmentId); err != nil {
+			return models.Position{}, fmt.Errorf("position_repo: update_position: %w", err)
+		}
+	}
+
+	updated, err := getPosition(ctx, tx, companyId, id)
+	if err != nil {
+		return models.Position{}, fmt.Errorf("position_repo: update_position: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.Position{}, fmt.Errorf("position_repo: update_position: commit transaction: %w", err)
+	}
+	return updated, nil
+}
+
+// DeletePosition removes a position without assigned employees.
+func (r *PositionRepository) DeletePosition(ctx context.Context, companyId, id int32) error {
+	ctx, span := tracing.Start(ctx, "PositionRepository.DeletePosition")
+	defer span.End()
+	defer metrics.ObserveQuery("delete_position", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("position_repo: delete_position: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var departmentId int32
+	var filled bool
+	err = tx.QueryRow(ctx, `
+		SELECT department_id, EXISTS(SELECT 1 FROM employees WHERE position_id = p.id)
+		FROM positions AS p
+		WHERE id = $1 AND company_id = $2
+		FOR UPDATE`, id, companyId).Scan(&departmentId, &filled)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("position_repo: delete_position: %w", ErrPositionNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("position_repo: delete_position: query row position: %w", err)
+	}
+	if filled {
+		return fmt.Errorf("position_repo: delete_position: %w", ErrPositionFilled)
+	}
+
+	if _, err = tx.Exec(ctx, "DELETE FROM positions WHERE id = $1", id); err != nil {
+		return fmt.Errorf("position_repo: delete_position: delete position: %w", err)
+	}
+	if err = cleanUnusedDepartments(ctx, tx, departmentId); err != nil {
+		return fmt.Errorf("position_repo: delete_position: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("position_repo: delete_position: commit transaction: %w", err)
+	}
+	return nil
+}
+
+// VacancyReport compares budgeted and filled headcount per department of the
+// company. Departments without positions are left out.
+func (r *PositionRepository) VacancyReport(ctx context.Context, companyId int32) ([]models.DepartmentHeadcount, error) {
+	ctx, span := tracing.Start(ctx, "PositionRepository.VacancyReport")
+	defer span.End()
+	defer metrics.ObserveQuery("vacancy_report", time.Now())
+
+	rows, err := r.db.Query(ctx, `
+		WITH headcount AS (
+			SELECT p.id, p.department_id, p.budgeted_headcount AS budgeted, COUNT(e.id)::INT AS filled
+			FROM positions AS p
+			LEFT JOIN employees AS e ON e.position_id = p.id
+			WHERE p.company_id = $1
+			GROUP BY p.id
+		)
+		SELECT d.name, d.phone, COUNT(*)::INT, SUM(h.budgeted)::INT, SUM(h.filled)::INT,
+		       SUM(GREATEST(h.budgeted - h.filled, 0))::INT, SUM(GREATEST(h.filled - h.budgeted, 0))::INT
+		FROM headcount AS h
+		JOIN departments AS d ON h.department_id = d.id
+		GROUP BY d.id, d.name, d.phone
+		ORDER BY d.name, d.phone`, companyId)
+	if err != nil {
+		return nil, fmt.Errorf("position_repo: vacancy_report: query: %w", err)
+	}
+	defer rows.Close()
+
+	var report []models.DepartmentHeadcount
+	for rows.Next() {
+		var line models.DepartmentHeadcount
+		err = rows.Scan(&line.Department.Name, &line.Department.Phone, &line.Positions, &line.Budgeted,
+			&line.Filled, &line.Open, &line.Overfilled)
+		if err != nil {
+			return nil, fmt.Errorf("position_repo: vacancy_report: scan: %w", err)
+		}
+		report = append(report, line)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("position_repo: vacancy_report: rows: %w", err)
+	}
+	return report, nil
+}
+
+func getPosition(ctx context.Context, tx pgx.Tx, companyId, id int32) (models.Position, error) {
+	position, err := scanPosition(tx.QueryRow(ctx, `
+		SELECT`+positionColumns+`
+		FROM positions AS p
+		JOIN departments AS d ON p.department_id = d.id
+		WHERE p.id = $1 AND p.company_id = $2`, id, companyId))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Position{}, ErrPositionNotFound
+	}
+	if err != nil {
+		return models.Position{}, fmt.Errorf("get position: %w", err)
+	}
+	return position, nil
+}
+
+// ensureDepartment returns the id of the department, creating it if needed.
+func ensureDepartment(ctx context.Context, tx pgx.Tx, department models.Department) (int32, error) {
+	exists, err := departmentExists(ctx, tx, department)
+	if err != nil {
+		return 0, err
+	}
+	return createOrGetDepartmentId(ctx, tx, exists, department)
+}
+
+// setPosition assigns positionId to employeeId, or unassigns it when
+// positionId is 0. The position must be in the employee's company and
+// department.
+func setPosition(ctx context.Context, tx pgx.Tx, employeeId, positionId int32) error {
+	if positionId == 0 {
+		if _, err := tx.Exec(ctx, "UPDATE employees SET position_id = NULL WHERE id = $1", employeeId); err != nil {
+			return fmt.Errorf("set position: clear position: %w", err)
+		}
+		return nil
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE employees AS e
+		SET position_id = p.id
+		FROM positions AS p
+		WHERE e.id = $1 AND p.id = $2 AND p.company_id = e.company_id AND p.department_id = e.department_id
+		  AND e.status <> 'terminated'`,
+		employeeId, positionId)
+	if err != nil {
+		return fmt.Errorf("set position: update position: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("set position: position %d is not in the employee's company and department, "+
+			"or the employee is terminated: %w", positionId, ErrInvalidPosition)
+	}
+	return nil
+}
+
+// detachMismatchedPosition unassigns the position of employeeId after the
+// employee has moved out of its company or department.
+func detachMismatchedPosition(ctx context.Context, tx pgx.Tx, employeeId int32) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE employees AS e
+		SET position_id = NULL
+		FROM positions AS p
+		WHERE e.id = $1 AND e.position_id = p.id
+		  AND (p.company_id <> e.company_id OR p.department_id <> e.department_id)`, employeeId)
+	if err != nil {
+		return fmt.Errorf("detach mismatched position: %w", err)
+	}
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
@@ -0,0 +1,481 @@
+package repositories
+
+import (
+	"context"
+	"employee-service/metrics"
+	"employee-service/models"
+	"employee-service/tracing"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTransferNotFound = errors.New("transfer not found")
+	// ErrTransferPending is returned when a transfer is requested for an
+	// employee who already has one scheduled.
+	ErrTransferPending = errors.New("employee already has a pending transfer")
+	// ErrTransferNotPending is returned when an applied, failed or cancelled
+	// transfer is cancelled.
+	ErrTransferNotPending = errors.New("transfer is not pending")
+)
+
+// transferColumns are the columns read by scanTransfer, for queries on
+// transfers.
+const transferColumns = `
+		id, employee_id, COALESCE(company_id, 0), COALESCE(department_name, ''), COALESCE(department_phone, ''),
+		position_id, manager_id, effective_date, reason, status, failure, created_at, applied_at`
+
+func scanTransfer(row pgx.Row) (models.Transfer, error) {
+	var transfer models.Transfer
+	err := row.Scan(&transfer.Id, &transfer.EmployeeId, &transfer.CompanyId, &transfer.Department.Name,
+		&transfer.Department.Phone, &transfer.PositionId, &transfer.ManagerId, &transfer.EffectiveDate,
+		&transfer.Reason, &transfer.Status, &transfer.Failure, &transfer.CreatedAt, &transfer.AppliedAt)
+	if err != nil {
+		return models.Transfer{}, fmt.Errorf("scan transfer: %w", err)
+	}
+	return transfer, nil
+}
+
+// TransferEmployee schedules the transfer of an employee. A transfer that is
+// already effective is applied right away, so its errors are returned and
+// nothing is recorded; later ones are applied by ApplyDueTransfers.
+func (r *EmployeeRepository) TransferEmployee(ctx context.Context, transfer models.Transfer) (models.Transfer, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.TransferEmployee")
+	defer span.End()
+	defer metrics.ObserveQuery("transfer_employee", time.Now())
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return models.Transfer{}, fmt.Errorf("employee_repo: transfer_employee: acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return models.Transfer{}, fmt.Errorf("employee_repo: transfer_employee: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err = validateTransfer(ctx, tx, transfer); err != nil {
+		return models.Transfer{}, fmt.Errorf("employee_repo: transfer_employee: %w", err)
+	}
+
+	row := tx.QueryRow(ctx, `
+		INSERT INTO transfers (employee_id, company_id, department_name, department_phone, position_id, manager_id,
+		                       effective_date, reason)
+		VALUES ($1, NULLIF($2, 0), NULLIF($3, ''), NULLIF($4, ''), $5, $6, $7, $8)
+		RETURNING`+transferColumns,
+		transfer.EmployeeId, transfer.CompanyId, transfer.Department.Name, transfer.Department.Phone,
+		transfer.PositionId, transfer.ManagerId, transfer.EffectiveDate, transfer.Reason)
+	transfer, err = scanTransfer(row)
+	if isUniqueViolation(err) {
+		return models.Transfer{}, fmt.Errorf("employee_repo: transfer_employee: %w", ErrTransferPending)
+	}
+	if err != nil {
+		return models.Transfer{}, fmt.Errorf("employee_repo: transfer_employee: insert transfer: %w", err)
+	}
+
+	if !transfer.EffectiveDate.After(today()) {
+		if err = r.applyTransfer(ctx, tx, &transfer); err != nil {
+			return models.Transfer{}, fmt.Errorf("employee_repo: transfer_employee: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.Transfer{}, fmt.Errorf("employee_repo: transfer_employee: commit transaction: %w", err)
+	}
+	return transfer, nil
+}
+
+// CancelTransfer cancels a pending transfer of the employee.
+func (r *EmployeeRepository) CancelTransfer(ctx context.Context, employeeId, id int32) (models.Transfer, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.CancelTransfer")
+	defer span.End()
+	defer metrics.ObserveQuery("cancel_transfer", time.Now())
+
+	row := r.db.QueryRow(ctx, `
+		UPDATE transfers
+		SET status = 'cancelled'
+		WHERE id = $1 AND employee_id = $2 AND status = 'pending'
+		RETURNING`+transferColumns, id, employeeId)
+	transfer, err := scanTransfer(row)
+	if err == nil {
+		return transfer, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return models.Transfer{}, fmt.Errorf("employee_repo: cancel_transfer: %w", err)
+	}
+
+	var exists bool
+	err = r.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM transfers WHERE id = $1 AND employee_id = $2)",
+		id, employeeId).Scan(&exists)
+	if err != nil {
+		return models.Transfer{}, fmt.Errorf("employee_repo: cancel_transfer: query row transfer: %w", err)
+	}
+	if !exists {
+		return models.Transfer{}, fmt.Errorf("employee_repo: cancel_transfer: %w", ErrTransferNotFound)
+	}
+	return models.Transfer{}, fmt.Errorf("employee_repo: cancel_transfer: %w", ErrTransferNotPending)
+}
+
+// ListTransfers returns the transfers of the employee, latest effective first.
+func (r *EmployeeRepository) ListTransfers(ctx context.Context, employeeId int32) ([]models.Transfer, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.ListTransfers")
+	defer span.End()
+	defer metrics.ObserveQuery("list_transfers", time.Now())
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("employee_repo: list_transfers: acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if err = employeeExists(ctx, conn, employeeId); err != nil {
+		return nil, fmt.Errorf("employee_repo: list_transfers: %w", err)
+	}
+
+	transfers, err := employeeTransfers(ctx, conn, employeeId)
+	if err != nil {
+		return nil, fmt.Errorf("employee_repo: list_transfers: %w", err)
+	}
+	return transfers, nil
+}
+
+// AssignmentHistory returns the assignments of the employee, oldest first.
+func (r *EmployeeRepository) AssignmentHistory(ctx context.Context, employeeId int32) ([]models.Assignment, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.AssignmentHistory")
+	defer span.End()
+	defer metrics.ObserveQuery("assignment_history", time.Now())
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("employee_repo: assignment_history: acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if err = employeeExists(ctx, conn, employeeId); err != nil {
+		return nil, fmt.Errorf("employee_repo: assignment_history: %w", err)
+	}
+
+	assignments, err := employeeAssignments(ctx, conn, employeeId)
+	if err != nil {
+		return nil, fmt.Errorf("employee_repo: assignment_history: %w", err)
+	}
+	return assignments, nil
+}
+
+// employeeTransfers returns the transfers of the employee, latest effective
+// first.
+func employeeTransfers(ctx context.Context, q querier, employeeId int32) ([]models.Transfer, error) {
+	rows, err := q.Query(ctx, `
+		SELECT`+transferColumns+`
+		FROM transfers
+		WHERE employee_id = $1
+		ORDER BY effective_date DESC, id DESC`, employeeId)
+	if err != nil {
+		return nil, fmt.Errorf("employee transfers: query: %w", err)
+	}
+	defer rows.Close()
+
+	var transfers []models.Transfer
+	for rows.Next() {
+		transfer, err := scanTransfer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("employee transfers: %w", err)
+		}
+		transfers = append(transfers, transfer)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("employee transfers: rows: %w", err)
+	}
+	return transfers, nil
+}
+
+// employeeAssignments returns the assignments of the employee, oldest first.
+func employeeAssignments(ctx context.Context, q querier, employeeId int32) ([]models.Assignment, error) {
+	rows, err := q.Query(ctx, `
+		SELECT company_id, department_name, department_phone, position_id, started_on, ended_on, transfer_id
+		FROM employee_assignments
+		WHERE employee_id = $1
+		ORDER BY started_on, id`, employeeId)
+	if err != nil {
+		return nil, fmt.Errorf("employee assignments: query: %w", err)
+	}
+	defer rows.Close()
+
+	var assignments []models.Assignment
+	for rows.Next() {
+		var assignment models.Assignment
+		err = rows.Scan(&assignment.CompanyId, &assignment.Department.Name, &assignment.Department.Phone,
+			&assignment.PositionId, &assignment.StartedOn, &assignment.EndedOn, &assignment.TransferId)
+		if err != nil {
+			return nil, fmt.Errorf("employee assignments: scan: %w", err)
+		}
+		assignments = append(assignments, assignment)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("employee assignments: rows: %w", err)
+	}
+	return assignments, nil
+}
+
+// ApplyDueTransfers applies up to limit pending transfers that have become
+// effective, each in its own transaction. A transfer that no longer applies,
+// for example because its position was deleted in the meantime, is marked
+// failed. It returns the transfers it processed.
+func (r *EmployeeRepository) ApplyDueTransfers(ctx context.Context, limit int) ([]models.Transfer, error) {
+	ctx, span := tracing.Start(ctx, "EmployeeRepository.ApplyDueTransfers")
+	defer span.End()
+	defer metrics.ObserveQuery("apply_due_transfers", time.Now())
+
+	var processed []models.Transfer
+	for len(processed) < limit {
+		transfer, found, err := r.applyNextDueTransfer(ctx)
+		if err != nil {
+			return processed, fmt.Errorf("employee_repo: apply_due_transfers: %w", err)
+		}
+		if !found {
+			break
+		}
+		processed = append(processed, transfer)
+	}
+	return processed, nil
+}
+
+func (r *EmployeeRepository) applyNextDueTransfer(ctx context.Context) (models.Transfer, bool, error) {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return models.Transfer{}, false, fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return models.Transfer{}, false, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// SKIP LOCKED lets several instances of the service share the work.
+	row := tx.QueryRow(ctx, `
+		SELECT`+transferColumns+`
+		FROM transfers
+		WHERE status = 'pending' AND effective_date <= $1
+		ORDER BY effective_date, id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`, today())
+	transfer, err := scanTransfer(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Transfer{}, false, nil
+	}
+	if err != nil {
+		return models.Transfer{}, false, err
+	}
+
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return models.Transfer{}, false, fmt.Errorf("begin savepoint: %w", err)
+	}
+	err = r.applyTransfer(ctx, savepoint, &transfer)
+	if err == nil {
+		err = savepoint.Commit(ctx)
+	}
+	if err != nil {
+		if rollbackErr := savepoint.Rollback(ctx); rollbackErr != nil {
+			return models.Transfer{}, false, fmt.Errorf("rollback savepoint: %w", rollbackErr)
+		}
+		if !isTransferRejected(err) {
+			return models.Transfer{}, false, err
+		}
+
+		transfer.Status, transfer.Failure = models.TransferFailed, err.Error()
+		_, err = tx.Exec(ctx, "UPDATE transfers SET status = 'failed', failure = $2 WHERE id = $1",
+			transfer.Id, transfer.Failure)
+		if err != nil {
+			return models.Transfer{}, false, fmt.Errorf("mark transfer failed: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.Transfer{}, false, fmt.Errorf("commit transaction: %w", err)
+	}
+	return transfer, true, nil
+}
+
+// isTransferRejected reports whether err means the transfer cannot be
+// applied, as opposed to a failure worth retrying: a cancelled context, a lost
+// connection, or a deadlock or serialization failure. Any other error marks the
+// transfer failed, or it would block the ones due after it.
+func isTransferRejected(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		pgconn.Timeout(err) || pgconn.SafeToRetry(err) {
+		return false
+	}
+	// Classes 08 and 57 are connection failures and server shutdowns, 40
+	// deadlocks and serialization failures.
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return !strings.HasPrefix(pgErr.Code, "08") && !strings.HasPrefix(pgErr.Code, "40") &&
+			!strings.HasPrefix(pgErr.Code, "57")
+	}
+	return true
+}
+
+// validateTransfer checks a transfer when it is requested: the employee must
+// not be terminated, and the position and manager must belong to the target
+// company. Whether the position fits the department and the manager keeps the
+// reporting lines acyclic is only known when the transfer is applied.
+func validateTransfer(ctx context.Context, tx pgx.Tx, transfer models.Transfer) error {
+	var companyId int32
+	var employeeStatus string
+	err := tx.QueryRow(ctx, "SELECT company_id, status FROM employees WHERE id = $1 FOR UPDATE",
+		transfer.EmployeeId).Scan(&companyId, &employeeStatus)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrEmployeeNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("validate transfer: query row employee: %w", err)
+	}
+	if employeeStatus == models.StatusTerminated {
+		return fmt.Errorf("validate transfer: employee is %s: %w", employeeStatus, ErrInvalidTransition)
+	}
+	if transfer.CompanyId != 0 {
+		companyId = transfer.CompanyId
+	}
+
+	if transfer.PositionId != nil && *transfer.PositionId != 0 {
+		var exists bool
+		err = tx.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM positions WHERE id = $1 AND company_id = $2)",
+			*transfer.PositionId, companyId).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("validate transfer: query row position: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("validate transfer: position %d is not in company %d: %w",
+				*transfer.PositionId, companyId, ErrInvalidPosition)
+		}
+	}
+
+	if transfer.ManagerId != nil && *transfer.ManagerId != 0 {
+		var exists bool
+		err = tx.QueryRow(ctx, `
+			SELECT EXISTS(SELECT 1 FROM employees WHERE id = $1 AND company_id = $2 AND status <> 'terminated')`,
+			*transfer.ManagerId, companyId).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("validate transfer: query row manager: %w", err)
+		}
+		if !exists || *transfer.ManagerId == transfer.EmployeeId {
+			return fmt.Errorf("validate transfer: manager %d is not a current employee of company %d: %w",
+				*transfer.ManagerId, companyId, ErrInvalidManager)
+		}
+	}
+	return nil
+}
+
+// applyTransfer moves the employee as the transfer describes and marks it
+// applied.
+func (r *EmployeeRepository) applyTransfer(ctx context.Context, tx pgx.Tx, transfer *models.Transfer) error {
+	var employeeStatus string
+	err := tx.QueryRow(ctx, "SELECT status FROM employees WHERE id = $1 FOR UPDATE", transfer.EmployeeId).
+		Scan(&employeeStatus)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("apply transfer: %w", ErrEmployeeNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("apply transfer: query row employee: %w", err)
+	}
+	if employeeStatus == models.StatusTerminated {
+		return fmt.Errorf("apply transfer: employee is %s: %w", employeeStatus, ErrInvalidTransition)
+	}
+
+	employee := models.Employee{
+		Id:         transfer.EmployeeId,
+		CompanyId:  transfer.CompanyId,
+		Department: transfer.Department,
+		ManagerId:  transfer.ManagerId,
+		PositionId: transfer.PositionId,
+	}
+	if err = r.updateEmployee(ctx, tx, employee, transfer); err != nil {
+		return fmt.Errorf("apply transfer: %w", err)
+	}
+
+	err = tx.QueryRow(ctx, `
+		UPDATE transfers
+		SET status = 'applied', applied_at = now()
+		WHERE id = $1
+		RETURNING status, applied_at`, transfer.Id).Scan(&transfer.Status, &transfer.AppliedAt)
+	if err != nil {
+		return fmt.Errorf("apply transfer: mark applied: %w", err)
+	}
+	return nil
+}
+
+// recordAssignment starts a new assignment of employeeId on startedOn when the
+// company, department or position of the employee differ from the current
+// one. Terminated employees have no assignment.
+func recordAssignment(ctx context.Context, tx pgx.Tx, employeeId int32, startedOn time.Time, transferId *int32) error {
+	var current models.Assignment
+	var employeeStatus string
+	err := tx.QueryRow(ctx, `
+		SELECT e.company_id, COALESCE(d.name, ''), COALESCE(d.phone, ''), e.position_id, e.status
+		FROM employees AS e
+		JOIN departments AS d ON e.department_id = d.id
+		WHERE e.id = $1`, employeeId).Scan(&current.CompanyId, &current.Department.Name,
+		&current.Department.Phone, &current.PositionId, &employeeStatus)
+	if err != nil {
+		return fmt.Errorf("record assignment: query row employee: %w", err)
+	}
+	if employeeStatus == models.StatusTerminated {
+		return nil
+	}
+
+	var unchanged bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS(
+			SELECT 1
+			FROM employee_assignments
+			WHERE employee_id = $1 AND ended_on IS NULL AND company_id = $2 AND department_name = $3
+			  AND department_phone = $4 AND position_id IS NOT DISTINCT FROM $5)`,
+		employeeId, current.CompanyId, current.Department.Name, current.Department.Phone, current.PositionId).
+		Scan(&unchanged)
+	if err != nil {
+		return fmt.Errorf("record assignment: query row current assignment: %w", err)
+	}
+	if unchanged {
+		return nil
+	}
+
+	if err = endAssignment(ctx, tx, employeeId, startedOn); err != nil {
+		return fmt.Errorf("record assignment: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO employee_assignments (employee_id, company_id, department_name, department_phone, position_id,
+		                                  started_on, transfer_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		employeeId, current.CompanyId, current.Department.Name, current.Department.Phone, current.PositionId,
+		startedOn, transferId)
+	if err != nil {
+		return fmt.Errorf("record assignment: insert assignment: %w", err)
+	}
+	return nil
+}
+
+// endAssignment ends the current assignment of employeeId on endedOn.
+func endAssignment(ctx context.Context, tx pgx.Tx, employeeId int32, endedOn time.Time) error {
+	_, err := tx.Exec(ctx, "UPDATE employee_assignments SET ended_on = $2 WHERE employee_id = $1 AND ended_on IS NULL",
+		employeeId, endedOn)
+	if err != nil {
+		return fmt.Errorf("end assignment: %w", err)
+	}
+	return nil
+}
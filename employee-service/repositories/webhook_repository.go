@@ -0,0 +1,252 @@
+package repositories
+
+import (
+	"context"
+	"employee-service/encryption"
+	"employee-service/metrics"
+	"employee-service/models"
+	"employee-service/tracing"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+type WebhookRepositoryInterface interface {
+	CreateWebhook(ctx context.Context, webhook models.Webhook) (models.Webhook, error)
+	ListWebhooks(ctx context.Context, companyId int32) ([]models.Webhook, error)
+	DeleteWebhook(ctx context.Context, companyId, id int32) error
+	ListDeliveries(ctx context.Context, companyId, webhookId int32, status string, limit int) ([]models.WebhookDelivery, error)
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.DueDelivery, error)
+	RecordDeliveryResults(ctx context.Context, results []models.DeliveryResult) error
+	EncryptSecrets(ctx context.Context) (int, error)
+}
+
+type WebhookRepository struct {
+	db      *pgxpool.Pool
+	keyring *encryption.Keyring
+}
+
+func NewWebhookRepository(db *pgxpool.Pool, keyring *encryption.Keyring) *WebhookRepository {
+	return &WebhookRepository{db: db, keyring: keyring}
+}
+
+// webhookColumns leaves out the signing secret, which is only read to sign
+// deliveries.
+const webhookColumns = "id, company_id, url, event_types, created_by, created_at"
+
+func scanWebhook(row pgx.Row) (models.Webhook, error) {
+	var webhook models.Webhook
+	err := row.Scan(&webhook.Id, &webhook.CompanyId, &webhook.URL, &webhook.EventTypes, &webhook.CreatedBy,
+		&webhook.CreatedAt)
+	if err != nil {
+		return models.Webhook{}, fmt.Errorf("scan webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+func (r *WebhookRepository) CreateWebhook(ctx context.Context, webhook models.Webhook) (models.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.CreateWebhook")
+	defer span.End()
+	defer metrics.ObserveQuery("create_webhook", time.Now())
+
+	secret, err := r.keyring.Encrypt(webhook.Secret)
+	if err != nil {
+		return models.Webhook{}, fmt.Errorf("webhook_repo: create_webhook: encrypt secret: %w", err)
+	}
+
+	row := r.db.QueryRow(ctx, `
+		INSERT INTO webhooks (company_id, url, event_types, secret_ciphertext, secret_dek, secret_key_id, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+webhookColumns,
+		webhook.CompanyId, webhook.URL, webhook.EventTypes, secret.Ciphertext, secret.WrappedKey, secret.KeyID,
+		webhook.CreatedBy)
+	created, err := scanWebhook(row)
+	if err != nil {
+		return models.Webhook{}, fmt.Errorf("webhook_repo: create_webhook: %w", err)
+	}
+	created.Secret = webhook.Secret
+	return created, nil
+}
+
+func (r *WebhookRepository) ListWebhooks(ctx context.Context, companyId int32) ([]models.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.ListWebhooks")
+	defer span.End()
+	defer metrics.ObserveQuery("list_webhooks", time.Now())
+
+	rows, err := r.db.Query(ctx, "SELECT "+webhookColumns+" FROM webhooks WHERE company_id = $1 ORDER BY id", companyId)
+	if err != nil {
+		return nil, fmt.Errorf("webhook_repo: list_webhooks: query: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []models.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("webhook_repo: list_webhooks: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("webhook_repo: list_webhooks: rows: %w", err)
+	}
+	return webhooks, nil
+}
+
+// DeleteWebhook removes the webhook together with its delivery log.
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, companyId, id int32) error {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.DeleteWebhook")
+	defer span.End()
+	defer metrics.ObserveQuery("delete_webhook", time.Now())
+
+	tag, err := r.db.Exec(ctx, "DELETE FROM webhooks WHERE id = $1 AND company_id = $2", id, companyId)
+	if err != nil {
+		return fmt.Errorf("webhook_repo: delete_webhook: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("webhook_repo: delete_webhook: %w", ErrWebhookNotFound)
+	}
+	return nil
+}
+
+// ListDeliveries returns the latest deliveries of a webhook of the company,
+// newest first. An empty status returns deliveries in every state.
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, companyId, webhookId int32, status string, limit int) ([]models.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.ListDeliveries")
+	defer span.End()
+	defer metrics.ObserveQuery("list_webhook_deliveries", time.Now())
+
+	var exists bool
+	err := r.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM webhooks WHERE id = $1 AND company_id = $2)",
+		webhookId, companyId).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("webhook_repo: list_deliveries: query row webhook: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("webhook_repo: list_deliveries: %w", ErrWebhookNotFound)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+		       last_status_code, last_error, created_at, delivered_at
+		FROM webhook_deliveries
+		WHERE webhook_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY id DESC
+		LIMIT $3`, webhookId, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("webhook_repo: list_deliveries: query: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		err = rows.Scan(&delivery.Id, &delivery.WebhookId, &delivery.EventId, &delivery.EventType, &delivery.Payload,
+			&delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt, &delivery.LastStatusCode,
+			&delivery.LastError, &delivery.CreatedAt, &delivery.DeliveredAt)
+		if err != nil {
+			return nil, fmt.Errorf("webhook_repo: list_deliveries: scan: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("webhook_repo: list_deliveries: rows: %w", err)
+	}
+	return deliveries, nil
+}
+
+// ClaimDueDeliveries returns up to limit pending deliveries whose next attempt
+// is due, with the decrypted secrets of their webhooks. The claim commits
+// right away and postpones the next attempt by lease, so that concurrent
+// dispatchers skip the deliveries while they are sent, and a dispatcher that
+// stops before recording the results only delays them.
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.DueDelivery, error) {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.ClaimDueDeliveries")
+	defer span.End()
+	defer metrics.ObserveQuery("claim_due_webhook_deliveries", time.Now())
+
+	rows, err := r.db.Query(ctx, `
+		WITH due AS (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE status = $1 AND next_attempt_at <= now()
+			ORDER BY next_attempt_at, id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED)
+		UPDATE webhook_deliveries AS d
+		SET next_attempt_at = now() + make_interval(secs => $3)
+		FROM due, webhooks AS w
+		WHERE d.id = due.id AND w.id = d.webhook_id
+		RETURNING d.id, d.event_id, d.event_type, d.payload, d.attempts, d.created_at,
+		          w.id, w.company_id, w.url, w.secret, w.secret_ciphertext, w.secret_dek, w.secret_key_id`,
+		models.DeliveryPending, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("webhook_repo: claim_due: query: %w", err)
+	}
+	defer rows.Close()
+
+	var batch []models.DueDelivery
+	for rows.Next() {
+		var item models.DueDelivery
+		var secret storedWebhookSecret
+		err = rows.Scan(&item.Delivery.Id, &item.Delivery.EventId, &item.Delivery.EventType, &item.Delivery.Payload,
+			&item.Delivery.Attempts, &item.Delivery.CreatedAt,
+			&item.Webhook.Id, &item.Webhook.CompanyId, &item.Webhook.URL,
+			&secret.plaintext, &secret.ciphertext, &secret.dek, &secret.keyId)
+		if err != nil {
+			return nil, fmt.Errorf("webhook_repo: claim_due: scan: %w", err)
+		}
+		if item.Webhook.Secret, err = r.decryptSecret(secret); err != nil {
+			return nil, fmt.Errorf("webhook_repo: claim_due: webhook %d: %w", item.Webhook.Id, err)
+		}
+		item.Delivery.WebhookId = item.Webhook.Id
+		batch = append(batch, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("webhook_repo: claim_due: rows: %w", err)
+	}
+	return batch, nil
+}
+
+// RecordDeliveryResults stores the results of claimed deliveries. A result is
+// ignored when the delivery was meanwhile claimed again after its lease ran
+// out, or deleted.
+func (r *WebhookRepository) RecordDeliveryResults(ctx context.Context, results []models.DeliveryResult) error {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.RecordDeliveryResults")
+	defer span.End()
+	defer metrics.ObserveQuery("record_webhook_delivery_results", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("webhook_repo: record_results: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, result := range results {
+		var statusCode *int32
+		if result.Attempt.StatusCode != 0 {
+			statusCode = &result.Attempt.StatusCode
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE webhook_deliveries
+			SET status = $1, attempts = $2, last_status_code = $3, last_error = $4,
+			    next_attempt_at = CASE WHEN $1 = $5 THEN now() + make_interval(secs => $6) ELSE next_attempt_at END,
+			    delivered_at = CASE WHEN $1 = $7 THEN now() END
+			WHERE id = $8 AND status = $5 AND attempts = $2 - 1`,
+			result.Status, result.Attempts, statusCode, result.Attempt.Error, models.DeliveryPending,
+			result.RetryIn.Seconds(), models.DeliveryDelivered, result.DeliveryId)
+		if err != nil {
+			return fmt.Errorf("webhook_repo: record_results: delivery %d: %w", result.DeliveryId, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("webhook_repo: record_results: commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"employee-service/encryption"
+	"employee-service/metrics"
+	"employee-service/tracing"
+	"fmt"
+	"time"
+)
+
+// storedWebhookSecret holds the column values read for a signing secret.
+// plaintext is only set for webhooks created before secrets were encrypted
+// and not yet processed by EncryptSecrets.
+type storedWebhookSecret struct {
+	plaintext  *string
+	ciphertext []byte
+	dek        []byte
+	keyId      *string
+}
+
+func (r *WebhookRepository) decryptSecret(secret storedWebhookSecret) (string, error) {
+	if secret.keyId == nil {
+		if secret.plaintext == nil {
+			return "", nil
+		}
+		return *secret.plaintext, nil
+	}
+
+	plaintext, err := r.keyring.Decrypt(encryption.Envelope{
+		KeyID:      *secret.keyId,
+		WrappedKey: secret.dek,
+		Ciphertext: secret.ciphertext,
+	})
+	if err != nil {
+		return "", fmt.Errorf("decrypt webhook secret: %w", err)
+	}
+	return plaintext, nil
+}
+
+// EncryptSecrets encrypts signing secrets still stored in plaintext and
+// re-wraps the data keys of secrets encrypted with a retired keyring key. It
+// returns the number of webhooks changed and is safe to run repeatedly.
+func (r *WebhookRepository) EncryptSecrets(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.EncryptSecrets")
+	defer span.End()
+	defer metrics.ObserveQuery("encrypt_webhook_secrets", time.Now())
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("webhook_repo: encrypt_secrets: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT id, secret, secret_ciphertext, secret_dek, secret_key_id
+		FROM webhooks
+		WHERE secret IS NOT NULL OR secret_key_id <> $1
+		FOR UPDATE`, r.keyring.ActiveKeyID())
+	if err != nil {
+		return 0, fmt.Errorf("webhook_repo: encrypt_secrets: query: %w", err)
+	}
+
+	type pending struct {
+		id     int32
+		secret storedWebhookSecret
+	}
+	var webhooks []pending
+	for rows.Next() {
+		var webhook pending
+		err = rows.Scan(&webhook.id, &webhook.secret.plaintext, &webhook.secret.ciphertext, &webhook.secret.dek,
+			&webhook.secret.keyId)
+		if err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("webhook_repo: encrypt_secrets: scan: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("webhook_repo: encrypt_secrets: rows: %w", err)
+	}
+
+	for _, webhook := range webhooks {
+		var envelope encryption.Envelope
+		if webhook.secret.keyId != nil {
+			envelope, err = r.keyring.Rewrap(encryption.Envelope{
+				KeyID:      *webhook.secret.keyId,
+				WrappedKey: webhook.secret.dek,
+				Ciphertext: webhook.secret.ciphertext,
+			})
+		} else {
+			envelope, err = r.keyring.Encrypt(*webhook.secret.plaintext)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("webhook_repo: encrypt_secrets: webhook %d: %w", webhook.id, err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE webhooks
+			SET secret = NULL, secret_ciphertext = $1, secret_dek = $2, secret_key_id = $3
+			WHERE id = $4`,
+			envelope.Ciphertext, envelope.WrappedKey, envelope.KeyID, webhook.id)
+		if err != nil {
+			return 0, fmt.Errorf("webhook_repo: encrypt_secrets: update webhook %d: %w", webhook.id, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("webhook_repo: encrypt_secrets: commit transaction: %w", err)
+	}
+
+	return len(webhooks), nil
+}
@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+	"time"
+)
+
+// PgxLogger turns the per-statement log events of pgx v4, which has no tracing
+// hooks of its own, into client spans. Events are emitted once a statement has
+// finished, so the span start is back-dated by the reported duration. Query
+// arguments are never recorded.
+//
+// The connection config must use LogLevel pgx.LogLevelInfo or lower for
+// successful statements to be reported.
+type PgxLogger struct{}
+
+func (PgxLogger) Log(ctx context.Context, level pgx.LogLevel, msg string, data map[string]interface{}) {
+	switch msg {
+	case "Query", "Exec", "SendBatch":
+	default:
+		return
+	}
+
+	end := time.Now()
+	duration, _ := data["time"].(time.Duration)
+	sql, _ := data["sql"].(string)
+
+	spanName := msg
+	if fields := strings.Fields(sql); len(fields) > 0 {
+		spanName = strings.ToUpper(fields[0])
+	}
+
+	_, span := otel.Tracer(tracerName).Start(ctx, spanName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(end.Add(-duration)),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperationName(spanName),
+			semconv.DBQueryText(strings.Join(strings.Fields(sql), " "))))
+
+	if err, ok := data["err"].(error); ok {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End(trace.WithTimestamp(end))
+}
@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"os"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+	ExporterOTLP   = "otlp"
+)
+
+type Config struct {
+	ServiceName string
+	// Exporter is one of ExporterNone, ExporterStdout, ExporterFile or
+	// ExporterOTLP.
+	Exporter     string
+	OTLPEndpoint string
+	FilePath     string
+}
+
+// Setup installs the global tracer provider and the W3C trace context
+// propagator. The returned function flushes pending spans and must be called
+// before the process exits.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+
+	if cfg.Exporter == "" || cfg.Exporter == ExporterNone {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: %w", err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(cfg.ServiceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK())
+	if err != nil {
+		return nil, fmt.Errorf("tracing: resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case ExporterStdout:
+		return stdouttrace.New()
+	case ExporterFile:
+		file, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("open trace file: %w", err)
+		}
+		return stdouttrace.New(stdouttrace.WithWriter(file))
+	case ExporterOTLP:
+		return otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint), otlptracegrpc.WithInsecure())
+	default:
+		return nil, fmt.Errorf("unknown exporter %q", cfg.Exporter)
+	}
+}
+
+const tracerName = "employee-service"
+
+// Start starts a span as a child of the span in ctx.
+func Start(ctx context.Context, name string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name)
+}
@@ -0,0 +1,82 @@
+package transfers
+
+import (
+	"context"
+	"employee-service/models"
+	"employee-service/repositories"
+	"log/slog"
+	"time"
+)
+
+// Scheduler applies pending transfers and scheduled terminations once they
+// become effective. Both are dated by day, so checking every few minutes
+// applies them on time.
+type Scheduler struct {
+	employees *repositories.EmployeeRepository
+	interval  time.Duration
+	batchSize int
+}
+
+func NewScheduler(employees *repositories.EmployeeRepository, interval time.Duration, batchSize int) *Scheduler {
+	return &Scheduler{employees: employees, interval: interval, batchSize: batchSize}
+}
+
+// Run applies due transfers, then due terminations, every interval until ctx
+// is done. Terminations only run once every due transfer is applied, so that
+// those due by the termination date are not cancelled. A full batch is
+// followed immediately by the next one.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		more, err := s.applyTransfers(ctx)
+		if err == nil && !more {
+			more, err = s.applyTerminations(ctx)
+		}
+
+		if err == nil && more {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// applyTransfers applies a batch of due transfers and reports whether more
+// may be due.
+func (s *Scheduler) applyTransfers(ctx context.Context) (bool, error) {
+	processed, err := s.employees.ApplyDueTransfers(ctx, s.batchSize)
+	if err != nil && ctx.Err() == nil {
+		slog.Error("Failed to apply due transfers", "error", err)
+	}
+	for _, transfer := range processed {
+		if transfer.Status == models.TransferFailed {
+			slog.Warn("Transfer rejected", "transfer_id", transfer.Id, "employee_id", transfer.EmployeeId,
+				"failure", transfer.Failure)
+		} else {
+			slog.Info("Transfer applied", "transfer_id", transfer.Id, "employee_id", transfer.EmployeeId)
+		}
+	}
+	return len(processed) == s.batchSize, err
+}
+
+// applyTerminations applies a batch of due terminations and reports whether
+// more may be due.
+func (s *Scheduler) applyTerminations(ctx context.Context) (bool, error) {
+	terminated, err := s.employees.ApplyDueTerminations(ctx, s.batchSize)
+	if err != nil && ctx.Err() == nil {
+		slog.Error("Failed to apply due terminations", "error", err)
+	}
+	for _, id := range terminated {
+		slog.Info("Scheduled termination applied", "employee_id", id)
+	}
+	return len(terminated) == s.batchSize, err
+}
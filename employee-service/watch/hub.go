@@ -0,0 +1,137 @@
+package watch
+
+import (
+	"context"
+	"employee-service/repositories"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const maxReconnectBackoff = 10 * time.Second
+
+// Hub listens for change notifications on a dedicated database connection and
+// wakes up the watchers of the company that changed. Notifications only say
+// that something may have changed; watchers read the change feed themselves.
+type Hub struct {
+	pool *pgxpool.Pool
+
+	mu       sync.Mutex
+	watchers map[int32]map[chan struct{}]bool
+	done     chan struct{}
+}
+
+func NewHub(pool *pgxpool.Pool) *Hub {
+	return &Hub{pool: pool, watchers: make(map[int32]map[chan struct{}]bool), done: make(chan struct{})}
+}
+
+// Subscribe returns a channel that receives a value whenever the company may
+// have new changes, and a function that stops the subscription.
+func (h *Hub) Subscribe(companyId int32) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	h.mu.Lock()
+	if h.watchers[companyId] == nil {
+		h.watchers[companyId] = make(map[chan struct{}]bool)
+	}
+	h.watchers[companyId][ch] = true
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		delete(h.watchers[companyId], ch)
+		if len(h.watchers[companyId]) == 0 {
+			delete(h.watchers, companyId)
+		}
+	}
+}
+
+// Done is closed once Run returns, so open streams can end before the server
+// stops.
+func (h *Hub) Done() <-chan struct{} {
+	return h.done
+}
+
+// Run listens until ctx is done, reconnecting after connection failures.
+func (h *Hub) Run(ctx context.Context) {
+	defer close(h.done)
+
+	backoff := 500 * time.Millisecond
+	for {
+		err := h.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		slog.Warn("Change notifications interrupted, reconnecting", "backoff", backoff.String(), "error", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxReconnectBackoff)
+	}
+}
+
+func (h *Hub) listen(ctx context.Context) error {
+	pooled, err := h.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The listening connection never goes back to the pool.
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err = conn.Exec(ctx, "LISTEN "+repositories.ChangesChannel); err != nil {
+		return err
+	}
+
+	// Notifications sent while disconnected are lost.
+	h.notifyAll()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		companyId, err := strconv.ParseInt(notification.Payload, 10, 32)
+		if err != nil {
+			slog.Warn("Ignoring malformed change notification", "payload", notification.Payload)
+			continue
+		}
+		h.notify(int32(companyId))
+	}
+}
+
+func (h *Hub) notify(companyId int32) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.watchers[companyId] {
+		wake(ch)
+	}
+}
+
+func (h *Hub) notifyAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, watchers := range h.watchers {
+		for ch := range watchers {
+			wake(ch)
+		}
+	}
+}
+
+// wake never blocks: a pending wake-up already makes the watcher read the feed.
+func wake(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,123 @@
+package webhooks
+
+import (
+	"context"
+	"employee-service/models"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	retryBaseDelay = 10 * time.Second
+	retryMaxDelay  = time.Hour
+)
+
+// DeliveryStore claims due deliveries and records the results of sending
+// them.
+type DeliveryStore interface {
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.DueDelivery, error)
+	RecordDeliveryResults(ctx context.Context, results []models.DeliveryResult) error
+}
+
+// Dispatcher sends due webhook deliveries every interval.
+type Dispatcher struct {
+	store       DeliveryStore
+	sender      *Sender
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int32
+	lease       time.Duration
+}
+
+// NewDispatcher returns a dispatcher that claims batches of batchSize
+// deliveries. lease must cover sending a whole batch; deliveries not recorded
+// by then are sent again.
+func NewDispatcher(store DeliveryStore, sender *Sender, interval time.Duration, batchSize int, maxAttempts int32,
+	lease time.Duration) *Dispatcher {
+	return &Dispatcher{store: store, sender: sender, interval: interval, batchSize: batchSize,
+		maxAttempts: maxAttempts, lease: lease}
+}
+
+// Run dispatches until ctx is done. A full batch is followed immediately by
+// the next one.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		processed, err := d.dispatch(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("Failed to dispatch webhook deliveries", "error", err)
+		}
+
+		if err == nil && processed == d.batchSize {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch claims a batch, sends it without holding a transaction open and
+// records the results. It returns the number of deliveries claimed.
+func (d *Dispatcher) dispatch(ctx context.Context) (int, error) {
+	batch, err := d.store.ClaimDueDeliveries(ctx, d.batchSize, d.lease)
+	if err != nil {
+		return 0, fmt.Errorf("webhooks: dispatch: %w", err)
+	}
+	if len(batch) == 0 {
+		return 0, nil
+	}
+
+	results := make([]models.DeliveryResult, 0, len(batch))
+	for _, item := range batch {
+		// Deliveries left unsent on shutdown are retried once the lease ends.
+		if ctx.Err() != nil {
+			break
+		}
+		results = append(results, d.result(item.Delivery, d.sender.Deliver(ctx, item.Webhook, item.Delivery)))
+	}
+
+	if err = d.store.RecordDeliveryResults(context.WithoutCancel(ctx), results); err != nil {
+		return 0, fmt.Errorf("webhooks: dispatch: %w", err)
+	}
+	return len(batch), nil
+}
+
+// result applies the retry policy to an attempt of the delivery.
+func (d *Dispatcher) result(delivery models.WebhookDelivery, attempt models.DeliveryAttempt) models.DeliveryResult {
+	result := models.DeliveryResult{DeliveryId: delivery.Id, Attempts: delivery.Attempts + 1, Attempt: attempt}
+
+	switch delay, retryable := d.retryPolicy(result.Attempts); {
+	case attempt.Delivered:
+		result.Status = models.DeliveryDelivered
+	case retryable:
+		result.Status = models.DeliveryPending
+		result.RetryIn = delay
+	default:
+		result.Status = models.DeliveryDead
+	}
+	return result
+}
+
+// retryPolicy doubles the delay after every failed attempt, starting at
+// retryBaseDelay and capped at retryMaxDelay, until maxAttempts is reached.
+func (d *Dispatcher) retryPolicy(attempts int32) (time.Duration, bool) {
+	if attempts >= d.maxAttempts {
+		return 0, false
+	}
+
+	delay := retryBaseDelay
+	for i := int32(1); i < attempts && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, retryMaxDelay), true
+}
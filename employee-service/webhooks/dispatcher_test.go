@@ -0,0 +1,125 @@
+package webhooks
+
+import (
+	"context"
+	"employee-service/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeStore hands out the pending deliveries once and keeps the results.
+type fakeStore struct {
+	due     []models.DueDelivery
+	lease   time.Duration
+	results []models.DeliveryResult
+}
+
+func (s *fakeStore) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.DueDelivery, error) {
+	s.lease = lease
+	claimed := s.due[:min(limit, len(s.due))]
+	s.due = s.due[len(claimed):]
+	return claimed, nil
+}
+
+func (s *fakeStore) RecordDeliveryResults(ctx context.Context, results []models.DeliveryResult) error {
+	s.results = append(s.results, results...)
+	return nil
+}
+
+func newTestDispatcher(store *fakeStore, client *http.Client) *Dispatcher {
+	return NewDispatcher(store, NewSender(client), time.Second, 10, 3, time.Minute)
+}
+
+func dueDelivery(url string, id int64, attempts int32) models.DueDelivery {
+	return models.DueDelivery{
+		Webhook:  models.Webhook{Id: 1, URL: url, Secret: "whsec_test"},
+		Delivery: models.WebhookDelivery{Id: id, WebhookId: 1, EventId: id, Attempts: attempts, Payload: []byte(`{}`)},
+	}
+}
+
+func TestDispatchRecordsDeliveredAndRetried(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(DeliveryHeader) == "1" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	store := &fakeStore{due: []models.DueDelivery{dueDelivery(server.URL, 1, 0), dueDelivery(server.URL, 2, 1)}}
+	processed, err := newTestDispatcher(store, server.Client()).dispatch(context.Background())
+	if err != nil {
+		t.Fatalf("dispatch() error = %v", err)
+	}
+	if processed != 2 || len(store.results) != 2 {
+		t.Fatalf("dispatch() = %d with %d results, want 2 and 2", processed, len(store.results))
+	}
+	if store.lease != time.Minute {
+		t.Errorf("claimed with lease %v, want %v", store.lease, time.Minute)
+	}
+
+	delivered := store.results[0]
+	if delivered.DeliveryId != 1 || delivered.Status != models.DeliveryDelivered || delivered.Attempts != 1 {
+		t.Errorf("result of delivery 1 = %+v, want delivered after 1 attempt", delivered)
+	}
+
+	retried := store.results[1]
+	if retried.DeliveryId != 2 || retried.Status != models.DeliveryPending || retried.Attempts != 2 ||
+		retried.RetryIn != 2*retryBaseDelay || retried.Attempt.StatusCode != http.StatusInternalServerError {
+		t.Errorf("result of delivery 2 = %+v, want pending after 2 attempts, retried in %v",
+			retried, 2*retryBaseDelay)
+	}
+}
+
+func TestDispatchDeadLettersAfterMaxAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	store := &fakeStore{due: []models.DueDelivery{dueDelivery(server.URL, 1, 2)}}
+	if _, err := newTestDispatcher(store, server.Client()).dispatch(context.Background()); err != nil {
+		t.Fatalf("dispatch() error = %v", err)
+	}
+
+	if len(store.results) != 1 {
+		t.Fatalf("got %d results, want 1", len(store.results))
+	}
+	if dead := store.results[0]; dead.Status != models.DeliveryDead || dead.Attempts != 3 || dead.Attempt.Error == "" {
+		t.Errorf("result = %+v, want dead after 3 attempts with the error", dead)
+	}
+}
+
+func TestDispatchWithoutDueDeliveries(t *testing.T) {
+	store := &fakeStore{}
+	processed, err := newTestDispatcher(store, http.DefaultClient).dispatch(context.Background())
+	if err != nil || processed != 0 || store.results != nil {
+		t.Errorf("dispatch() = %d, %v with results %v, want nothing recorded", processed, err, store.results)
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	dispatcher := NewDispatcher(&fakeStore{}, nil, time.Second, 10, 12, time.Minute)
+
+	tests := []struct {
+		attempts  int32
+		delay     time.Duration
+		retryable bool
+	}{
+		{attempts: 1, delay: retryBaseDelay, retryable: true},
+		{attempts: 2, delay: 2 * retryBaseDelay, retryable: true},
+		{attempts: 3, delay: 4 * retryBaseDelay, retryable: true},
+		{attempts: 11, delay: retryMaxDelay, retryable: true},
+		{attempts: 12, retryable: false},
+	}
+	for _, test := range tests {
+		delay, retryable := dispatcher.retryPolicy(test.attempts)
+		if delay != test.delay || retryable != test.retryable {
+			t.Errorf("retryPolicy(%d) = %v, %t, want %v, %t", test.attempts, delay, retryable, test.delay,
+				test.retryable)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"employee-service/models"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+
+	maxErrorLength = 512
+)
+
+// Sender posts deliveries to webhook endpoints. The body is signed with the
+// webhook secret: SignatureHeader is "t=<unix time>,v1=<hex HMAC-SHA256 of
+// "<unix time>.<body>">", so receivers can reject replayed requests.
+type Sender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewSender(client *http.Client) *Sender {
+	return &Sender{client: client, now: time.Now}
+}
+
+// payload is the request body of a delivery. Id is the event id and stays the
+// same across retries, so receivers can deduplicate.
+type payload struct {
+	Id         int64           `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+func (s *Sender) Deliver(ctx context.Context, webhook models.Webhook, delivery models.WebhookDelivery) models.DeliveryAttempt {
+	body, err := json.Marshal(payload{
+		Id:         delivery.EventId,
+		Type:       delivery.EventType,
+		OccurredAt: delivery.CreatedAt.UTC(),
+		Data:       delivery.Payload,
+	})
+	if err != nil {
+		return models.DeliveryAttempt{Error: fmt.Sprintf("marshal payload: %v", err)}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return models.DeliveryAttempt{Error: fmt.Sprintf("new request: %v", err)}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "employee-service-webhooks")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.Id, 10))
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, s.now(), body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return models.DeliveryAttempt{Error: truncate(err.Error())}
+	}
+	defer resp.Body.Close()
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorLength))
+
+	attempt := models.DeliveryAttempt{StatusCode: int32(resp.StatusCode)}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		attempt.Delivered = true
+	} else {
+		attempt.Error = truncate(fmt.Sprintf("status %d: %s", resp.StatusCode, bytes.TrimSpace(message)))
+	}
+	return attempt
+}
+
+// Sign returns the SignatureHeader value for body sent at t.
+func Sign(secret string, t time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewSecret returns a random signing secret.
+func NewSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("webhooks: new secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(secret), nil
+}
+
+func truncate(message string) string {
+	if len(message) > maxErrorLength {
+		return message[:maxErrorLength]
+	}
+	return message
+}
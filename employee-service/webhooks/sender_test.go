@@ -0,0 +1,94 @@
+package webhooks
+
+import (
+	"context"
+	"employee-service/models"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSenderSignsDelivery(t *testing.T) {
+	sentAt := time.Unix(1700000000, 0)
+	var header http.Header
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := NewSender(server.Client())
+	sender.now = func() time.Time { return sentAt }
+
+	attempt := sender.Deliver(context.Background(), models.Webhook{URL: server.URL, Secret: "whsec_test"},
+		models.WebhookDelivery{Id: 7, EventId: 42, EventType: models.EventEmployeeUpdated,
+			Payload: []byte(`{"employee_id":1}`), CreatedAt: sentAt})
+
+	if !attempt.Delivered || attempt.StatusCode != http.StatusNoContent || attempt.Error != "" {
+		t.Fatalf("Deliver() = %+v, want delivered with status 204", attempt)
+	}
+	if got, want := header.Get(SignatureHeader), Sign("whsec_test", sentAt, body); got != want {
+		t.Errorf("%s = %q, want %q", SignatureHeader, got, want)
+	}
+	if got := header.Get(SignatureHeader); !strings.HasPrefix(got, "t=1700000000,") {
+		t.Errorf("%s = %q, want the send time first", SignatureHeader, got)
+	}
+	if got := header.Get(EventHeader); got != models.EventEmployeeUpdated {
+		t.Errorf("%s = %q, want %q", EventHeader, got, models.EventEmployeeUpdated)
+	}
+	if got := header.Get(DeliveryHeader); got != "7" {
+		t.Errorf("%s = %q, want %q", DeliveryHeader, got, "7")
+	}
+
+	var sent payload
+	if err := json.Unmarshal(body, &sent); err != nil {
+		t.Fatalf("unmarshal body %s: %v", body, err)
+	}
+	if sent.Id != 42 || sent.Type != models.EventEmployeeUpdated || string(sent.Data) != `{"employee_id":1}` {
+		t.Errorf("body = %s, want event 42 with the delivery payload", body)
+	}
+}
+
+func TestSignDependsOnSecretAndTime(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	sentAt := time.Unix(1700000000, 0)
+
+	signature := Sign("whsec_a", sentAt, body)
+	if signature != Sign("whsec_a", sentAt, body) {
+		t.Fatal("Sign() is not deterministic")
+	}
+	if signature == Sign("whsec_b", sentAt, body) {
+		t.Error("Sign() does not depend on the secret")
+	}
+	if signature == Sign("whsec_a", sentAt.Add(time.Second), body) {
+		t.Error("Sign() does not depend on the time")
+	}
+	if signature == Sign("whsec_a", sentAt, []byte(`{"id":2}`)) {
+		t.Error("Sign() does not depend on the body")
+	}
+}
+
+func TestSenderReportsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	sender := NewSender(server.Client())
+
+	attempt := sender.Deliver(context.Background(), models.Webhook{URL: server.URL}, models.WebhookDelivery{})
+	if attempt.Delivered || attempt.StatusCode != http.StatusServiceUnavailable ||
+		attempt.Error != "status 503: unavailable" {
+		t.Errorf("Deliver() = %+v, want status 503 with the response body", attempt)
+	}
+
+	server.Close()
+	attempt = sender.Deliver(context.Background(), models.Webhook{URL: server.URL}, models.WebhookDelivery{})
+	if attempt.Delivered || attempt.StatusCode != 0 || attempt.Error == "" {
+		t.Errorf("Deliver() to a closed server = %+v, want an error without status", attempt)
+	}
+}
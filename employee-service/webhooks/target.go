@@ -0,0 +1,67 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenTarget is returned for webhook URLs that resolve to loopback,
+// private, link-local or otherwise non-public addresses, so that webhooks
+// cannot be used to reach services inside the network.
+var ErrForbiddenTarget = errors.New("webhook target is not a public address")
+
+// sharedAddressSpace is the carrier-grade NAT range, which net.IP does not
+// report as private.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// CheckTarget resolves host and fails with ErrForbiddenTarget unless every
+// address it resolves to is public.
+func CheckTarget(ctx context.Context, host string) error {
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("webhooks: check target: resolve %q: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if !publicIP(addr.IP) {
+			return fmt.Errorf("webhooks: check target: %q resolves to %s: %w", host, addr.IP, ErrForbiddenTarget)
+		}
+	}
+	return nil
+}
+
+// NewClient returns an HTTP client for deliveries that refuses to connect to
+// non-public addresses. The check runs on every connection, so it also covers
+// redirects and hosts whose DNS records changed after registration.
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: checkDialAddress}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConnsPerHost: 2,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
+
+func checkDialAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("webhooks: dial %s: %w", address, err)
+	}
+	if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+		return fmt.Errorf("webhooks: dial %s: %w", address, ErrForbiddenTarget)
+	}
+	return nil
+}
+
+func publicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified() && !sharedAddressSpace.Contains(ip)
+}
@@ -0,0 +1,52 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCheckTarget(t *testing.T) {
+	tests := []struct {
+		host      string
+		forbidden bool
+	}{
+		{host: "127.0.0.1", forbidden: true},
+		{host: "::1", forbidden: true},
+		{host: "10.1.2.3", forbidden: true},
+		{host: "172.16.0.1", forbidden: true},
+		{host: "192.168.1.1", forbidden: true},
+		{host: "169.254.169.254", forbidden: true},
+		{host: "fe80::1", forbidden: true},
+		{host: "fd00::1", forbidden: true},
+		{host: "100.64.0.1", forbidden: true},
+		{host: "0.0.0.0", forbidden: true},
+		{host: "::ffff:127.0.0.1", forbidden: true},
+		{host: "93.184.216.34", forbidden: false},
+		{host: "2606:4700::1111", forbidden: false},
+	}
+	for _, test := range tests {
+		err := CheckTarget(context.Background(), test.host)
+		if forbidden := errors.Is(err, ErrForbiddenTarget); forbidden != test.forbidden {
+			t.Errorf("CheckTarget(%q) = %v, want forbidden %t", test.host, err, test.forbidden)
+		}
+	}
+}
+
+func TestClientRefusesLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached the loopback server")
+	}))
+	defer server.Close()
+
+	resp, err := NewClient(time.Second).Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+	}
+	if !errors.Is(err, ErrForbiddenTarget) {
+		t.Errorf("Get(%s) error = %v, want %v", server.URL, err, ErrForbiddenTarget)
+	}
+}